@@ -0,0 +1,120 @@
+package idgenerator
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// AtomicGenerator generates unique Snowflake IDs without a lock.
+//
+// The last timestamp and the sequence number are packed into a single word and updated by compare-and-swap,
+// which is faster than Generator under high concurrency when the datacenter ID and the machine ID are fixed.
+// When the sequence number is exhausted, AtomicGenerator moves on to the next millisecond without waiting,
+// so under a sustained load of more IDs than the sequence number allows, the timestamps run ahead of the clock.
+// If the clock moves backward, AtomicGenerator keeps using the last timestamp.
+//
+// AtomicGenerator supports the options that fix the fields of the ID, such as WithDatacenterID, WithLayout, WithBaseTime,
+// WithReservedBit, and WithClock. The options that change how the ID is derived, WithObfuscation, WithSequenceProvider,
+// and WithSequenceNumber, are not supported.
+// The options about the behavior of Generator, such as WithMetrics and WithInterceptor, are ignored.
+// An AtomicGenerator is safe for concurrent use by multiple goroutines.
+type AtomicGenerator struct {
+	datacenterID int
+	machineID    int
+	baseTime     time.Time
+	layout       Layout
+	clock        ClockSource
+	reservedBit  SnowflakeID
+
+	// state is the last timestamp shifted by the sequence bits, ORed with the sequence number.
+	state atomic.Uint64
+}
+
+var _ IDGenerator = (*AtomicGenerator)(nil)
+
+// NewAtomicGenerator returns a new AtomicGenerator.
+// As with NewGenerator, WithTimestamp and WithShardCount return ErrUnsupportedOption.
+// WithObfuscation, WithSequenceProvider, and WithSequenceNumber also return ErrUnsupportedOption,
+// because the sequence number is managed by compare-and-swap and the IDs are returned as is.
+func NewAtomicGenerator(opts ...Option) (*AtomicGenerator, error) {
+	s := &snowflake{}
+	for _, f := range opts {
+		if err := f(s); err != nil {
+			return nil, err
+		}
+	}
+	if s.timestamp != 0 || s.shardCount != 0 {
+		return nil, ErrUnsupportedOption
+	}
+	if s.obfuscation || s.sequenceProvider != nil || s.sequenceNumber != 0 {
+		return nil, ErrUnsupportedOption
+	}
+
+	layout := s.getLayout()
+	if s.random {
+		if s.datacenterID == 0 {
+			s.datacenterID = rand.Intn(layout.maxDatacenterID() + 1)
+		}
+		if s.machineID == 0 {
+			s.machineID = rand.Intn(layout.maxMachineID() + 1)
+		}
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	return &AtomicGenerator{
+		datacenterID: s.datacenterID,
+		machineID:    s.machineID,
+		baseTime:     s.getBaseTime(),
+		layout:       layout,
+		clock:        s.getClock(),
+		reservedBit:  s.getReservedBit(),
+	}, nil
+}
+
+// Next returns a new generated Snowflake ID.
+func (g *AtomicGenerator) Next() (SnowflakeID, error) {
+	ts, err := elapsedTimestamp(g.clock.Now().UTC(), g.baseTime, g.layout)
+	if err != nil {
+		return 0, err
+	}
+	maxSeq := uint64(g.layout.maxSequenceNumber())
+	for {
+		old := g.state.Load()
+		lastTs, seq := int64(old>>g.layout.SequenceBits), old&maxSeq
+		next := ts
+		if ts <= lastTs {
+			next, seq = lastTs, seq+1
+			if seq > maxSeq {
+				// Move on to the next millisecond instead of waiting for it.
+				next, seq = lastTs+1, 0
+			}
+		} else {
+			seq = 0
+		}
+		if next > g.layout.maxTimestamp() {
+			return 0, ErrOverLifeTime
+		}
+		if g.state.CompareAndSwap(old, uint64(next)<<g.layout.SequenceBits|seq) {
+			return SnowflakeID(g.layout.compose(next, g.datacenterID, g.machineID, int(seq))) | g.reservedBit, nil
+		}
+	}
+}
+
+// NextN returns n new generated Snowflake IDs in increasing order.
+func (g *AtomicGenerator) NextN(n int) ([]SnowflakeID, error) {
+	if n <= 0 {
+		return nil, ErrInvalidCount
+	}
+	ids := make([]SnowflakeID, n)
+	for i := range ids {
+		id, err := g.Next()
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
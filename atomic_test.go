@@ -0,0 +1,134 @@
+package idgenerator
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewAtomicGenerator(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{"Default", nil, nil},
+		{"WithLayout", []Option{WithSonyflakeLayout(), WithMachineID(1 << 15)}, nil},
+		{"Error WithTimestamp", []Option{WithTimestamp(time.Now())}, ErrUnsupportedOption},
+		{"Error WithShardCount", []Option{WithShardCount(2)}, ErrUnsupportedOption},
+		{"Error WithObfuscation", []Option{WithObfuscation(42)}, ErrUnsupportedOption},
+		{"Error WithSequenceProvider", []Option{WithSequenceProvider(&sharedSequence{})}, ErrUnsupportedOption},
+		{"Error WithSequenceNumber", []Option{WithSequenceNumber(1)}, ErrUnsupportedOption},
+		{"Error invalid machine ID", []Option{WithMachineID(32)}, ErrInvalidMachineID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewAtomicGenerator(tt.opts...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewAtomicGenerator() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAtomicGenerator_Next(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewAtomicGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15))
+	if err != nil {
+		t.Fatalf("NewAtomicGenerator() error = %v", err)
+	}
+	for _, want := range []SnowflakeID{11234023837724672, 11234023837724673} {
+		got, err := g.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("Next() = %v, want %v", got, want)
+		}
+	}
+
+	// The clock moved backward, so the last timestamp is used.
+	c.Advance(-time.Millisecond)
+	got, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if want := SnowflakeID(11234023837724674); got != want {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestAtomicGenerator_Next_SequenceRollover(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewAtomicGenerator(WithClock(c))
+	if err != nil {
+		t.Fatalf("NewAtomicGenerator() error = %v", err)
+	}
+	ids, err := g.NextN(maxSequenceNumber + 2)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	// The clock is frozen, so the timestamp moves on to the next millisecond without waiting.
+	if got, want := ids[len(ids)-1], SnowflakeID(11234023837794304); got != want {
+		t.Errorf("NextN() last = %v, want %v", got, want)
+	}
+	// The next millisecond of the clock continues from the borrowed one.
+	c.Advance(time.Millisecond)
+	got, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if want := SnowflakeID(11234023837794305); got != want {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestAtomicGenerator_Next_Concurrent(t *testing.T) {
+	g, err := NewAtomicGenerator()
+	if err != nil {
+		t.Fatalf("NewAtomicGenerator() error = %v", err)
+	}
+
+	const goroutines, perGoroutine = 16, 1000
+	results := make([][]SnowflakeID, goroutines)
+	var wg sync.WaitGroup
+	for i := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perGoroutine {
+				id, err := g.Next()
+				if err != nil {
+					t.Errorf("Next() error = %v", err)
+					return
+				}
+				results[i] = append(results[i], id)
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[SnowflakeID]bool)
+	for _, ids := range results {
+		for j, id := range ids {
+			if seen[id] {
+				t.Errorf("duplicated ID %v", id)
+			}
+			seen[id] = true
+			// The IDs of each goroutine are in increasing order.
+			if j > 0 && id <= ids[j-1] {
+				t.Errorf("Next() returned %v after %v, want increasing IDs", id, ids[j-1])
+			}
+		}
+	}
+}
+
+func TestAtomicGenerator_NextN_Error(t *testing.T) {
+	g, err := NewAtomicGenerator()
+	if err != nil {
+		t.Fatalf("NewAtomicGenerator() error = %v", err)
+	}
+	if _, err := g.NextN(0); err != ErrInvalidCount {
+		t.Errorf("NextN() error = %v, want %v", err, ErrInvalidCount)
+	}
+}
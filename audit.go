@@ -0,0 +1,81 @@
+package idgenerator
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// AuditEntry is a record of an ID generated by a Generator.
+type AuditEntry struct {
+	ID                   SnowflakeID
+	GeneratedAt          time.Time
+	SequenceAtGeneration int
+
+	// index is the position of the entry in all the recorded entries.
+	index uint64
+}
+
+// WithAuditLog records the last capacity generated IDs in a ring buffer, which can be read by Generator.AuditLog.
+// It helps to know which IDs were generated before a failure.
+func WithAuditLog(capacity int) Option {
+	return func(s *snowflake) error {
+		if capacity <= 0 {
+			return ErrInvalidAuditLogCapacity
+		}
+		s.auditLogCapacity = capacity
+		return nil
+	}
+}
+
+// auditLog is a lock-free ring buffer of AuditEntry.
+type auditLog struct {
+	entries []atomic.Pointer[AuditEntry]
+	// next is the index of the next entry.
+	next atomic.Uint64
+}
+
+// newAuditLog returns an auditLog, or nil if capacity is zero. A nil auditLog records nothing.
+func newAuditLog(capacity int) *auditLog {
+	if capacity == 0 {
+		return nil
+	}
+	return &auditLog{entries: make([]atomic.Pointer[AuditEntry], capacity)}
+}
+
+func (l *auditLog) record(id SnowflakeID, sequenceNumber int) {
+	if l == nil {
+		return
+	}
+	i := l.next.Add(1) - 1
+	l.entries[i%uint64(len(l.entries))].Store(&AuditEntry{
+		ID:                   id,
+		GeneratedAt:          time.Now(),
+		SequenceAtGeneration: sequenceNumber,
+		index:                i,
+	})
+}
+
+func (l *auditLog) snapshot() []AuditEntry {
+	if l == nil {
+		return nil
+	}
+	next := l.next.Load()
+	var first uint64
+	if capacity := uint64(len(l.entries)); next > capacity {
+		first = next - capacity
+	}
+	entries := make([]AuditEntry, 0, next-first)
+	for i := first; i < next; i++ {
+		// Skip the entry being written, or already overwritten by a newer one.
+		if e := l.entries[i%uint64(len(l.entries))].Load(); e != nil && e.index == i {
+			entries = append(entries, *e)
+		}
+	}
+	return entries
+}
+
+// AuditLog returns a snapshot of the IDs recorded by WithAuditLog, the most recent last.
+// It returns nil if WithAuditLog is not specified.
+func (g *Generator) AuditLog() []AuditEntry {
+	return g.audit.snapshot()
+}
@@ -0,0 +1,136 @@
+package idgenerator
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithAuditLog(t *testing.T) {
+	type args struct {
+		capacity int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		generate int
+		want     []SnowflakeID
+		wantErr  bool
+	}{
+		{"Fewer IDs than capacity", args{3}, 2, []SnowflakeID{11234023837724672, 11234023837724673}, false},
+		{"Same IDs as capacity", args{3}, 3, []SnowflakeID{11234023837724672, 11234023837724673, 11234023837724674}, false},
+		{"More IDs than capacity", args{3}, 5, []SnowflakeID{11234023837724674, 11234023837724675, 11234023837724676}, false},
+		{"Error capacity:0", args{0}, 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+			g, err := NewGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15), WithAuditLog(tt.args.capacity))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGenerator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			before := time.Now()
+			if _, err := g.NextN(tt.generate); err != nil {
+				t.Fatalf("NextN() error = %v", err)
+			}
+
+			got := g.AuditLog()
+			if len(got) != len(tt.want) {
+				t.Fatalf("AuditLog() len = %v, want %v", len(got), len(tt.want))
+			}
+			for i, e := range got {
+				if e.ID != tt.want[i] {
+					t.Errorf("AuditLog()[%d].ID = %v, want %v", i, e.ID, tt.want[i])
+				}
+				if want := ExtractSequenceNumber(int64(tt.want[i])); e.SequenceAtGeneration != want {
+					t.Errorf("AuditLog()[%d].SequenceAtGeneration = %v, want %v", i, e.SequenceAtGeneration, want)
+				}
+				if e.GeneratedAt.Before(before) {
+					t.Errorf("AuditLog()[%d].GeneratedAt = %v, want after %v", i, e.GeneratedAt, before)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_AuditLog(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if got := g.AuditLog(); got != nil {
+		t.Errorf("AuditLog() = %v, want nil", got)
+	}
+
+	// Reading the audit log does not block or race with generation.
+	g, err = NewGenerator(WithAuditLog(100))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10000; i++ {
+			if _, err := g.Next(); err != nil {
+				t.Errorf("Next() error = %v", err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			entries := g.AuditLog()
+			for j := 1; j < len(entries); j++ {
+				if entries[j].ID <= entries[j-1].ID {
+					t.Errorf("AuditLog() has %v after %v, want increasing IDs", entries[j].ID, entries[j-1].ID)
+					return
+				}
+			}
+		}
+	}()
+	wg.Wait()
+	if got := len(g.AuditLog()); got != 100 {
+		t.Errorf("AuditLog() len = %v, want %v", got, 100)
+	}
+}
+
+func TestGenerator_AuditLog_AsReturned(t *testing.T) {
+	g, err := NewGenerator(WithAuditLog(10), WithObfuscation(42), WithReservedBit(true))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	ids, err := g.NextN(2)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	want := append([]SnowflakeID{id}, ids...)
+	entries := g.AuditLog()
+	if len(entries) != len(want) {
+		t.Fatalf("AuditLog() len = %v, want %v", len(entries), len(want))
+	}
+	// The audit log records the IDs as returned.
+	for i, e := range entries {
+		if !IsReservedBitSet(e.ID) {
+			t.Errorf("AuditLog()[%d].ID = %v, want the reserved bit set", i, e.ID)
+		}
+		if e.ID != want[i] {
+			t.Errorf("AuditLog()[%d].ID = %v, want %v", i, e.ID, want[i])
+		}
+		if got := g.ExtractSequenceNumber(e.ID); got != e.SequenceAtGeneration {
+			t.Errorf("ExtractSequenceNumber(AuditLog()[%d].ID) = %v, want %v", i, got, e.SequenceAtGeneration)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package idgenerator
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// autoScalingAlpha is the weight of the latest interval in the exponential moving average of the latency.
+	autoScalingAlpha = 0.3
+)
+
+// autoScalingInterval is how often an AutoScalingGeneratorPool scales, which is replaced in tests.
+var autoScalingInterval = 100 * time.Millisecond
+
+// AutoScalingGeneratorPool is a GeneratorPool whose number of active shards follows the load.
+//
+// It starts with the minimum number of shards. In a background goroutine, it keeps the exponential moving average
+// of the generation latency, which grows with the lock contention of the shards. When the average exceeds the threshold,
+// the number of shards is doubled up to the maximum, and when it drops below half of the threshold, it is halved down to the minimum.
+//
+// All the shards up to the maximum are created up front with distinct machine IDs, as with NewGeneratorPool,
+// and a shard that is deactivated keeps its state, so the IDs stay unique when it is activated again.
+// Call Close to stop the background goroutine.
+// An AutoScalingGeneratorPool is safe for concurrent use by multiple goroutines.
+type AutoScalingGeneratorPool struct {
+	shards    []*Generator
+	stats     []shardStats
+	minShards int
+	threshold time.Duration
+
+	current atomic.Int64
+	// latency is the exponential moving average of the latency in nanoseconds.
+	latency atomic.Int64
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// shardStats accumulates the latency of a shard during an interval.
+type shardStats struct {
+	sum   atomic.Int64
+	count atomic.Int64
+}
+
+var _ IDGenerator = (*AutoScalingGeneratorPool)(nil)
+
+// NewAutoScalingGeneratorPool returns a new AutoScalingGeneratorPool scaling from minShards to maxShards shards
+// when the average latency of Next crosses threshold, e.g., a microsecond.
+//
+// The options are the same as NewGeneratorPool, except that WithShardCount is replaced with maxShards,
+// so the machine IDs of maxShards shards must be available in the layout.
+// It returns ErrInvalidShardCount unless 0 < minShards <= maxShards, and ErrInvalidLatencyThreshold unless threshold is positive.
+func NewAutoScalingGeneratorPool(minShards, maxShards int, threshold time.Duration, opts ...Option) (*AutoScalingGeneratorPool, error) {
+	if minShards <= 0 || maxShards < minShards {
+		return nil, fmt.Errorf("%w: from %d to %d shards", ErrInvalidShardCount, minShards, maxShards)
+	}
+	if threshold <= 0 {
+		return nil, ErrInvalidLatencyThreshold
+	}
+	pool, err := NewGeneratorPool(append(opts[:len(opts):len(opts)], WithShardCount(maxShards))...)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &AutoScalingGeneratorPool{
+		shards:    pool.shards,
+		stats:     make([]shardStats, maxShards),
+		minShards: minShards,
+		threshold: threshold,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	p.current.Store(int64(minShards))
+	go p.run(autoScalingInterval)
+	return p, nil
+}
+
+// CurrentShardCount returns the number of active shards.
+func (p *AutoScalingGeneratorPool) CurrentShardCount() int {
+	return int(p.current.Load())
+}
+
+// Latency returns the exponential moving average of the latency per ID, which decides the number of shards.
+func (p *AutoScalingGeneratorPool) Latency() time.Duration {
+	return time.Duration(p.latency.Load())
+}
+
+// Next returns a new generated Snowflake ID.
+func (p *AutoScalingGeneratorPool) Next() (SnowflakeID, error) {
+	i := p.shard()
+	start := time.Now()
+	id, err := p.shards[i].Next()
+	p.record(i, time.Since(start), 1)
+	return id, err
+}
+
+// NextN returns n new generated Snowflake IDs in increasing order, all generated by the same shard.
+func (p *AutoScalingGeneratorPool) NextN(n int) ([]SnowflakeID, error) {
+	i := p.shard()
+	start := time.Now()
+	ids, err := p.shards[i].NextN(n)
+	p.record(i, time.Since(start), max(n, 1))
+	return ids, err
+}
+
+// Close stops scaling. The pool keeps generating IDs with the current number of shards.
+func (p *AutoScalingGeneratorPool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.stop)
+		<-p.done
+	})
+}
+
+func (p *AutoScalingGeneratorPool) shard() int {
+	return rand.N(int(p.current.Load()))
+}
+
+func (p *AutoScalingGeneratorPool) record(i int, elapsed time.Duration, n int) {
+	p.stats[i].sum.Add(int64(elapsed))
+	p.stats[i].count.Add(int64(n))
+}
+
+func (p *AutoScalingGeneratorPool) run(interval time.Duration) {
+	defer close(p.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.scale()
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// scale updates the moving average with the latency of the last interval, and doubles or halves the shards.
+// An interval without any call counts as zero latency, so an idle pool shrinks.
+func (p *AutoScalingGeneratorPool) scale() {
+	var sum, count int64
+	for i := range p.stats {
+		sum += p.stats[i].sum.Swap(0)
+		count += p.stats[i].count.Swap(0)
+	}
+	var mean float64
+	if count > 0 {
+		mean = float64(sum) / float64(count)
+	}
+	latency := autoScalingAlpha*mean + (1-autoScalingAlpha)*float64(p.latency.Load())
+	p.latency.Store(int64(latency))
+
+	current := int(p.current.Load())
+	switch {
+	case latency > float64(p.threshold) && current < len(p.shards):
+		p.current.Store(int64(min(current*2, len(p.shards))))
+	case latency < float64(p.threshold)/2 && current > p.minShards:
+		p.current.Store(int64(max(current/2, p.minShards)))
+	}
+}
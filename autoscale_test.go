@@ -0,0 +1,139 @@
+package idgenerator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// stubAutoScalingInterval makes the background goroutine never scale, so that tests call scale directly.
+func stubAutoScalingInterval(t *testing.T) {
+	t.Helper()
+	orig := autoScalingInterval
+	autoScalingInterval = time.Hour
+	t.Cleanup(func() { autoScalingInterval = orig })
+}
+
+func TestNewAutoScalingGeneratorPool(t *testing.T) {
+	stubAutoScalingInterval(t)
+	type args struct {
+		minShards int
+		maxShards int
+		threshold time.Duration
+		opts      []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{"Valid", args{1, 8, time.Microsecond, nil}, nil},
+		{"Fixed shards", args{4, 4, time.Microsecond, nil}, nil},
+		{"Error zero shards", args{0, 8, time.Microsecond, nil}, ErrInvalidShardCount},
+		{"Error max below min", args{4, 2, time.Microsecond, nil}, ErrInvalidShardCount},
+		{"Error too many shards", args{1, 17, time.Microsecond, []Option{WithMachineID(16)}}, ErrInvalidShardCount},
+		{"Error zero threshold", args{1, 8, 0, nil}, ErrInvalidLatencyThreshold},
+		{"Error invalid option", args{1, 8, time.Microsecond, []Option{WithMachineID(-1)}}, ErrInvalidMachineID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewAutoScalingGeneratorPool(tt.args.minShards, tt.args.maxShards, tt.args.threshold, tt.args.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewAutoScalingGeneratorPool() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer p.Close()
+			if got := p.CurrentShardCount(); got != tt.args.minShards {
+				t.Errorf("CurrentShardCount() = %v, want %v", got, tt.args.minShards)
+			}
+		})
+	}
+}
+
+func TestAutoScalingGeneratorPool_scale(t *testing.T) {
+	stubAutoScalingInterval(t)
+	p, err := NewAutoScalingGeneratorPool(1, 6, time.Microsecond)
+	if err != nil {
+		t.Fatalf("NewAutoScalingGeneratorPool() error = %v", err)
+	}
+	defer p.Close()
+
+	// Doubled while the latency is above the threshold, up to the maximum.
+	for _, want := range []int{2, 4, 6, 6} {
+		p.record(0, 10*time.Microsecond, 1)
+		p.scale()
+		if got := p.CurrentShardCount(); got != want {
+			t.Errorf("CurrentShardCount() = %v, want %v", got, want)
+		}
+	}
+	if got := p.Latency(); got <= time.Microsecond {
+		t.Errorf("Latency() = %v, want more than %v", got, time.Microsecond)
+	}
+
+	// Halved once the latency of an idle pool decays below half of the threshold, down to the minimum.
+	var got []int
+	for i := 0; i < 100 && p.CurrentShardCount() > 1; i++ {
+		before := p.CurrentShardCount()
+		p.scale()
+		if after := p.CurrentShardCount(); after != before {
+			got = append(got, after)
+		}
+	}
+	if want := []int{3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CurrentShardCount() changed to %v, want %v", got, want)
+	}
+	if got := p.Latency(); got >= time.Microsecond/2 {
+		t.Errorf("Latency() = %v, want less than %v", got, time.Microsecond/2)
+	}
+}
+
+func TestAutoScalingGeneratorPool_Next(t *testing.T) {
+	stubAutoScalingInterval(t)
+	p, err := NewAutoScalingGeneratorPool(1, 4, time.Microsecond, WithMachineID(8))
+	if err != nil {
+		t.Fatalf("NewAutoScalingGeneratorPool() error = %v", err)
+	}
+	defer p.Close()
+	p.current.Store(4)
+
+	seen := make(map[SnowflakeID]struct{})
+	machineIDs := make(map[int]struct{})
+	for i := 0; i < 1000; i++ {
+		id, err := p.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if _, ok := seen[id]; ok {
+			t.Fatalf("Next() returned duplicated ID %v", id)
+		}
+		seen[id] = struct{}{}
+		machineIDs[ExtractMachineID(int64(id))] = struct{}{}
+	}
+	ids, err := p.NextN(3)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			t.Fatalf("NextN() returned duplicated ID %v", id)
+		}
+	}
+	for id := range machineIDs {
+		if id < 8 || id > 11 {
+			t.Errorf("Next() machine ID = %v, want from 8 to 11", id)
+		}
+	}
+	if p.Latency() != 0 {
+		t.Errorf("Latency() = %v before scaling, want 0", p.Latency())
+	}
+
+	p.Close()
+	// Close is idempotent, and the pool keeps generating IDs.
+	p.Close()
+	if _, err := p.Next(); err != nil {
+		t.Errorf("Next() error = %v after Close", err)
+	}
+}
@@ -0,0 +1,41 @@
+// Package avro serializes Snowflake IDs as Apache Avro long values.
+//
+// Avro encodes a long with zig-zag encoding followed by a variable-length encoding of 7 bits per byte,
+// least significant group first, so AvroEncode writes 1 to 9 bytes for a Snowflake ID.
+// The values can be read by any Avro library with Schema, and libraries that do not know
+// the snowflake-id logical type read them as plain longs.
+//
+// It is a separate module, so that the tests can verify the encoding with an Avro library
+// without adding it to the dependencies of the idgenerator package.
+package avro
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// Schema is the Avro schema of a Snowflake ID: a long annotated with the snowflake-id logical type.
+const Schema = `{"type":"long","logicalType":"snowflake-id"}`
+
+// AvroEncode returns id encoded as an Avro long.
+func AvroEncode(id idgenerator.SnowflakeID) []byte {
+	// The signed varint of encoding/binary is the zig-zag encoding of Avro.
+	return binary.AppendVarint(nil, id.Int64())
+}
+
+// AvroDecode decodes an Avro long encoded by AvroEncode. b must contain exactly one value.
+func AvroDecode(b []byte) (idgenerator.SnowflakeID, error) {
+	v, n := binary.Varint(b)
+	if n <= 0 {
+		return 0, fmt.Errorf("%w: malformed Avro long %x", idgenerator.ErrInvalidID, b)
+	}
+	if n != len(b) {
+		return 0, fmt.Errorf("%w: %d trailing bytes after Avro long", idgenerator.ErrInvalidID, len(b)-n)
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("%w: %d is negative", idgenerator.ErrInvalidID, v)
+	}
+	return idgenerator.SnowflakeID(v), nil
+}
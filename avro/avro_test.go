@@ -0,0 +1,99 @@
+package avro
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/hamba/avro/v2"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func TestAvroEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		id   idgenerator.SnowflakeID
+		want []byte
+	}{
+		{"Zero", 0, []byte{0x00}},
+		{"One", 1, []byte{0x02}},
+		{"Snowflake ID", 11234023837724673, []byte{0x82, 0xc0, 0xf7, 0x83, 0xa0, 0xd2, 0xf4, 0x27}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AvroEncode(tt.id); !bytes.Equal(got, tt.want) {
+				t.Errorf("AvroEncode() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAvroDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       []byte
+		want    idgenerator.SnowflakeID
+		wantErr error
+	}{
+		{"Snowflake ID", []byte{0x82, 0xc0, 0xf7, 0x83, 0xa0, 0xd2, 0xf4, 0x27}, 11234023837724673, nil},
+		{"Error empty", nil, 0, idgenerator.ErrInvalidID},
+		{"Error truncated", []byte{0x82, 0xc0}, 0, idgenerator.ErrInvalidID},
+		{"Error trailing bytes", []byte{0x02, 0x00}, 0, idgenerator.ErrInvalidID},
+		{"Error negative", []byte{0x01}, 0, idgenerator.ErrInvalidID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AvroDecode(tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AvroDecode() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("AvroDecode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAvroEncode_Library verifies that the values are compatible with a standard Avro library in both directions.
+func TestAvroEncode_Library(t *testing.T) {
+	schema, err := avro.Parse(Schema)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	for _, id := range []idgenerator.SnowflakeID{0, 1, 11234023837724673, math.MaxInt64} {
+		var got int64
+		if err := avro.Unmarshal(schema, AvroEncode(id), &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if got != id.Int64() {
+			t.Errorf("Unmarshal(AvroEncode()) = %v, want %v", got, id)
+		}
+
+		b, err := avro.Marshal(schema, id.Int64())
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		decoded, err := AvroDecode(b)
+		if err != nil {
+			t.Fatalf("AvroDecode() error = %v", err)
+		}
+		if decoded != id {
+			t.Errorf("AvroDecode(Marshal()) = %v, want %v", decoded, id)
+		}
+	}
+}
+
+func TestSchema(t *testing.T) {
+	schema, err := avro.Parse(Schema)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if schema.Type() != avro.Long {
+		t.Errorf("Type() = %v, want %v", schema.Type(), avro.Long)
+	}
+	if got := schema.(*avro.PrimitiveSchema).Prop("logicalType"); got != "snowflake-id" {
+		t.Errorf("logicalType = %v, want %v", got, "snowflake-id")
+	}
+}
@@ -0,0 +1,145 @@
+// Package aws configures Generators from the EC2 instance metadata service (IMDS),
+// so that instances started from the same image get their node IDs without per-instance configuration:
+//
+//	ctx := context.Background()
+//	g, err := idgenerator.NewGenerator(
+//		aws.WithAZAsDatacenterID(ctx, time.Second),
+//		aws.WithMachineIDFromEC2Metadata(ctx, time.Second),
+//	)
+//
+// IMDSv2 is used when it is available, and IMDSv1 otherwise.
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+const (
+	tokenPath            = "/latest/api/token"
+	instanceIDPath       = "/latest/meta-data/instance-id"
+	availabilityZonePath = "/latest/meta-data/placement/availability-zone"
+	tokenTTLSeconds      = "60"
+	maxMachineID         = 31
+	maxDatacenterID      = 31
+)
+
+var (
+	// ErrMetadataUnavailable is returned when the instance metadata service does not respond successfully.
+	ErrMetadataUnavailable = errors.New("EC2 instance metadata unavailable")
+	// ErrInvalidAvailabilityZone is returned when the availability zone cannot be mapped to a datacenter ID.
+	ErrInvalidAvailabilityZone = errors.New("invalid availability zone")
+
+	// errUnexpectedStatus is wrapped in ErrMetadataUnavailable when the service responds with a status other than 200.
+	errUnexpectedStatus = errors.New("unexpected status")
+)
+
+// endpoint is the address of the instance metadata service, which is replaced in tests.
+var endpoint = "http://169.254.169.254"
+
+// WithMachineIDFromEC2Metadata specifies the machine ID of Snowflake ID from the EC2 instance ID.
+// The FNV-32a hash of the instance ID, such as "i-0123456789abcdef0", is reduced to a 5-bit machine ID by modulo,
+// so instances may collide with the same probability as idgenerator.WithMachineIDFromHostname.
+//
+// It returns an error wrapping ErrMetadataUnavailable if the instance metadata service does not respond within timeout,
+// e.g., when it is not running on EC2, rather than generating IDs with a wrong machine ID.
+func WithMachineIDFromEC2Metadata(ctx context.Context, timeout time.Duration) idgenerator.Option {
+	return idgenerator.LazyOption(func() (idgenerator.Option, error) {
+		instanceID, err := fetchMetadata(ctx, timeout, instanceIDPath)
+		if err != nil {
+			return nil, err
+		}
+		h := fnv.New32a()
+		h.Write([]byte(instanceID))
+		return idgenerator.WithMachineID(int(h.Sum32() % (maxMachineID + 1))), nil
+	})
+}
+
+// WithAZAsDatacenterID specifies the datacenter ID of Snowflake ID from the availability zone of the EC2 instance.
+// The letter at the end of the zone name is mapped to the datacenter ID, e.g., 0 for "us-east-1a" and 1 for "us-east-1b",
+// so the availability zones in a region get different datacenter IDs, while those in different regions may not.
+//
+// It returns an error wrapping ErrMetadataUnavailable if the instance metadata service does not respond within timeout,
+// and ErrInvalidAvailabilityZone if the zone name does not end with a letter from a to z.
+func WithAZAsDatacenterID(ctx context.Context, timeout time.Duration) idgenerator.Option {
+	return idgenerator.LazyOption(func() (idgenerator.Option, error) {
+		zone, err := fetchMetadata(ctx, timeout, availabilityZonePath)
+		if err != nil {
+			return nil, err
+		}
+		id, err := datacenterIDFromZone(zone)
+		if err != nil {
+			return nil, err
+		}
+		return idgenerator.WithDatacenterID(id), nil
+	})
+}
+
+func datacenterIDFromZone(zone string) (int, error) {
+	if zone == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAvailabilityZone, zone)
+	}
+	c := zone[len(zone)-1]
+	if c < 'a' || c > 'z' || int(c-'a') > maxDatacenterID {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAvailabilityZone, zone)
+	}
+	return int(c - 'a'), nil
+}
+
+// fetchMetadata returns the value at path of the instance metadata service.
+// It gets an IMDSv2 session token first, and falls back to IMDSv1 if the token is not available.
+func fetchMetadata(ctx context.Context, timeout time.Duration, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	token, err := fetchToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+path, nil)
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		req.Header.Set("X-aws-ec2-metadata-token", token)
+	}
+	return doMetadataRequest(req)
+}
+
+// fetchToken returns an IMDSv2 session token, or an empty string if the service does not support IMDSv2.
+func fetchToken(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint+tokenPath, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", tokenTTLSeconds)
+	token, err := doMetadataRequest(req)
+	if errors.Is(err, errUnexpectedStatus) {
+		return "", nil
+	}
+	return token, err
+}
+
+func doMetadataRequest(req *http.Request) (string, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMetadataUnavailable, err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMetadataUnavailable, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %w %s for %s", ErrMetadataUnavailable, errUnexpectedStatus, resp.Status, req.URL.Path)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
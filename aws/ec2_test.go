@@ -0,0 +1,129 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// stubEndpoint starts an instance metadata service returning metadata, which requires a token if imdsv2 is true.
+func stubEndpoint(t *testing.T, imdsv2 bool, metadata map[string]string) {
+	t.Helper()
+	const token = "token"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == tokenPath {
+			if !imdsv2 {
+				http.NotFound(w, r)
+				return
+			}
+			if r.Method != http.MethodPut || r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds") == "" {
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(token))
+			return
+		}
+		if imdsv2 && r.Header.Get("X-aws-ec2-metadata-token") != token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		v, ok := metadata[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(v))
+	}))
+	t.Cleanup(srv.Close)
+
+	orig := endpoint
+	endpoint = srv.URL
+	t.Cleanup(func() { endpoint = orig })
+}
+
+func TestWithMachineIDFromEC2Metadata(t *testing.T) {
+	tests := []struct {
+		name   string
+		imdsv2 bool
+	}{
+		{"IMDSv2", true},
+		{"IMDSv1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubEndpoint(t, tt.imdsv2, map[string]string{
+				instanceIDPath:       "i-0123456789abcdef0",
+				availabilityZonePath: "us-east-1c",
+			})
+			ctx := context.Background()
+			g, err := idgenerator.NewGenerator(
+				WithAZAsDatacenterID(ctx, time.Second),
+				WithMachineIDFromEC2Metadata(ctx, time.Second),
+			)
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			if got, want := g.DatacenterID(), 2; got != want {
+				t.Errorf("DatacenterID() = %v, want %v", got, want)
+			}
+			if got, want := g.MachineID(), 21; got != want {
+				t.Errorf("MachineID() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWithMachineIDFromEC2Metadata_Error(t *testing.T) {
+	t.Run("Timeout", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		t.Cleanup(srv.Close)
+		orig := endpoint
+		endpoint = srv.URL
+		t.Cleanup(func() { endpoint = orig })
+
+		_, err := idgenerator.NewGenerator(WithMachineIDFromEC2Metadata(context.Background(), 10*time.Millisecond))
+		if !errors.Is(err, ErrMetadataUnavailable) {
+			t.Errorf("NewGenerator() error = %v, want %v", err, ErrMetadataUnavailable)
+		}
+	})
+	t.Run("Not found", func(t *testing.T) {
+		stubEndpoint(t, true, nil)
+		_, err := idgenerator.NewGenerator(WithMachineIDFromEC2Metadata(context.Background(), time.Second))
+		if !errors.Is(err, ErrMetadataUnavailable) {
+			t.Errorf("NewGenerator() error = %v, want %v", err, ErrMetadataUnavailable)
+		}
+	})
+}
+
+func TestDatacenterIDFromZone(t *testing.T) {
+	tests := []struct {
+		zone    string
+		want    int
+		wantErr bool
+	}{
+		{"us-east-1a", 0, false},
+		{"ap-northeast-1d", 3, false},
+		{"us-west-2-lax-1b", 1, false},
+		{"us-east-1", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			got, err := datacenterIDFromZone(tt.zone)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("datacenterIDFromZone() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("datacenterIDFromZone() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package idgenerator
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// base32Alphabet is the Crockford's Base32 alphabet, which excludes I, L, O and U.
+	base32Alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// base32Length is the number of digits to encode a 64-bit value in base 32.
+	base32Length = 13
+)
+
+var base32Index = func() [256]int8 {
+	var index [256]int8
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(base32Alphabet); i++ {
+		c := base32Alphabet[i]
+		index[c] = int8(i)
+		index[c|0x20] = int8(i) // lower case
+	}
+	// Ambiguous characters are decoded as the digits they look like.
+	index['O'], index['o'] = 0, 0
+	index['I'], index['i'], index['L'], index['l'] = 1, 1, 1, 1
+	return index
+}()
+
+// Base32 returns the ID encoded in Crockford's Base32 as a 13-character string.
+//
+// The string is zero-padded, so that sorting encoded IDs lexicographically gives the same order as sorting the IDs.
+func (id SnowflakeID) Base32() string {
+	var b [base32Length]byte
+	v := uint64(id)
+	for i := base32Length - 1; i >= 0; i-- {
+		b[i] = base32Alphabet[v&0x1f]
+		v >>= 5
+	}
+	return string(b[:])
+}
+
+// ParseBase32 decodes a string encoded by SnowflakeID.Base32.
+//
+// As the Crockford's Base32 specification, the input is case-insensitive, hyphens are ignored,
+// O is decoded as 0, and I and L are decoded as 1.
+func ParseBase32(s string) (SnowflakeID, error) {
+	t := strings.ReplaceAll(s, "-", "")
+	if len(t) != base32Length {
+		return 0, fmt.Errorf("%w: %q is not %d characters", ErrInvalidID, s, base32Length)
+	}
+	var v uint64
+	for i := 0; i < len(t); i++ {
+		d := base32Index[t[i]]
+		if d < 0 {
+			return 0, fmt.Errorf("%w: %q contains invalid character %q", ErrInvalidID, s, t[i])
+		}
+		if v>>59 != 0 {
+			return 0, fmt.Errorf("%w: %q overflows 64 bits", ErrInvalidID, s)
+		}
+		v = v<<5 | uint64(d)
+	}
+	return SnowflakeID(v), nil
+}
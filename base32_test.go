@@ -0,0 +1,86 @@
+package idgenerator
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestSnowflakeID_Base32(t *testing.T) {
+	tests := []struct {
+		name string
+		id   SnowflakeID
+		want string
+	}{
+		{"Zero", 0, "0000000000000"},
+		{"One", 1, "0000000000001"},
+		{"Snowflake ID", 11234023837724673, "009Z99403XW01"},
+		{"Maximum value", math.MaxInt64, "7ZZZZZZZZZZZZ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.id.Base32(); got != tt.want {
+				t.Errorf("SnowflakeID.Base32() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBase32(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    SnowflakeID
+		wantErr bool
+	}{
+		{"Zero", "0000000000000", 0, false},
+		{"Snowflake ID", "009Z99403XW01", 11234023837724673, false},
+		{"Lower case", "009z99403xw01", 11234023837724673, false},
+		{"Ambiguous characters", "oO9Z994O3XWOi", 11234023837724673, false},
+		{"Hyphens", "009Z9-9403-XW01", 11234023837724673, false},
+		{"Maximum value", "7ZZZZZZZZZZZZ", math.MaxInt64, false},
+		{"Error too short", "9Z99403XW01", 0, true},
+		{"Error too long", "0009Z99403XW01", 0, true},
+		{"Error invalid character", "009Z99403UW01", 0, true},
+		{"Error overflow", "GZZZZZZZZZZZZ", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBase32(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseBase32() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseBase32() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnowflakeID_Base32_Order(t *testing.T) {
+	ids := []SnowflakeID{0, 31, 32, 1023, 1024, 11234023837724673, 11234023837724674, math.MaxInt64}
+	encoded := make([]string, len(ids))
+	for i, id := range ids {
+		encoded[i] = id.Base32()
+	}
+	if !sort.StringsAreSorted(encoded) {
+		t.Errorf("SnowflakeID.Base32() = %v, want sorted strings", encoded)
+	}
+}
+
+func FuzzSnowflakeID_Base32(f *testing.F) {
+	for _, v := range []int64{0, 1, 31, 32, 11234023837724673, math.MaxInt64, -1, math.MinInt64} {
+		f.Add(v)
+	}
+	f.Fuzz(func(t *testing.T, v int64) {
+		id := SnowflakeID(v)
+		got, err := ParseBase32(id.Base32())
+		if err != nil {
+			t.Fatalf("ParseBase32() error = %v", err)
+		}
+		if got != id {
+			t.Errorf("ParseBase32() = %v, want %v", got, id)
+		}
+	})
+}
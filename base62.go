@@ -0,0 +1,56 @@
+package idgenerator
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// base62Alphabet is in ASCII order, so that the lexicographic order of encoded IDs matches the numeric order.
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// base62Length is the number of digits to encode a 64-bit value in base 62.
+	base62Length = 11
+)
+
+var base62Index = func() [256]int8 {
+	var index [256]int8
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(base62Alphabet); i++ {
+		index[base62Alphabet[i]] = int8(i)
+	}
+	return index
+}()
+
+// Base62 returns the ID encoded in base 62 as an 11-character string.
+//
+// The string is zero-padded, so that sorting encoded IDs lexicographically gives the same order as sorting the IDs.
+func (id SnowflakeID) Base62() string {
+	var b [base62Length]byte
+	v := uint64(id)
+	for i := base62Length - 1; i >= 0; i-- {
+		b[i] = base62Alphabet[v%62]
+		v /= 62
+	}
+	return string(b[:])
+}
+
+// ParseBase62 decodes a string encoded by SnowflakeID.Base62.
+func ParseBase62(s string) (SnowflakeID, error) {
+	if len(s) != base62Length {
+		return 0, fmt.Errorf("%w: %q is not %d characters", ErrInvalidID, s, base62Length)
+	}
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		d := base62Index[s[i]]
+		if d < 0 {
+			return 0, fmt.Errorf("%w: %q contains invalid character %q", ErrInvalidID, s, s[i])
+		}
+		if v > (math.MaxUint64-uint64(d))/62 {
+			return 0, fmt.Errorf("%w: %q overflows 64 bits", ErrInvalidID, s)
+		}
+		v = v*62 + uint64(d)
+	}
+	return SnowflakeID(v), nil
+}
@@ -0,0 +1,83 @@
+package idgenerator
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestSnowflakeID_Base62(t *testing.T) {
+	tests := []struct {
+		name string
+		id   SnowflakeID
+		want string
+	}{
+		{"Zero", 0, "00000000000"},
+		{"One", 1, "00000000001"},
+		{"Snowflake ID", 11234023837724673, "00pS1Hwq1mz"},
+		{"Maximum value", math.MaxInt64, "AzL8n0Y58m7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.id.Base62(); got != tt.want {
+				t.Errorf("SnowflakeID.Base62() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBase62(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    SnowflakeID
+		wantErr bool
+	}{
+		{"Zero", "00000000000", 0, false},
+		{"Snowflake ID", "00pS1Hwq1mz", 11234023837724673, false},
+		{"Maximum value", "AzL8n0Y58m7", math.MaxInt64, false},
+		{"Error too short", "pS1Hwq1mz", 0, true},
+		{"Error too long", "000pS1Hwq1mz", 0, true},
+		{"Error invalid character", "00pS1Hw-1mz", 0, true},
+		{"Error overflow", "zzzzzzzzzzz", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBase62(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseBase62() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseBase62() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnowflakeID_Base62_Order(t *testing.T) {
+	ids := []SnowflakeID{0, 61, 62, 3843, 3844, 11234023837724673, 11234023837724674, math.MaxInt64}
+	encoded := make([]string, len(ids))
+	for i, id := range ids {
+		encoded[i] = id.Base62()
+	}
+	if !sort.StringsAreSorted(encoded) {
+		t.Errorf("SnowflakeID.Base62() = %v, want sorted strings", encoded)
+	}
+}
+
+func FuzzSnowflakeID_Base62(f *testing.F) {
+	for _, v := range []int64{0, 1, 61, 62, 11234023837724673, math.MaxInt64, -1, math.MinInt64} {
+		f.Add(v)
+	}
+	f.Fuzz(func(t *testing.T, v int64) {
+		id := SnowflakeID(v)
+		got, err := ParseBase62(id.Base62())
+		if err != nil {
+			t.Fatalf("ParseBase62() error = %v", err)
+		}
+		if got != id {
+			t.Errorf("ParseBase62() = %v, want %v", got, id)
+		}
+	})
+}
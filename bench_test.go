@@ -0,0 +1,110 @@
+package idgenerator
+
+//go:generate sh -c "{ echo '# Benchmarks'; echo; echo 'Generated by go generate. Do not edit.'; echo; echo '```'; go test -run '^$' -bench . -benchmem; echo '```'; } > BENCHMARKS.md"
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// benchmarkStrategies returns the generators to compare: the mutex-based Generator,
+// the lock-free AtomicGenerator, and the sharded GeneratorPool.
+func benchmarkStrategies(b *testing.B) []struct {
+	name string
+	g    IDGenerator
+} {
+	b.Helper()
+	g, err := NewGenerator()
+	if err != nil {
+		b.Fatalf("NewGenerator() error = %v", err)
+	}
+	a, err := NewAtomicGenerator()
+	if err != nil {
+		b.Fatalf("NewAtomicGenerator() error = %v", err)
+	}
+	p, err := NewGeneratorPool()
+	if err != nil {
+		b.Fatalf("NewGeneratorPool() error = %v", err)
+	}
+	return []struct {
+		name string
+		g    IDGenerator
+	}{
+		{"Generator", g},
+		{"Atomic", a},
+		{"Pool", p},
+	}
+}
+
+func BenchmarkNext(b *testing.B) {
+	for _, goroutines := range []int{1, 8, 64} {
+		for _, s := range benchmarkStrategies(b) {
+			b.Run(fmt.Sprintf("%s/goroutines=%d", s.name, goroutines), func(b *testing.B) {
+				b.ReportAllocs()
+				benchmarkConcurrent(b, goroutines, func() error {
+					_, err := s.g.Next()
+					return err
+				})
+			})
+		}
+	}
+}
+
+func BenchmarkNextN(b *testing.B) {
+	for _, s := range benchmarkStrategies(b) {
+		b.Run(s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := s.g.NextN(1000); err != nil {
+					b.Fatalf("NextN() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParseSnowflakeID(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseSnowflakeID(11234023837724673, time.Time{}, Layout{}); err != nil {
+			b.Fatalf("ParseSnowflakeID() error = %v", err)
+		}
+	}
+}
+
+func BenchmarkBase62_RoundTrip(b *testing.B) {
+	var id SnowflakeID = 11234023837724673
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		got, err := ParseBase62(id.Base62())
+		if err != nil || got != id {
+			b.Fatalf("ParseBase62() = %v, %v, want %v", got, err, id)
+		}
+	}
+}
+
+// benchmarkConcurrent calls next b.N times in total from the given number of goroutines.
+// Unlike b.RunParallel, the number of goroutines does not depend on GOMAXPROCS.
+func benchmarkConcurrent(b *testing.B, goroutines int, next func() error) {
+	var wg sync.WaitGroup
+	b.ResetTimer()
+	for i := range goroutines {
+		n := b.N / goroutines
+		if i < b.N%goroutines {
+			n++
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range n {
+				if err := next(); err != nil {
+					b.Errorf("Next() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
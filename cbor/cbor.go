@@ -0,0 +1,104 @@
+// Package cbor encodes Snowflake IDs as CBOR (RFC 8949) unsigned integers.
+//
+// Snowflake IDs are never negative, so they are encoded with major type 0 (unsigned integer)
+// in the shortest form: 1 byte up to 23, and 1 byte of the head followed by 1, 2, 4, or 8 bytes of the value.
+// A Snowflake ID of the current time takes 9 bytes.
+//
+// idgenerator.SnowflakeID implements encoding.BinaryMarshaler as 8 raw bytes,
+// which CBOR libraries such as fxamacker/cbor encode as a byte string.
+// Use ID in the fields of messages to encode them as integers instead.
+//
+// It is a separate module, so that the tests can verify the encoding with a CBOR library
+// without adding it to the dependencies of the idgenerator package.
+package cbor
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+const (
+	majorTypeUnsigned = 0 << 5
+	additionalUint8   = 24
+	additionalUint16  = 25
+	additionalUint32  = 26
+	additionalUint64  = 27
+)
+
+// SnowflakeIDToCBOR returns id encoded as a CBOR unsigned integer in the shortest form.
+func SnowflakeIDToCBOR(id idgenerator.SnowflakeID) []byte {
+	v := uint64(id)
+	switch {
+	case v < additionalUint8:
+		return []byte{majorTypeUnsigned | byte(v)}
+	case v <= 0xff:
+		return []byte{majorTypeUnsigned | additionalUint8, byte(v)}
+	case v <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{majorTypeUnsigned | additionalUint16}, uint16(v))
+	case v <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{majorTypeUnsigned | additionalUint32}, uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{majorTypeUnsigned | additionalUint64}, v)
+	}
+}
+
+// SnowflakeIDFromCBOR decodes a CBOR unsigned integer. b must contain exactly one value.
+// Values not in the shortest form are accepted, as RFC 8949 allows for decoders.
+func SnowflakeIDFromCBOR(b []byte) (idgenerator.SnowflakeID, error) {
+	if len(b) == 0 {
+		return 0, fmt.Errorf("%w: empty CBOR", idgenerator.ErrInvalidID)
+	}
+	if major := b[0] &^ 0x1f; major != majorTypeUnsigned {
+		return 0, fmt.Errorf("%w: CBOR major type %d, want unsigned integer", idgenerator.ErrInvalidID, major>>5)
+	}
+
+	var v uint64
+	var n int
+	switch additional := b[0] & 0x1f; {
+	case additional < additionalUint8:
+		v, n = uint64(additional), 1
+	case additional == additionalUint8 && len(b) >= 2:
+		v, n = uint64(b[1]), 2
+	case additional == additionalUint16 && len(b) >= 3:
+		v, n = uint64(binary.BigEndian.Uint16(b[1:])), 3
+	case additional == additionalUint32 && len(b) >= 5:
+		v, n = uint64(binary.BigEndian.Uint32(b[1:])), 5
+	case additional == additionalUint64 && len(b) >= 9:
+		v, n = binary.BigEndian.Uint64(b[1:]), 9
+	default:
+		return 0, fmt.Errorf("%w: malformed CBOR unsigned integer %x", idgenerator.ErrInvalidID, b)
+	}
+	if n != len(b) {
+		return 0, fmt.Errorf("%w: %d trailing bytes after CBOR unsigned integer", idgenerator.ErrInvalidID, len(b)-n)
+	}
+	if v > 1<<63-1 {
+		return 0, fmt.Errorf("%w: %d overflows int64", idgenerator.ErrInvalidID, v)
+	}
+	return idgenerator.SnowflakeID(v), nil
+}
+
+// ID is a Snowflake ID encoded as a CBOR unsigned integer.
+// It implements the Marshaler and Unmarshaler interfaces of fxamacker/cbor.
+type ID idgenerator.SnowflakeID
+
+// SnowflakeID returns the ID as idgenerator.SnowflakeID.
+func (id ID) SnowflakeID() idgenerator.SnowflakeID {
+	return idgenerator.SnowflakeID(id)
+}
+
+// MarshalCBOR encodes the ID with SnowflakeIDToCBOR.
+func (id ID) MarshalCBOR() ([]byte, error) {
+	return SnowflakeIDToCBOR(idgenerator.SnowflakeID(id)), nil
+}
+
+// UnmarshalCBOR decodes the ID with SnowflakeIDFromCBOR.
+func (id *ID) UnmarshalCBOR(b []byte) error {
+	v, err := SnowflakeIDFromCBOR(b)
+	if err != nil {
+		return err
+	}
+	*id = ID(v)
+	return nil
+}
@@ -0,0 +1,107 @@
+package cbor
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func TestSnowflakeIDToCBOR(t *testing.T) {
+	tests := []struct {
+		name string
+		id   idgenerator.SnowflakeID
+		want []byte
+	}{
+		{"Zero", 0, []byte{0x00}},
+		{"23", 23, []byte{0x17}},
+		{"24", 24, []byte{0x18, 0x18}},
+		{"uint16", 0x100, []byte{0x19, 0x01, 0x00}},
+		{"uint32", 0x10000, []byte{0x1a, 0x00, 0x01, 0x00, 0x00}},
+		{"Snowflake ID", 11234023837724673, []byte{0x1b, 0x00, 0x27, 0xe9, 0x49, 0x00, 0x3e, 0xf0, 0x01}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SnowflakeIDToCBOR(tt.id); !bytes.Equal(got, tt.want) {
+				t.Errorf("SnowflakeIDToCBOR() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnowflakeIDFromCBOR(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       []byte
+		want    idgenerator.SnowflakeID
+		wantErr error
+	}{
+		{"Snowflake ID", []byte{0x1b, 0x00, 0x27, 0xe9, 0x49, 0x00, 0x3e, 0xf0, 0x01}, 11234023837724673, nil},
+		{"Not shortest form", []byte{0x1b, 0, 0, 0, 0, 0, 0, 0, 0x01}, 1, nil},
+		{"Error empty", nil, 0, idgenerator.ErrInvalidID},
+		{"Error negative integer", []byte{0x20}, 0, idgenerator.ErrInvalidID},
+		{"Error truncated", []byte{0x1b, 0x00}, 0, idgenerator.ErrInvalidID},
+		{"Error trailing bytes", []byte{0x01, 0x00}, 0, idgenerator.ErrInvalidID},
+		{"Error overflow", []byte{0x1b, 0x80, 0, 0, 0, 0, 0, 0, 0}, 0, idgenerator.ErrInvalidID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SnowflakeIDFromCBOR(tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SnowflakeIDFromCBOR() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SnowflakeIDFromCBOR() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSnowflakeIDToCBOR_Library verifies that the values are compatible with a standard CBOR library,
+// and take as few bytes as its canonical encoding.
+func TestSnowflakeIDToCBOR_Library(t *testing.T) {
+	for _, id := range []idgenerator.SnowflakeID{0, 23, 24, 0xffff, 0x10000, 11234023837724673, math.MaxInt64} {
+		b := SnowflakeIDToCBOR(id)
+		var got uint64
+		if err := cbor.Unmarshal(b, &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if got != uint64(id) {
+			t.Errorf("Unmarshal(SnowflakeIDToCBOR()) = %v, want %v", got, id)
+		}
+
+		want, err := cbor.Marshal(uint64(id))
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if !bytes.Equal(b, want) {
+			t.Errorf("SnowflakeIDToCBOR() = %x, want %x", b, want)
+		}
+	}
+}
+
+func TestID_RoundTrip(t *testing.T) {
+	type message struct {
+		ID   ID     `cbor:"id"`
+		Name string `cbor:"name"`
+	}
+	want := message{ID: 11234023837724673, Name: "alice"}
+	b, err := cbor.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !bytes.Contains(b, SnowflakeIDToCBOR(11234023837724673)) {
+		t.Errorf("Marshal() = %x, want an unsigned integer", b)
+	}
+	var got message
+	if err := cbor.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Unmarshal() = %v, want %v", got, want)
+	}
+}
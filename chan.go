@@ -0,0 +1,64 @@
+package idgenerator
+
+import "context"
+
+const defaultChanBufferSize = 64
+
+// WithChanBufferSize specifies the buffer size of the channel returned by Generator.Chan.
+// A zero n means the default, 64.
+func WithChanBufferSize(n int) Option {
+	return func(s *snowflake) error {
+		if n < 0 {
+			return ErrInvalidChanBufferSize
+		}
+		s.chanBufferSize = n
+		return nil
+	}
+}
+
+func (s *snowflake) getChanBufferSize() int {
+	if s.chanBufferSize != 0 {
+		return s.chanBufferSize
+	}
+	return defaultChanBufferSize
+}
+
+// Chan starts a goroutine that sends new generated IDs to the returned channel,
+// so that IDs can be received with a for range loop.
+//
+// When ctx is cancelled, the goroutine stops and closes the channel.
+// When the generation fails, the goroutine sends the error to the channel returned by Err,
+// and stops and closes the channel.
+func (g *Generator) Chan(ctx context.Context) <-chan SnowflakeID {
+	ids := make(chan SnowflakeID, g.chanBufferSize)
+	go func() {
+		defer close(ids)
+		for {
+			id, err := g.NextCtx(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case g.errs <- err:
+				default:
+					// An earlier error has not been received yet.
+				}
+				return
+			}
+			select {
+			case ids <- id:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ids
+}
+
+// Err returns the channel that receives the error that stopped a channel returned by Chan.
+// Check it after the channel returned by Chan is closed without cancelling the context.
+// The channel holds one error, and later errors are dropped until it is received.
+func (g *Generator) Err() <-chan error {
+	return g.errs
+}
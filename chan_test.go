@@ -0,0 +1,88 @@
+package idgenerator
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithChanBufferSize(t *testing.T) {
+	type args struct {
+		n int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{"n:0", args{0}, defaultChanBufferSize, false},
+		{"n:1", args{1}, 1, false},
+		{"n:1000", args{1000}, 1000, false},
+		{"Error n:-1", args{-1}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(WithChanBufferSize(tt.args.n))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGenerator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if got := cap(g.Chan(ctx)); got != tt.want {
+				t.Errorf("Chan() cap = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_Chan(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ids := g.Chan(ctx)
+
+	var prev SnowflakeID
+	for i := 0; i < 10000; i++ {
+		id := <-ids
+		if id <= prev {
+			t.Fatalf("Chan() sent %v after %v, want increasing IDs", id, prev)
+		}
+		prev = id
+	}
+
+	cancel()
+	for range ids {
+		// Drain the buffered IDs until the channel is closed.
+	}
+	select {
+	case err := <-g.Err():
+		t.Errorf("Err() = %v, want no error", err)
+	default:
+	}
+}
+
+func TestGenerator_Chan_Error(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c), WithSequenceExhaustionPolicy(ReturnError))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	n := 0
+	for range g.Chan(context.Background()) {
+		n++
+	}
+	if n != maxSequenceNumber+1 {
+		t.Errorf("Chan() sent %v IDs, want %v", n, maxSequenceNumber+1)
+	}
+	if err := <-g.Err(); err != ErrSequenceExhausted {
+		t.Errorf("Err() = %v, want %v", err, ErrSequenceExhausted)
+	}
+}
@@ -0,0 +1,38 @@
+//go:build chaos
+
+package idgenerator
+
+import "time"
+
+// This file exposes hooks to inject faults into a Generator for the chaos sub-package.
+// It is built only with the chaos build tag, so that production builds cannot inject faults.
+
+// steppedClock is a ClockSource stepped by offset from clock.
+type steppedClock struct {
+	clock  ClockSource
+	offset time.Duration
+}
+
+func (c steppedClock) Now() time.Time {
+	return c.clock.Now().Add(c.offset)
+}
+
+// ChaosStepClock moves the clock of g backward by d from now on, as if the system clock were adjusted.
+func ChaosStepClock(g *Generator, d time.Duration) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if c, ok := g.clock.(steppedClock); ok {
+		c.offset -= d
+		g.clock = c
+		return
+	}
+	g.clock = steppedClock{clock: g.clock, offset: -d}
+}
+
+// ChaosExhaustSequence sets the sequence number of g to the maximum,
+// so that the next ID in the same millisecond overflows the sequence number.
+func ChaosExhaustSequence(g *Generator) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.sequenceNumber = g.layout.maxSequenceNumber()
+}
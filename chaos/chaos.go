@@ -0,0 +1,72 @@
+//go:build chaos
+
+package chaos
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// ChaosConfig specifies the faults injected by a ChaosGenerator before each call.
+type ChaosConfig struct {
+	// ClockStepProbability is the probability of moving the clock backward.
+	ClockStepProbability float64
+	// ClockStepMagnitude is how far the clock moves backward.
+	ClockStepMagnitude time.Duration
+	// SequenceOverflowProbability is the probability of setting the sequence number to the maximum,
+	// which overflows if the next ID is in the same millisecond.
+	SequenceOverflowProbability float64
+	// Seed is the seed of the random numbers deciding the faults, so that a run can be reproduced.
+	Seed int64
+}
+
+// ChaosGenerator wraps a Generator and injects faults into it as specified by a ChaosConfig.
+// A ChaosGenerator is safe for concurrent use by multiple goroutines.
+type ChaosGenerator struct {
+	g      *idgenerator.Generator
+	config ChaosConfig
+	rand   *rand.Rand
+
+	mutex sync.Mutex
+}
+
+var _ idgenerator.IDGenerator = (*ChaosGenerator)(nil)
+
+// NewChaosGenerator returns a new ChaosGenerator injecting faults into g.
+// The faults change the state of g, so g should not be used directly afterwards.
+func NewChaosGenerator(g *idgenerator.Generator, config ChaosConfig) *ChaosGenerator {
+	return &ChaosGenerator{
+		g:      g,
+		config: config,
+		rand:   rand.New(rand.NewSource(config.Seed)),
+	}
+}
+
+// Next injects faults, and returns a new ID generated by the wrapped Generator.
+func (c *ChaosGenerator) Next() (idgenerator.SnowflakeID, error) {
+	c.inject()
+	return c.g.Next()
+}
+
+// NextN injects faults, and returns n new IDs generated by the wrapped Generator.
+func (c *ChaosGenerator) NextN(n int) ([]idgenerator.SnowflakeID, error) {
+	c.inject()
+	return c.g.NextN(n)
+}
+
+func (c *ChaosGenerator) inject() {
+	c.mutex.Lock()
+	stepClock := c.rand.Float64() < c.config.ClockStepProbability
+	exhaustSequence := c.rand.Float64() < c.config.SequenceOverflowProbability
+	c.mutex.Unlock()
+
+	if stepClock {
+		idgenerator.ChaosStepClock(c.g, c.config.ClockStepMagnitude)
+	}
+	if exhaustSequence {
+		idgenerator.ChaosExhaustSequence(c.g)
+	}
+}
@@ -0,0 +1,95 @@
+//go:build chaos
+
+package chaos
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func newGenerator(t *testing.T, opts ...idgenerator.Option) (*idgenerator.Generator, *idgenerator.SimulatedClock) {
+	t.Helper()
+	c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := idgenerator.NewGenerator(append([]idgenerator.Option{idgenerator.WithClock(c)}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	return g, c
+}
+
+func TestChaosGenerator_ClockStep(t *testing.T) {
+	tests := []struct {
+		name      string
+		tolerance time.Duration
+		wantErr   error
+	}{
+		{"Beyond tolerance", 0, idgenerator.ErrClockMovedBackward},
+		{"Within tolerance", 5 * time.Millisecond, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := newGenerator(t, idgenerator.WithClockSkewTolerance(tt.tolerance))
+			if _, err := g.Next(); err != nil {
+				t.Fatalf("Next() error = %v", err)
+			}
+			c := NewChaosGenerator(g, ChaosConfig{ClockStepProbability: 1, ClockStepMagnitude: 2 * time.Millisecond})
+			if _, err := c.Next(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Next() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChaosGenerator_SequenceOverflow(t *testing.T) {
+	g, clock := newGenerator(t, idgenerator.WithSequenceExhaustionPolicy(idgenerator.ReturnError))
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	c := NewChaosGenerator(g, ChaosConfig{SequenceOverflowProbability: 1})
+	if _, err := c.Next(); err != idgenerator.ErrSequenceExhausted {
+		t.Errorf("Next() error = %v, want %v", err, idgenerator.ErrSequenceExhausted)
+	}
+
+	// In the next millisecond, the sequence number starts from 0 regardless of the injected one.
+	clock.Advance(time.Millisecond)
+	id, err := c.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if got := idgenerator.ExtractSequenceNumber(id.Int64()); got != 0 {
+		t.Errorf("Next() sequence number = %v, want %v", got, 0)
+	}
+}
+
+func TestChaosGenerator_Seed(t *testing.T) {
+	config := ChaosConfig{ClockStepProbability: 0.3, ClockStepMagnitude: time.Millisecond, SequenceOverflowProbability: 0.3, Seed: 42}
+	run := func() []error {
+		g, clock := newGenerator(t, idgenerator.WithSequenceExhaustionPolicy(idgenerator.ReturnError))
+		c := NewChaosGenerator(g, config)
+		var errs []error
+		for range 50 {
+			_, err := c.Next()
+			errs = append(errs, err)
+			clock.Advance(time.Millisecond / 2)
+		}
+		return errs
+	}
+
+	// The same seed injects the same faults.
+	first, second := run(), run()
+	var failures int
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("Next() #%d error = %v, want %v", i, second[i], first[i])
+		}
+		if first[i] != nil {
+			failures++
+		}
+	}
+	if failures == 0 {
+		t.Error("no faults were injected")
+	}
+}
@@ -0,0 +1,5 @@
+// Package chaos injects clock skew and sequence overflow into a Generator, to test how an application handles them.
+//
+// It is built only with the chaos build tag, e.g., go test -tags chaos ./...,
+// so that production builds cannot inject faults. Without the tag, the package is empty.
+package chaos
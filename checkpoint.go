@@ -0,0 +1,69 @@
+package idgenerator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// checkpoint is the state of a Generator saved by Save.
+type checkpoint struct {
+	LastTimestamp int64 `json:"last_timestamp"`
+	LastSequence  int   `json:"last_sequence"`
+	DatacenterID  int   `json:"datacenter_id"`
+	MachineID     int   `json:"machine_id"`
+}
+
+// Save writes the state of the Generator to w as a JSON record, which can be restored by LoadGenerator.
+// Save it on shutdown, or periodically, to avoid generating the same IDs after a restart with a clock moved backward.
+func (g *Generator) Save(w io.Writer) error {
+	g.mutex.Lock()
+	cp := checkpoint{
+		LastTimestamp: g.lastTimestamp,
+		LastSequence:  g.sequenceNumber,
+		DatacenterID:  g.datacenterID,
+		MachineID:     g.machineID,
+	}
+	g.mutex.Unlock()
+
+	return json.NewEncoder(w).Encode(cp)
+}
+
+// LoadGenerator returns a new Generator restored from the state written by Generator.Save.
+//
+// The datacenter ID and the machine ID of the checkpoint take precedence over opts,
+// including WithShardID, WithWorkerID, and WithRandomEnabled.
+// As a safety margin, the restored Generator skips the millisecond after the last one of the checkpoint,
+// and returns ErrClockMovedBackward if the clock is behind it, as if it had generated IDs until then.
+func LoadGenerator(r io.Reader, opts ...Option) (*Generator, error) {
+	var cp checkpoint
+	if err := json.NewDecoder(r).Decode(&cp); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCheckpoint, err)
+	}
+	if cp.LastTimestamp < 0 {
+		return nil, fmt.Errorf("%w: negative last timestamp %d", ErrInvalidCheckpoint, cp.LastTimestamp)
+	}
+
+	g, err := NewGenerator(append(opts[:len(opts):len(opts)], withCheckpointNode(cp))...)
+	if err != nil {
+		return nil, err
+	}
+	if cp.LastTimestamp > 0 {
+		g.lastTimestamp = cp.LastTimestamp + 1
+		g.sequenceNumber = g.layout.maxSequenceNumber()
+	}
+	return g, nil
+}
+
+// withCheckpointNode sets the datacenter ID and the machine ID of cp,
+// and drops the shard ID, the worker ID, and the random IDs, which would otherwise replace them in NewGenerator.
+func withCheckpointNode(cp checkpoint) Option {
+	return func(s *snowflake) error {
+		s.datacenterID = cp.DatacenterID
+		s.machineID = cp.MachineID
+		s.hasShardID = false
+		s.hasWorkerID = false
+		s.random = false
+		return nil
+	}
+}
@@ -0,0 +1,133 @@
+package idgenerator
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerator_Save(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if _, err := g.NextN(2); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := g.Save(&buf); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	want := `{"last_timestamp":2678400000,"last_sequence":1,"datacenter_id":31,"machine_id":15}`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("Save() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadGenerator(t *testing.T) {
+	type args struct {
+		advance time.Duration
+		opts    []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{"Clock advanced", args{time.Second, nil}, nil},
+		{"Error same millisecond", args{0, []Option{WithClockSkewTolerance(time.Second), WithSequenceExhaustionPolicy(ReturnError)}}, ErrSequenceExhausted},
+		{"Error next millisecond", args{time.Millisecond, []Option{WithSequenceExhaustionPolicy(ReturnError)}}, ErrSequenceExhausted},
+		{"Error clock moved backward", args{-time.Second, nil}, ErrClockMovedBackward},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+			g, err := NewGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15))
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			before, err := g.NextN(100)
+			if err != nil {
+				t.Fatalf("NextN() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := g.Save(&buf); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			// Restart with the same or a moved clock.
+			c.Advance(tt.args.advance)
+			restored, err := LoadGenerator(&buf, append(tt.args.opts, WithClock(c), WithDatacenterID(1))...)
+			if err != nil {
+				t.Fatalf("LoadGenerator() error = %v", err)
+			}
+			if restored.DatacenterID() != 31 || restored.MachineID() != 15 {
+				t.Errorf("LoadGenerator() node = %v/%v, want %v/%v", restored.DatacenterID(), restored.MachineID(), 31, 15)
+			}
+			if _, err := restored.Next(); err != tt.wantErr {
+				t.Fatalf("Next() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				return
+			}
+
+			// The IDs after the skipped millisecond do not overlap with the ones before the restart.
+			c.Set(time.Date(2024, 2, 1, 0, 0, 0, 2*int(time.Millisecond), time.UTC))
+			after, err := restored.NextN(100)
+			if err != nil {
+				t.Fatalf("NextN() error = %v", err)
+			}
+			if after[0] <= before[len(before)-1] {
+				t.Errorf("NextN() after restart = %v, want greater than %v", after[0], before[len(before)-1])
+			}
+			if got := ExtractSequenceNumber(int64(after[0])); got != 0 {
+				t.Errorf("NextN() sequence number = %v, want %v", got, 0)
+			}
+		})
+	}
+}
+
+func TestLoadGenerator_Node(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"WithShardID", []Option{WithShardID(5)}},
+		{"WithWorkerID", []Option{WithWorkerID(5)}},
+		{"WithRandomEnabled", []Option{WithRandomEnabled()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A checkpoint of node 0/0, which WithRandomEnabled would replace as unset.
+			g, err := LoadGenerator(strings.NewReader(`{"datacenter_id":0,"machine_id":0}`), tt.opts...)
+			if err != nil {
+				t.Fatalf("LoadGenerator() error = %v", err)
+			}
+			if g.DatacenterID() != 0 || g.MachineID() != 0 {
+				t.Errorf("LoadGenerator() node = %v/%v, want %v/%v", g.DatacenterID(), g.MachineID(), 0, 0)
+			}
+		})
+	}
+}
+
+func TestLoadGenerator_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"Error not JSON", "abc", ErrInvalidCheckpoint},
+		{"Error negative last timestamp", `{"last_timestamp":-1}`, ErrInvalidCheckpoint},
+		{"Error invalid datacenter ID", `{"datacenter_id":32}`, ErrInvalidDatacenterID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadGenerator(strings.NewReader(tt.input)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("LoadGenerator() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
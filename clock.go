@@ -0,0 +1,65 @@
+package idgenerator
+
+import (
+	"sync"
+	"time"
+)
+
+// ClockSource provides the current time to generate IDs.
+type ClockSource interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SimulatedClock is a ClockSource whose time moves only when Advance or Set is called.
+// It is useful to test time-dependent behavior without sleeping.
+// A SimulatedClock is safe for concurrent use by multiple goroutines.
+type SimulatedClock struct {
+	now time.Time
+
+	mutex sync.Mutex
+}
+
+var _ ClockSource = (*SimulatedClock)(nil)
+
+// NewSimulatedClock returns a new SimulatedClock set to t.
+func NewSimulatedClock(t time.Time) *SimulatedClock {
+	return &SimulatedClock{now: t}
+}
+
+// Now returns the simulated current time.
+func (c *SimulatedClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// Advance moves the simulated current time forward by d. A negative d moves it backward.
+func (c *SimulatedClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set changes the simulated current time to t.
+func (c *SimulatedClock) Set(t time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = t
+}
+
+// WithClock specifies the ClockSource used instead of time.Now.
+func WithClock(cs ClockSource) Option {
+	return func(s *snowflake) error {
+		if cs == nil {
+			return ErrInvalidClock
+		}
+		s.clock = cs
+		return nil
+	}
+}
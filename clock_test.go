@@ -0,0 +1,39 @@
+package idgenerator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulatedClock(t *testing.T) {
+	start := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	c := NewSimulatedClock(start)
+	if got := c.Now(); !got.Equal(start) {
+		t.Errorf("SimulatedClock.Now() = %v, want %v", got, start)
+	}
+
+	c.Advance(time.Second)
+	if got, want := c.Now(), start.Add(time.Second); !got.Equal(want) {
+		t.Errorf("SimulatedClock.Now() after Advance = %v, want %v", got, want)
+	}
+
+	c.Set(start.Add(-time.Hour))
+	if got, want := c.Now(), start.Add(-time.Hour); !got.Equal(want) {
+		t.Errorf("SimulatedClock.Now() after Set = %v, want %v", got, want)
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	id, err := NewSnowflakeID(WithClock(c), WithDatacenterID(31), WithMachineID(15), WithSequenceNumber(1))
+	if err != nil {
+		t.Fatalf("NewSnowflakeID() error = %v", err)
+	}
+	if id != 11234023837724673 {
+		t.Errorf("NewSnowflakeID() = %v, want %v", id, 11234023837724673)
+	}
+
+	if _, err := NewSnowflakeID(WithClock(nil)); err != ErrInvalidClock {
+		t.Errorf("NewSnowflakeID() error = %v, want %v", err, ErrInvalidClock)
+	}
+}
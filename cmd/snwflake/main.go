@@ -0,0 +1,134 @@
+// Command snwflake generates Snowflake IDs, and decomposes them into their fields.
+//
+// Install it with:
+//
+//	go install github.com/kawabatas/go-id-generator/cmd/snwflake@latest
+//
+// Usage:
+//
+//	snwflake [--datacenter n] [--machine n] [--random] [--basetime RFC3339] [--count n] [--format decimal|hex|base62|binary]
+//	snwflake --parse id [--basetime RFC3339] [--format decimal|hex|base62|binary]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	sf "github.com/kawabatas/go-id-generator"
+)
+
+var errInvalidFormat = errors.New("invalid format")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "snwflake:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("snwflake", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	datacenter := fs.Int("datacenter", 0, "datacenter ID (0-31)")
+	machine := fs.Int("machine", 0, "machine ID (0-31)")
+	random := fs.Bool("random", false, "pick random datacenter and machine IDs if they are not specified")
+	baseTime := fs.String("basetime", "", "base time in RFC3339 (default 2024-01-01T00:00:00Z)")
+	count := fs.Int("count", 1, "number of IDs to generate")
+	format := fs.String("format", "decimal", "format of IDs: decimal, hex, base62, or binary")
+	parse := fs.String("parse", "", "decompose the `id` in the format into its fields instead of generating IDs")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var bt time.Time
+	if *baseTime != "" {
+		var err error
+		if bt, err = time.Parse(time.RFC3339, *baseTime); err != nil {
+			return fmt.Errorf("invalid base time: %w", err)
+		}
+	}
+
+	if *parse != "" {
+		id, err := parseID(*parse, *format)
+		if err != nil {
+			return err
+		}
+		c, err := sf.ParseSnowflakeID(id.Int64(), bt, sf.Layout{})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "id: %d\n", id)
+		fmt.Fprintf(stdout, "timestamp: %s\n", c.Timestamp.Format(time.RFC3339Nano))
+		fmt.Fprintf(stdout, "datacenter_id: %d\n", c.DatacenterID)
+		fmt.Fprintf(stdout, "machine_id: %d\n", c.MachineID)
+		fmt.Fprintf(stdout, "sequence_number: %d\n", c.SequenceNumber)
+		return nil
+	}
+
+	opts := []sf.Option{sf.WithDatacenterID(*datacenter), sf.WithMachineID(*machine)}
+	if *random {
+		opts = append(opts, sf.WithRandomEnabled())
+	}
+	if !bt.IsZero() {
+		opts = append(opts, sf.WithBaseTime(bt))
+	}
+	g, err := sf.NewGenerator(opts...)
+	if err != nil {
+		return err
+	}
+	ids, err := g.NextN(*count)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		s, err := formatID(id, *format)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, s)
+	}
+	return nil
+}
+
+func formatID(id sf.SnowflakeID, format string) (string, error) {
+	switch format {
+	case "decimal":
+		return id.String(), nil
+	case "hex":
+		return fmt.Sprintf("%016x", uint64(id)), nil
+	case "base62":
+		return id.Base62(), nil
+	case "binary":
+		return fmt.Sprintf("%064b", uint64(id)), nil
+	default:
+		return "", fmt.Errorf("%w: %q", errInvalidFormat, format)
+	}
+}
+
+func parseID(s, format string) (sf.SnowflakeID, error) {
+	var base int
+	switch format {
+	case "decimal":
+		base = 10
+	case "hex":
+		base = 16
+	case "base62":
+		return sf.ParseBase62(s)
+	case "binary":
+		base = 2
+	default:
+		return 0, fmt.Errorf("%w: %q", errInvalidFormat, format)
+	}
+	v, err := strconv.ParseInt(s, base, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", sf.ErrInvalidID, err)
+	}
+	return sf.SnowflakeID(v), nil
+}
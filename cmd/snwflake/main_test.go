@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	sf "github.com/kawabatas/go-id-generator"
+)
+
+func TestRun_Generate(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantLen int
+		wantErr error
+	}{
+		{"Default", nil, 1, nil},
+		{"Count", []string{"--count", "3"}, 3, nil},
+		{"Random", []string{"--random", "--format", "base62"}, 1, nil},
+		{"Error format", []string{"--format", "octal"}, 0, errInvalidFormat},
+		{"Error count", []string{"--count", "0"}, 0, sf.ErrInvalidCount},
+		{"Error datacenter", []string{"--datacenter", "32"}, 0, sf.ErrInvalidDatacenterID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(tt.args, &stdout, &stderr)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("run() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := len(strings.Fields(stdout.String())); got != tt.wantLen {
+				t.Errorf("run() printed %v IDs, want %v", got, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestRun_Format(t *testing.T) {
+	for _, format := range []string{"decimal", "hex", "base62", "binary"} {
+		t.Run(format, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if err := run([]string{"--datacenter", "31", "--machine", "15", "--format", format}, &stdout, &stderr); err != nil {
+				t.Fatalf("run() error = %v", err)
+			}
+			// The printed ID is parsed back in the same format.
+			id, err := parseID(strings.TrimSpace(stdout.String()), format)
+			if err != nil {
+				t.Fatalf("parseID() error = %v", err)
+			}
+			if got := sf.ExtractDatacenterID(id.Int64()); got != 31 {
+				t.Errorf("datacenter ID = %v, want %v", got, 31)
+			}
+		})
+	}
+}
+
+func TestRun_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			"Decimal",
+			[]string{"--parse", "11234023837724673"},
+			"id: 11234023837724673\ntimestamp: 2024-02-01T00:00:00Z\ndatacenter_id: 31\nmachine_id: 15\nsequence_number: 1\n",
+			false,
+		},
+		{
+			"Hex",
+			[]string{"--parse", "0027e949003ef001", "--format", "hex"},
+			"id: 11234023837724673\ntimestamp: 2024-02-01T00:00:00Z\ndatacenter_id: 31\nmachine_id: 15\nsequence_number: 1\n",
+			false,
+		},
+		{
+			"Base time",
+			[]string{"--parse", "00pS1Hwq1mz", "--format", "base62", "--basetime", "2025-01-01T00:00:00Z"},
+			"id: 11234023837724673\ntimestamp: 2025-02-01T00:00:00Z\ndatacenter_id: 31\nmachine_id: 15\nsequence_number: 1\n",
+			false,
+		},
+		{"Error invalid ID", []string{"--parse", "abc"}, "", true},
+		{"Error invalid base time", []string{"--parse", "1", "--basetime", "2025-01-01"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(tt.args, &stdout, &stderr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := stdout.String(); got != tt.want {
+				t.Errorf("run() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Package cobra provides subcommands of github.com/spf13/cobra generating Snowflake IDs and decomposing them,
+// to embed them in an existing CLI, e.g., as "ids generate" and "ids parse":
+//
+//	ids := &cobra.Command{Use: "ids", Short: "Generate and parse Snowflake IDs"}
+//	ids.AddCommand(idcobra.NewGenerateCommand(nil), idcobra.NewParseCommand())
+//	rootCmd.AddCommand(ids)
+//
+// With --json, the output is JSON, with IDs as strings since a JavaScript number loses precision over 2^53.
+package cobra
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"github.com/spf13/cobra"
+)
+
+var ErrInvalidFormat = errors.New("invalid format")
+
+type generateResult struct {
+	IDs []string `json:"ids"`
+}
+
+type parseResult struct {
+	ID             idgenerator.SnowflakeID `json:"id"`
+	Timestamp      string                  `json:"timestamp"`
+	DatacenterID   int                     `json:"datacenter_id"`
+	MachineID      int                     `json:"machine_id"`
+	SequenceNumber int                     `json:"sequence_number"`
+}
+
+// NewGenerateCommand returns the "generate" command, which prints new IDs one per line.
+//
+// The flags --datacenter, --machine, --random, and --base-time configure the Generator.
+// If g is nil or any of them is specified, the command generates IDs with a new Generator built from the flags,
+// and otherwise with g, e.g., the Generator of the application.
+func NewGenerateCommand(g *idgenerator.Generator) *cobra.Command {
+	var (
+		datacenter, machine, count int
+		random, jsonOutput         bool
+		baseTime, format           string
+	)
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate Snowflake IDs",
+		Long: `Generate prints new Snowflake IDs one per line, or as {"ids": ["..."]} with --json.
+
+The IDs are generated by the Generator of the application, unless any of
+--datacenter, --machine, --random, and --base-time is specified.`,
+		Example: `  generate --count 3
+  generate --datacenter 1 --machine 2 --format base62 --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			gen := g
+			if gen == nil || cmd.Flags().Changed("datacenter") || cmd.Flags().Changed("machine") || cmd.Flags().Changed("random") || cmd.Flags().Changed("base-time") {
+				bt, err := parseBaseTime(baseTime)
+				if err != nil {
+					return err
+				}
+				opts := []idgenerator.Option{idgenerator.WithDatacenterID(datacenter), idgenerator.WithMachineID(machine), idgenerator.WithBaseTime(bt)}
+				if random {
+					opts = append(opts, idgenerator.WithRandomEnabled())
+				}
+				if gen, err = idgenerator.NewGenerator(opts...); err != nil {
+					return err
+				}
+			}
+
+			ids, err := gen.NextNCtx(cmd.Context(), count)
+			if err != nil {
+				return err
+			}
+			result := generateResult{IDs: make([]string, len(ids))}
+			for i, id := range ids {
+				if result.IDs[i], err = formatID(id, format); err != nil {
+					return err
+				}
+			}
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), result)
+			}
+			for _, s := range result.IDs {
+				fmt.Fprintln(cmd.OutOrStdout(), s)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&datacenter, "datacenter", 0, "datacenter ID (0-31)")
+	cmd.Flags().IntVar(&machine, "machine", 0, "machine ID (0-31)")
+	cmd.Flags().BoolVar(&random, "random", false, "pick random datacenter and machine IDs if they are not specified")
+	cmd.Flags().StringVar(&baseTime, "base-time", "", "base time in RFC3339 (default 2024-01-01T00:00:00Z)")
+	cmd.Flags().IntVar(&count, "count", 1, "number of IDs to generate")
+	cmd.Flags().StringVar(&format, "format", "decimal", "format of IDs: decimal, hex, base62, or binary")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the IDs in JSON")
+	return cmd
+}
+
+// NewParseCommand returns the "parse" command, which decomposes an ID into its fields.
+func NewParseCommand() *cobra.Command {
+	var (
+		baseTime, format string
+		jsonOutput       bool
+	)
+	cmd := &cobra.Command{
+		Use:   "parse <id>",
+		Short: "Decompose a Snowflake ID into its fields",
+		Long: `Parse prints the timestamp, the datacenter ID, the machine ID, and the sequence number of the ID,
+one per line as "name: value", or as a JSON object with --json.
+
+The layout is detected from the ID, and the base time must be the one the ID was generated with.`,
+		Example: `  parse 11234023837724673
+  parse 0027e949003ef001 --format hex --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bt, err := parseBaseTime(baseTime)
+			if err != nil {
+				return err
+			}
+			id, err := parseID(args[0], format)
+			if err != nil {
+				return err
+			}
+			c, err := idgenerator.ParseSnowflakeID(id.Int64(), bt, idgenerator.Layout{})
+			if err != nil {
+				return err
+			}
+			result := parseResult{
+				ID:             id,
+				Timestamp:      c.Timestamp.Format(time.RFC3339Nano),
+				DatacenterID:   c.DatacenterID,
+				MachineID:      c.MachineID,
+				SequenceNumber: c.SequenceNumber,
+			}
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), result)
+			}
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "id: %d\n", result.ID)
+			fmt.Fprintf(w, "timestamp: %s\n", result.Timestamp)
+			fmt.Fprintf(w, "datacenter_id: %d\n", result.DatacenterID)
+			fmt.Fprintf(w, "machine_id: %d\n", result.MachineID)
+			fmt.Fprintf(w, "sequence_number: %d\n", result.SequenceNumber)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&baseTime, "base-time", "", "base time in RFC3339 (default 2024-01-01T00:00:00Z)")
+	cmd.Flags().StringVar(&format, "format", "decimal", "format of the ID: decimal, hex, base62, or binary")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the fields in JSON")
+	return cmd
+}
+
+func parseBaseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid base time: %w", err)
+	}
+	return t, nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+func formatID(id idgenerator.SnowflakeID, format string) (string, error) {
+	switch format {
+	case "decimal":
+		return id.String(), nil
+	case "hex":
+		return fmt.Sprintf("%016x", uint64(id)), nil
+	case "base62":
+		return id.Base62(), nil
+	case "binary":
+		return fmt.Sprintf("%064b", uint64(id)), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidFormat, format)
+	}
+}
+
+func parseID(s, format string) (idgenerator.SnowflakeID, error) {
+	var base int
+	switch format {
+	case "decimal":
+		base = 10
+	case "hex":
+		base = 16
+	case "base62":
+		return idgenerator.ParseBase62(s)
+	case "binary":
+		base = 2
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrInvalidFormat, format)
+	}
+	v, err := strconv.ParseInt(s, base, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", idgenerator.ErrInvalidID, err)
+	}
+	return idgenerator.SnowflakeID(v), nil
+}
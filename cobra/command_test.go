@@ -0,0 +1,150 @@
+package cobra
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"github.com/spf13/cobra"
+)
+
+func execute(t *testing.T, cmd *cobra.Command, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestNewGenerateCommand(t *testing.T) {
+	app, err := idgenerator.NewGenerator(idgenerator.WithDatacenterID(3), idgenerator.WithMachineID(4))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	tests := []struct {
+		name             string
+		g                *idgenerator.Generator
+		args             []string
+		wantCount        int
+		wantDatacenterID int
+		wantMachineID    int
+	}{
+		{"Without a Generator", nil, nil, 1, 0, 0},
+		{"Generator of the application", app, []string{"--count", "3"}, 3, 3, 4},
+		{"Flags override the Generator", app, []string{"--datacenter", "31", "--machine", "15", "--count", "2"}, 2, 31, 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := execute(t, NewGenerateCommand(tt.g), tt.args...)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			lines := strings.Fields(out)
+			if len(lines) != tt.wantCount {
+				t.Fatalf("Execute() printed %q, want %d IDs", out, tt.wantCount)
+			}
+			for _, line := range lines {
+				id, err := parseID(line, "decimal")
+				if err != nil {
+					t.Fatalf("parseID() error = %v", err)
+				}
+				if got := idgenerator.ExtractDatacenterID(int64(id)); got != tt.wantDatacenterID {
+					t.Errorf("ExtractDatacenterID() = %v, want %v", got, tt.wantDatacenterID)
+				}
+				if got := idgenerator.ExtractMachineID(int64(id)); got != tt.wantMachineID {
+					t.Errorf("ExtractMachineID() = %v, want %v", got, tt.wantMachineID)
+				}
+			}
+		})
+	}
+}
+
+func TestNewGenerateCommand_JSON(t *testing.T) {
+	out, err := execute(t, NewGenerateCommand(nil), "--count", "2", "--format", "base62", "--json")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var result struct {
+		IDs []string `json:"ids"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("json.Unmarshal(%q) error = %v", out, err)
+	}
+	if len(result.IDs) != 2 {
+		t.Fatalf("Execute() = %v, want 2 IDs", result.IDs)
+	}
+	for _, s := range result.IDs {
+		if _, err := idgenerator.ParseBase62(s); err != nil {
+			t.Errorf("ParseBase62(%q) error = %v", s, err)
+		}
+	}
+}
+
+func TestNewGenerateCommand_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{"Invalid datacenter ID", []string{"--datacenter", "32"}, idgenerator.ErrInvalidDatacenterID},
+		{"Invalid count", []string{"--count", "0"}, idgenerator.ErrInvalidCount},
+		{"Invalid format", []string{"--format", "octal"}, ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := execute(t, NewGenerateCommand(nil), tt.args...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := execute(t, NewGenerateCommand(nil), "--base-time", "yesterday"); err == nil {
+		t.Errorf("Execute() with an invalid base time error = nil, want error")
+	}
+}
+
+func TestNewParseCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			"Decimal",
+			[]string{"11234023837724673"},
+			"id: 11234023837724673\ntimestamp: 2024-02-01T00:00:00Z\ndatacenter_id: 31\nmachine_id: 15\nsequence_number: 1\n",
+		},
+		{
+			"Hex in JSON",
+			[]string{"0027e949003ef001", "--format", "hex", "--json"},
+			`{"id":"11234023837724673","timestamp":"2024-02-01T00:00:00Z","datacenter_id":31,"machine_id":15,"sequence_number":1}` + "\n",
+		},
+		{
+			"Custom base time",
+			[]string{"11234023837724673", "--base-time", "2020-01-01T00:00:00Z"},
+			"id: 11234023837724673\ntimestamp: 2020-02-01T00:00:00Z\ndatacenter_id: 31\nmachine_id: 15\nsequence_number: 1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := execute(t, NewParseCommand(), tt.args...)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := execute(t, NewParseCommand(), "abc"); !errors.Is(err, idgenerator.ErrInvalidID) {
+		t.Errorf("Execute() error = %v, want %v", err, idgenerator.ErrInvalidID)
+	}
+	if _, err := execute(t, NewParseCommand()); err == nil {
+		t.Errorf("Execute() without an ID error = nil, want error")
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/kawabatas/go-id-generator/cobra
+
+go 1.22.0
+
+require (
+	github.com/kawabatas/go-id-generator v0.0.0-00010101000000-000000000000
+	github.com/spf13/cobra v1.10.2
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.9 // indirect
+)
+
+replace github.com/kawabatas/go-id-generator => ../
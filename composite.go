@@ -0,0 +1,89 @@
+package idgenerator
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+// CompositeID is a 128-bit ID that pairs two Snowflake IDs, for an ID space larger than 63 bits.
+// The high ID and the low ID are stored in big-endian order, so that comparing the bytes
+// gives the same order as comparing the high IDs first and then the low IDs.
+type CompositeID [16]byte
+
+// NewCompositeID returns the CompositeID of high and low.
+func NewCompositeID(high, low SnowflakeID) CompositeID {
+	var c CompositeID
+	binary.BigEndian.PutUint64(c[:8], uint64(high))
+	binary.BigEndian.PutUint64(c[8:], uint64(low))
+	return c
+}
+
+// High returns the high Snowflake ID.
+func (c CompositeID) High() SnowflakeID {
+	return SnowflakeID(binary.BigEndian.Uint64(c[:8]))
+}
+
+// Low returns the low Snowflake ID.
+func (c CompositeID) Low() SnowflakeID {
+	return SnowflakeID(binary.BigEndian.Uint64(c[8:]))
+}
+
+// String returns the ID as a 32-character lowercase hex string.
+func (c CompositeID) String() string {
+	return hex.EncodeToString(c[:])
+}
+
+// ParseCompositeID parses a 32-character hex string returned by CompositeID.String.
+func ParseCompositeID(s string) (CompositeID, error) {
+	var c CompositeID
+	if len(s) != hex.EncodedLen(len(c)) {
+		return CompositeID{}, fmt.Errorf("%w: %q is not %d characters", ErrInvalidID, s, hex.EncodedLen(len(c)))
+	}
+	if _, err := hex.Decode(c[:], []byte(s)); err != nil {
+		return CompositeID{}, fmt.Errorf("%w: %q", ErrInvalidID, s)
+	}
+	return c, nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The ID is encoded as CompositeID.String.
+func (c CompositeID) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *CompositeID) UnmarshalText(b []byte) error {
+	v, err := ParseCompositeID(string(b))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// Scan implements sql.Scanner.
+// A 16-byte value (e.g., from a BINARY(16) or bytea column) and a hex string value (e.g., from a text column) are accepted.
+// A NULL value is scanned as the zero value.
+func (c *CompositeID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*c = CompositeID{}
+	case []byte:
+		if len(v) == len(c) {
+			copy(c[:], v)
+			return nil
+		}
+		return c.UnmarshalText(v)
+	case string:
+		return c.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidID, src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer. The ID is stored as CompositeID.String.
+func (c CompositeID) Value() (driver.Value, error) {
+	return c.String(), nil
+}
@@ -0,0 +1,123 @@
+package idgenerator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNewCompositeID(t *testing.T) {
+	c := NewCompositeID(11234023837724673, 1)
+	if got := c.High(); got != 11234023837724673 {
+		t.Errorf("CompositeID.High() = %v, want %v", got, 11234023837724673)
+	}
+	if got := c.Low(); got != 1 {
+		t.Errorf("CompositeID.Low() = %v, want %v", got, 1)
+	}
+	if got, want := c.String(), "0027e949003ef0010000000000000001"; got != want {
+		t.Errorf("CompositeID.String() = %v, want %v", got, want)
+	}
+}
+
+func TestCompositeID_Order(t *testing.T) {
+	ids := []CompositeID{
+		NewCompositeID(1, 2),
+		NewCompositeID(1, 3),
+		NewCompositeID(2, 0),
+		NewCompositeID(11234023837724673, 0),
+	}
+	for i := 1; i < len(ids); i++ {
+		if bytes.Compare(ids[i-1][:], ids[i][:]) >= 0 {
+			t.Errorf("CompositeID %v is not less than %v", ids[i-1], ids[i])
+		}
+	}
+}
+
+func TestParseCompositeID(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    CompositeID
+		wantErr bool
+	}{
+		{"Lowercase", "0027e949003ef0010000000000000001", NewCompositeID(11234023837724673, 1), false},
+		{"Uppercase", "0027E949003EF0010000000000000001", NewCompositeID(11234023837724673, 1), false},
+		{"Error too short", "0027e949003ef001", CompositeID{}, true},
+		{"Error not hex", "0027e949003ef001000000000000000g", CompositeID{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCompositeID(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCompositeID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !errors.Is(err, ErrInvalidID) {
+				t.Errorf("ParseCompositeID() error = %v, want %v", err, ErrInvalidID)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCompositeID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompositeID_MarshalText(t *testing.T) {
+	type record struct {
+		ID CompositeID `json:"id"`
+	}
+	b, err := json.Marshal(record{NewCompositeID(11234023837724673, 1)})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if got, want := string(b), `{"id":"0027e949003ef0010000000000000001"}`; got != want {
+		t.Errorf("json.Marshal() = %v, want %v", got, want)
+	}
+	var r record
+	if err := json.Unmarshal(b, &r); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if want := NewCompositeID(11234023837724673, 1); r.ID != want {
+		t.Errorf("json.Unmarshal() = %v, want %v", r.ID, want)
+	}
+}
+
+func TestCompositeID_Scan(t *testing.T) {
+	want := NewCompositeID(11234023837724673, 1)
+	tests := []struct {
+		name    string
+		src     any
+		want    CompositeID
+		wantErr bool
+	}{
+		{"binary column", want[:], want, false},
+		{"text column as []byte", []byte("0027e949003ef0010000000000000001"), want, false},
+		{"text column as string", "0027e949003ef0010000000000000001", want, false},
+		{"NULL", nil, CompositeID{}, false},
+		{"Error invalid text", "abc", CompositeID{}, true},
+		{"Error unsupported type", int64(1), CompositeID{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCompositeID(1, 1)
+			err := c.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CompositeID.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && c != tt.want {
+				t.Errorf("CompositeID.Scan() = %v, want %v", c, tt.want)
+			}
+		})
+	}
+
+	v, err := want.Value()
+	if err != nil {
+		t.Fatalf("CompositeID.Value() error = %v", err)
+	}
+	var got CompositeID
+	if err := got.Scan(v); err != nil || got != want {
+		t.Errorf("CompositeID.Scan(Value()) = %v, %v, want %v", got, err, want)
+	}
+}
@@ -0,0 +1,115 @@
+package idgenerator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Config is the configuration of a Generator as a struct, which can be serialized, logged, and validated at once.
+type Config struct {
+	DatacenterID       int           `json:"datacenter_id" yaml:"datacenter_id"`
+	MachineID          int           `json:"machine_id" yaml:"machine_id"`
+	BaseTime           time.Time     `json:"base_time" yaml:"base_time"`
+	Random             bool          `json:"random" yaml:"random"`
+	SequenceStart      int           `json:"sequence_start" yaml:"sequence_start"`
+	ClockSkewTolerance time.Duration `json:"clock_skew_tolerance" yaml:"clock_skew_tolerance"`
+}
+
+// configJSON is the JSON representation of Config.
+// BaseTime is omitted when it is zero, and ClockSkewTolerance is a duration string such as "5ms".
+type configJSON struct {
+	DatacenterID       int        `json:"datacenter_id"`
+	MachineID          int        `json:"machine_id"`
+	BaseTime           *time.Time `json:"base_time,omitempty"`
+	Random             bool       `json:"random"`
+	SequenceStart      int        `json:"sequence_start"`
+	ClockSkewTolerance string     `json:"clock_skew_tolerance,omitempty"`
+}
+
+// Validate returns all validation failures of the configuration. It returns nil if the configuration is valid.
+func (c Config) Validate() []error {
+	var errs []error
+	if c.DatacenterID < 0 || c.DatacenterID > maxDatacenterID {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrInvalidDatacenterID, c.DatacenterID))
+	}
+	if c.MachineID < 0 || c.MachineID > maxMachineID {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrInvalidMachineID, c.MachineID))
+	}
+	if c.SequenceStart < 0 || c.SequenceStart > maxSequenceNumber {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrInvalidSequenceNumber, c.SequenceStart))
+	}
+	if c.ClockSkewTolerance < 0 {
+		errs = append(errs, fmt.Errorf("%w: %v", ErrInvalidClockSkewTolerance, c.ClockSkewTolerance))
+	}
+	return errs
+}
+
+// NewGeneratorFromConfig returns a new Generator configured by cfg.
+// If cfg is invalid, the returned error joins all validation failures.
+func NewGeneratorFromConfig(cfg Config) (*Generator, error) {
+	if errs := cfg.Validate(); len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return NewGenerator(cfg.options()...)
+}
+
+func (c Config) options() []Option {
+	opts := []Option{
+		WithDatacenterID(c.DatacenterID),
+		WithMachineID(c.MachineID),
+		WithSequenceNumber(c.SequenceStart),
+		WithClockSkewTolerance(c.ClockSkewTolerance),
+	}
+	if !c.BaseTime.IsZero() {
+		opts = append(opts, WithBaseTime(c.BaseTime))
+	}
+	if c.Random {
+		opts = append(opts, WithRandomEnabled())
+	}
+	return opts
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Config) MarshalJSON() ([]byte, error) {
+	v := configJSON{
+		DatacenterID:  c.DatacenterID,
+		MachineID:     c.MachineID,
+		Random:        c.Random,
+		SequenceStart: c.SequenceStart,
+	}
+	if !c.BaseTime.IsZero() {
+		v.BaseTime = &c.BaseTime
+	}
+	if c.ClockSkewTolerance != 0 {
+		v.ClockSkewTolerance = c.ClockSkewTolerance.String()
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Config) UnmarshalJSON(b []byte) error {
+	var v configJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	cfg := Config{
+		DatacenterID:  v.DatacenterID,
+		MachineID:     v.MachineID,
+		Random:        v.Random,
+		SequenceStart: v.SequenceStart,
+	}
+	if v.BaseTime != nil {
+		cfg.BaseTime = *v.BaseTime
+	}
+	if v.ClockSkewTolerance != "" {
+		d, err := time.ParseDuration(v.ClockSkewTolerance)
+		if err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidClockSkewTolerance, v.ClockSkewTolerance)
+		}
+		cfg.ClockSkewTolerance = d
+	}
+	*c = cfg
+	return nil
+}
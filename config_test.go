@@ -0,0 +1,123 @@
+package idgenerator
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		wantErrs []error
+	}{
+		{
+			"Valid",
+			Config{DatacenterID: 31, MachineID: 15, SequenceStart: 4095, ClockSkewTolerance: 5 * time.Millisecond},
+			nil,
+		},
+		{
+			"Zero value",
+			Config{},
+			nil,
+		},
+		{
+			"Error all fields",
+			Config{DatacenterID: 32, MachineID: -1, SequenceStart: 4096, ClockSkewTolerance: -time.Millisecond},
+			[]error{ErrInvalidDatacenterID, ErrInvalidMachineID, ErrInvalidSequenceNumber, ErrInvalidClockSkewTolerance},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cfg.Validate()
+			if len(got) != len(tt.wantErrs) {
+				t.Fatalf("Config.Validate() = %v, want %v", got, tt.wantErrs)
+			}
+			for i := range got {
+				if !errors.Is(got[i], tt.wantErrs[i]) {
+					t.Errorf("Config.Validate()[%d] = %v, want %v", i, got[i], tt.wantErrs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewGeneratorFromConfig(t *testing.T) {
+	cfg := Config{
+		DatacenterID: 31,
+		MachineID:    15,
+		BaseTime:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	g, err := NewGeneratorFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewGeneratorFromConfig() error = %v", err)
+	}
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	got, err := ParseSnowflakeID(int64(id), cfg.BaseTime, Layout{})
+	if err != nil {
+		t.Fatalf("ParseSnowflakeID() error = %v", err)
+	}
+	if got.DatacenterID != 31 || got.MachineID != 15 {
+		t.Errorf("Next() = %+v, want datacenter ID 31 and machine ID 15", got)
+	}
+
+	_, err = NewGeneratorFromConfig(Config{DatacenterID: 32, MachineID: 32})
+	if !errors.Is(err, ErrInvalidDatacenterID) || !errors.Is(err, ErrInvalidMachineID) {
+		t.Errorf("NewGeneratorFromConfig() error = %v, want both %v and %v", err, ErrInvalidDatacenterID, ErrInvalidMachineID)
+	}
+}
+
+func TestConfig_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		json string
+	}{
+		{
+			"All fields",
+			Config{
+				DatacenterID:       31,
+				MachineID:          15,
+				BaseTime:           time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				Random:             true,
+				SequenceStart:      1,
+				ClockSkewTolerance: 5 * time.Millisecond,
+			},
+			`{"datacenter_id":31,"machine_id":15,"base_time":"2020-01-01T00:00:00Z","random":true,"sequence_start":1,"clock_skew_tolerance":"5ms"}`,
+		},
+		{
+			"Zero value",
+			Config{},
+			`{"datacenter_id":0,"machine_id":0,"random":false,"sequence_start":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.cfg)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(b) != tt.json {
+				t.Errorf("json.Marshal() = %s, want %s", b, tt.json)
+			}
+			var got Config
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.cfg) {
+				t.Errorf("json.Unmarshal() = %+v, want %+v", got, tt.cfg)
+			}
+		})
+	}
+
+	var cfg Config
+	if err := json.Unmarshal([]byte(`{"clock_skew_tolerance":"5 ms"}`), &cfg); !errors.Is(err, ErrInvalidClockSkewTolerance) {
+		t.Errorf("json.Unmarshal() error = %v, want %v", err, ErrInvalidClockSkewTolerance)
+	}
+}
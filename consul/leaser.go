@@ -0,0 +1,233 @@
+// Package consul assigns worker IDs to Generators with sessions on Consul,
+// for environments managed by HashiCorp Consul where processes come and go and cannot have static worker IDs.
+//
+// A WorkerIDLeaser claims an unused worker ID by acquiring a key like idgenerator/workers/42 with a session,
+// which holds the hostname of the process and is renewed while the process runs:
+//
+//	l := consul.NewWorkerIDLeaser(client)
+//	defer l.Release()
+//	g, err := idgenerator.NewGenerator(consul.WithWorkerIDFromConsul(l))
+//
+// If the process dies without Release, the session expires after its TTL and the key is deleted,
+// so the worker ID can be claimed again.
+//
+// It is a separate module, so that the idgenerator package does not depend on the Consul client.
+package consul
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+const (
+	defaultPrefix  = "idgenerator/workers/"
+	defaultTTL     = 10 * time.Second
+	defaultMaxID   = 1023
+	releaseTimeout = 5 * time.Second
+	noWorkerID     = -1
+)
+
+// ErrNoWorkerID is returned when all the worker IDs are claimed.
+var ErrNoWorkerID = errors.New("no worker ID available")
+
+// WorkerIDLeaser claims a worker ID on Consul and keeps it while the process runs.
+// A WorkerIDLeaser is safe for concurrent use by multiple goroutines.
+type WorkerIDLeaser struct {
+	client   *api.Client
+	prefix   string
+	ttl      time.Duration
+	maxID    int
+	hostname string
+
+	id        int
+	sessionID string
+	cancel    context.CancelFunc
+
+	mutex sync.Mutex
+}
+
+// LeaserOption configures a WorkerIDLeaser.
+type LeaserOption func(*WorkerIDLeaser)
+
+// WithPrefix specifies the prefix of the keys. The default is "idgenerator/workers/".
+func WithPrefix(prefix string) LeaserOption {
+	return func(l *WorkerIDLeaser) {
+		l.prefix = prefix
+	}
+}
+
+// WithTTL specifies the TTL of the session, which Consul requires to be from 10 seconds to 24 hours.
+// The default is 10 seconds. A worker ID of a process that died without Release can be claimed again
+// after the session expires, which Consul may delay up to twice the TTL.
+func WithTTL(d time.Duration) LeaserOption {
+	return func(l *WorkerIDLeaser) {
+		l.ttl = d
+	}
+}
+
+// WithMaxID specifies the largest worker ID to claim. The default is 1023, the largest 10-bit worker ID.
+func WithMaxID(id int) LeaserOption {
+	return func(l *WorkerIDLeaser) {
+		l.maxID = id
+	}
+}
+
+// WithHostname specifies the value of the key. The default is the hostname of the process.
+func WithHostname(hostname string) LeaserOption {
+	return func(l *WorkerIDLeaser) {
+		l.hostname = hostname
+	}
+}
+
+// NewWorkerIDLeaser returns a new WorkerIDLeaser claiming a worker ID on client.
+func NewWorkerIDLeaser(client *api.Client, opts ...LeaserOption) *WorkerIDLeaser {
+	hostname, _ := os.Hostname()
+	l := &WorkerIDLeaser{
+		client:   client,
+		prefix:   defaultPrefix,
+		ttl:      defaultTTL,
+		maxID:    defaultMaxID,
+		hostname: hostname,
+		id:       noWorkerID,
+	}
+	for _, f := range opts {
+		f(l)
+	}
+	return l
+}
+
+// AcquireID claims the smallest unclaimed worker ID from 0 to the max ID, and returns it.
+// If a worker ID is already claimed by l, AcquireID returns it.
+//
+// The session is renewed in the background until ctx is done or Release is called, and then the key is deleted.
+// It returns ErrNoWorkerID if all the worker IDs are claimed.
+func (l *WorkerIDLeaser) AcquireID(ctx context.Context) (int, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.id != noWorkerID {
+		return l.id, nil
+	}
+
+	sessionID, _, err := l.client.Session().Create(&api.SessionEntry{
+		Name:     "idgenerator worker " + l.hostname,
+		TTL:      l.ttl.String(),
+		Behavior: api.SessionBehaviorDelete,
+	}, (&api.WriteOptions{}).WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	id, err := l.claim(ctx, sessionID)
+	if err != nil {
+		l.destroy(sessionID, noWorkerID)
+		return 0, err
+	}
+
+	renewCtx, cancel := context.WithCancel(ctx)
+	l.id, l.sessionID, l.cancel = id, sessionID, cancel
+	go func() {
+		// Renew the session until it expires or renewCtx is done, and delete the key if it is not released yet.
+		// The session is destroyed by release, not by RenewPeriodic, so that the key is deleted first.
+		_ = l.client.Session().RenewPeriodic(l.ttl.String(), sessionID, (&api.WriteOptions{}).WithContext(renewCtx), nil)
+		l.release(sessionID)
+	}()
+	return id, nil
+}
+
+func (l *WorkerIDLeaser) claim(ctx context.Context, sessionID string) (int, error) {
+	opts := (&api.WriteOptions{}).WithContext(ctx)
+	for id := 0; id <= l.maxID; id++ {
+		ok, _, err := l.client.KV().Acquire(&api.KVPair{
+			Key:     l.key(id),
+			Value:   []byte(l.hostname),
+			Session: sessionID,
+		}, opts)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return id, nil
+		}
+	}
+	return 0, ErrNoWorkerID
+}
+
+func (l *WorkerIDLeaser) key(id int) string {
+	return l.prefix + strconv.Itoa(id)
+}
+
+// ID returns the claimed worker ID, or false if no worker ID is claimed.
+// It also returns false after the session is lost, e.g., when Consul is unreachable for longer than the TTL.
+func (l *WorkerIDLeaser) ID() (int, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.id, l.id != noWorkerID
+}
+
+// Release stops renewing the session and deletes the key of the claimed worker ID.
+// Stop generating IDs with the worker ID before Release, since another process may claim it right after.
+func (l *WorkerIDLeaser) Release() error {
+	l.mutex.Lock()
+	sessionID, cancel := l.sessionID, l.cancel
+	l.mutex.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	return l.release(sessionID)
+}
+
+// release deletes the key and destroys the session, if it is still the current session.
+func (l *WorkerIDLeaser) release(sessionID string) error {
+	l.mutex.Lock()
+	if l.sessionID != sessionID || sessionID == "" {
+		l.mutex.Unlock()
+		return nil
+	}
+	id := l.id
+	l.id, l.sessionID, l.cancel = noWorkerID, "", nil
+	l.mutex.Unlock()
+	return l.destroy(sessionID, id)
+}
+
+// destroy deletes the key of id if it is still held by the session, and then destroys the session.
+// The key is deleted before the session, so that Consul does not apply the lock delay to the key.
+func (l *WorkerIDLeaser) destroy(sessionID string, id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	opts := (&api.WriteOptions{}).WithContext(ctx)
+	var errs []error
+	if id != noWorkerID {
+		pair, _, err := l.client.KV().Get(l.key(id), (&api.QueryOptions{}).WithContext(ctx))
+		if err != nil {
+			errs = append(errs, err)
+		} else if pair != nil && pair.Session == sessionID {
+			// The check-and-set does not delete the key claimed by another process after the session expired.
+			if _, _, err := l.client.KV().DeleteCAS(pair, opts); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if _, err := l.client.Session().Destroy(sessionID, opts); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// WithWorkerIDFromConsul specifies the worker ID of a Generator claimed by l, as with idgenerator.WithWorkerID.
+// It claims a worker ID with AcquireID if l has not claimed one yet, and keeps it until l.Release is called.
+func WithWorkerIDFromConsul(l *WorkerIDLeaser) idgenerator.Option {
+	return idgenerator.LazyOption(func() (idgenerator.Option, error) {
+		id, err := l.AcquireID(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return idgenerator.WithWorkerID(id), nil
+	})
+}
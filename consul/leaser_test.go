@@ -0,0 +1,125 @@
+package consul
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// newClient starts a local Consul agent, which requires the consul binary on $PATH.
+func newClient(t *testing.T) *api.Client {
+	t.Helper()
+	if _, err := exec.LookPath("consul"); err != nil {
+		t.Skip("consul is not found on $PATH")
+	}
+	srv, err := testutil.NewTestServerConfigT(t, func(c *testutil.TestServerConfig) {
+		c.LogLevel = "error"
+	})
+	if err != nil {
+		t.Fatalf("NewTestServerConfigT() error = %v", err)
+	}
+	t.Cleanup(func() { srv.Stop() })
+
+	client, err := api.NewClient(&api.Config{Address: srv.HTTPAddr})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestWorkerIDLeaser(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	l1 := NewWorkerIDLeaser(client, WithHostname("node-1"), WithMaxID(1))
+	l2 := NewWorkerIDLeaser(client, WithHostname("node-2"), WithMaxID(1))
+	l3 := NewWorkerIDLeaser(client, WithHostname("node-3"), WithMaxID(1))
+
+	for i, l := range []*WorkerIDLeaser{l1, l2} {
+		id, err := l.AcquireID(ctx)
+		if err != nil {
+			t.Fatalf("AcquireID() error = %v", err)
+		}
+		if id != i {
+			t.Errorf("AcquireID() = %v, want %v", id, i)
+		}
+	}
+	// The claimed worker ID is returned again.
+	if id, err := l1.AcquireID(ctx); err != nil || id != 0 {
+		t.Errorf("AcquireID() = %v, %v, want %v", id, err, 0)
+	}
+
+	pair, _, err := client.KV().Get("idgenerator/workers/1", nil)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if pair == nil || string(pair.Value) != "node-2" || pair.Session == "" {
+		t.Errorf("Get() = %v, want %v held by a session", pair, "node-2")
+	}
+
+	if _, err := l3.AcquireID(ctx); !errors.Is(err, ErrNoWorkerID) {
+		t.Errorf("AcquireID() error = %v, want %v", err, ErrNoWorkerID)
+	}
+
+	// The released worker ID can be claimed again.
+	if err := l1.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, ok := l1.ID(); ok {
+		t.Error("ID() ok = true after Release, want false")
+	}
+	if id, err := l3.AcquireID(ctx); err != nil || id != 0 {
+		t.Errorf("AcquireID() = %v, %v, want %v", id, err, 0)
+	}
+}
+
+func TestWorkerIDLeaser_ContextDone(t *testing.T) {
+	client := newClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	l := NewWorkerIDLeaser(client)
+	if _, err := l.AcquireID(ctx); err != nil {
+		t.Fatalf("AcquireID() error = %v", err)
+	}
+	cancel()
+
+	// The key is deleted in the background after ctx is done.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		pair, _, err := client.KV().Get("idgenerator/workers/0", nil)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if pair == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("key is not deleted after ctx is done")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWithWorkerIDFromConsul(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+	other := NewWorkerIDLeaser(client)
+	if _, err := other.AcquireID(ctx); err != nil {
+		t.Fatalf("AcquireID() error = %v", err)
+	}
+
+	l := NewWorkerIDLeaser(client)
+	defer l.Release()
+	g, err := idgenerator.NewGenerator(WithWorkerIDFromConsul(l))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if got, want := g.DatacenterID()<<5|g.MachineID(), 1; got != want {
+		t.Errorf("worker ID = %v, want %v", got, want)
+	}
+}
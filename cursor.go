@@ -0,0 +1,92 @@
+package idgenerator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Cursor encodes Snowflake IDs as opaque page tokens for cursor-based pagination, and decodes them.
+//
+// The zero value encodes an ID in URL-safe base64 without padding, as an 11-character string.
+// Such a cursor is opaque, but can be forged by a client.
+// When Key is set, the ID is signed with HMAC-SHA256 as a SignedID, and a tampered cursor is rejected.
+type Cursor struct {
+	Key []byte
+}
+
+// Encode returns the cursor of id.
+func (c Cursor) Encode(id SnowflakeID) string {
+	if c.Key != nil {
+		return SignID(id, c.Key).String()
+	}
+	return signedIDEncoding.EncodeToString(binary.BigEndian.AppendUint64(nil, uint64(id)))
+}
+
+// Decode returns the ID of a cursor encoded by Encode.
+// It returns ErrInvalidCursor if the cursor is malformed, and ErrInvalidSignature if it is tampered.
+func (c Cursor) Decode(token string) (SnowflakeID, error) {
+	var id SnowflakeID
+	if c.Key != nil {
+		var err error
+		if id, err = VerifySignedID(token, c.Key); err == ErrInvalidSignature {
+			return 0, err
+		} else if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidCursor, token)
+		}
+	} else {
+		b, err := signedIDEncoding.DecodeString(token)
+		if err != nil || len(b) != 8 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidCursor, token)
+		}
+		id = SnowflakeID(binary.BigEndian.Uint64(b))
+	}
+	if id < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCursor, token)
+	}
+	return id, nil
+}
+
+// PageAfter returns the IDRange of the IDs after the ID of the cursor, which is the same as WHERE id > cursor.
+func (c Cursor) PageAfter(token string) (IDRange, error) {
+	id, err := c.Decode(token)
+	if err != nil {
+		return IDRange{}, err
+	}
+	if id == math.MaxInt64 {
+		return IDRange{}, fmt.Errorf("%w: no ID after %d", ErrInvalidCursor, id)
+	}
+	return IDRange{Start: id + 1, End: math.MaxInt64}, nil
+}
+
+// PageBefore returns the IDRange of the IDs before the ID of the cursor, which is the same as WHERE id < cursor.
+func (c Cursor) PageBefore(token string) (IDRange, error) {
+	id, err := c.Decode(token)
+	if err != nil {
+		return IDRange{}, err
+	}
+	if id == 0 {
+		return IDRange{}, fmt.Errorf("%w: no ID before %d", ErrInvalidCursor, id)
+	}
+	return IDRange{Start: 0, End: id - 1}, nil
+}
+
+// EncodeCursor returns the unsigned cursor of id. Use a Cursor with a key to sign it.
+func EncodeCursor(id SnowflakeID) string {
+	return Cursor{}.Encode(id)
+}
+
+// DecodeCursor returns the ID of an unsigned cursor encoded by EncodeCursor.
+func DecodeCursor(token string) (SnowflakeID, error) {
+	return Cursor{}.Decode(token)
+}
+
+// PageAfter returns the IDRange of the IDs after the ID of an unsigned cursor. See Cursor.PageAfter.
+func PageAfter(token string) (IDRange, error) {
+	return Cursor{}.PageAfter(token)
+}
+
+// PageBefore returns the IDRange of the IDs before the ID of an unsigned cursor. See Cursor.PageBefore.
+func PageBefore(token string) (IDRange, error) {
+	return Cursor{}.PageBefore(token)
+}
@@ -0,0 +1,112 @@
+package idgenerator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEncodeCursor(t *testing.T) {
+	if got, want := EncodeCursor(11234023837724673), "ACfpSQA-8AE"; got != want {
+		t.Errorf("EncodeCursor() = %v, want %v", got, want)
+	}
+	if got, want := (Cursor{Key: []byte("secret")}).Encode(11234023837724673), "ACfpSQA-8AE3wlHSxVM"; got != want {
+		t.Errorf("Cursor.Encode() = %v, want %v", got, want)
+	}
+}
+
+func TestCursor_Decode(t *testing.T) {
+	type args struct {
+		token string
+	}
+	tests := []struct {
+		name    string
+		c       Cursor
+		args    args
+		want    SnowflakeID
+		wantErr error
+	}{
+		{"Unsigned", Cursor{}, args{"ACfpSQA-8AE"}, 11234023837724673, nil},
+		{"Signed", Cursor{Key: []byte("secret")}, args{"ACfpSQA-8AE3wlHSxVM"}, 11234023837724673, nil},
+		{"Error tampered", Cursor{Key: []byte("secret")}, args{"ACfpSQA-8AI3wlHSxVM"}, 0, ErrInvalidSignature},
+		{"Error unsigned cursor for a key", Cursor{Key: []byte("secret")}, args{"ACfpSQA-8AE"}, 0, ErrInvalidCursor},
+		{"Error signed cursor without a key", Cursor{}, args{"ACfpSQA-8AE3wlHSxVM"}, 0, ErrInvalidCursor},
+		{"Error negative ID", Cursor{}, args{"__________8"}, 0, ErrInvalidCursor},
+		{"Error not base64", Cursor{}, args{"ACfpSQA+8AE"}, 0, ErrInvalidCursor},
+		{"Error empty", Cursor{}, args{""}, 0, ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.Decode(tt.args.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Cursor.Decode() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Cursor.Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursor_RoundTrip(t *testing.T) {
+	for _, id := range []SnowflakeID{0, 1, 11234023837724673, math.MaxInt64} {
+		got, err := DecodeCursor(EncodeCursor(id))
+		if err != nil {
+			t.Fatalf("DecodeCursor() error = %v", err)
+		}
+		if got != id {
+			t.Errorf("DecodeCursor() = %v, want %v", got, id)
+		}
+	}
+}
+
+func TestPageAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    IDRange
+		wantErr error
+	}{
+		{"Valid", EncodeCursor(11234023837724673), IDRange{Start: 11234023837724674, End: math.MaxInt64}, nil},
+		{"Error last ID", EncodeCursor(math.MaxInt64), IDRange{}, ErrInvalidCursor},
+		{"Error invalid cursor", "!", IDRange{}, ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PageAfter(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PageAfter() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PageAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageBefore(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    IDRange
+		wantErr error
+	}{
+		{"Valid", EncodeCursor(11234023837724673), IDRange{Start: 0, End: 11234023837724672}, nil},
+		{"Error first ID", EncodeCursor(0), IDRange{}, ErrInvalidCursor},
+		{"Error invalid cursor", "!", IDRange{}, ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PageBefore(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PageBefore() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PageBefore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package idgenerator
+
+import "sync"
+
+// Default is the Generator used by Generate.
+// It is initialized on the first call of Generate with random datacenter and machine IDs and the default base time.
+// Use SetDefault instead of assigning to it, so that it is replaced safely with concurrent calls of Generate.
+var Default *Generator
+
+var defaultMutex sync.RWMutex
+
+// Generate returns a new Snowflake ID generated by Default.
+//
+// Since Default picks random datacenter and machine IDs, IDs generated by different processes may collide
+// with a small probability. Use a Generator with fixed IDs when IDs are generated by many processes.
+func Generate() (SnowflakeID, error) {
+	g, err := getDefault()
+	if err != nil {
+		return 0, err
+	}
+	return g.Next()
+}
+
+func getDefault() (*Generator, error) {
+	defaultMutex.RLock()
+	g := Default
+	defaultMutex.RUnlock()
+	if g != nil {
+		return g, nil
+	}
+
+	defaultMutex.Lock()
+	defer defaultMutex.Unlock()
+	if Default == nil {
+		var err error
+		if Default, err = NewGenerator(WithRandomEnabled()); err != nil {
+			return nil, err
+		}
+	}
+	return Default, nil
+}
+
+// SetDefault replaces Default with g, e.g., to generate deterministic IDs in tests.
+// If g is nil, Default is initialized again on the next call of Generate.
+func SetDefault(g *Generator) {
+	defaultMutex.Lock()
+	defer defaultMutex.Unlock()
+	Default = g
+}
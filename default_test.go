@@ -0,0 +1,42 @@
+package idgenerator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	prev, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	got, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got <= prev {
+		t.Errorf("Generate() returned %v after %v, want increasing IDs", got, prev)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	defaultMutex.RLock()
+	original := Default
+	defaultMutex.RUnlock()
+	defer SetDefault(original)
+
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	SetDefault(g)
+
+	got, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got != 11234023837724672 {
+		t.Errorf("Generate() = %v, want %v", got, 11234023837724672)
+	}
+}
@@ -0,0 +1,59 @@
+package idgenerator
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	entityTypesMutex sync.RWMutex
+	// entityTypes maps an entity type ID to its name.
+	entityTypes = map[int]string{}
+)
+
+// WithEntityType embeds the entity type typeID from 0 to 31 in the IDs as the 5-bit machine ID,
+// e.g., 1 for users, 2 for orders, and 3 for products, so that an ID alone reveals its type by EntityType.
+//
+// The datacenter ID, specified by WithDatacenterID, is left as the identifier of the node,
+// so IDs are unique only if each node has a fixed datacenter ID, and a single Generator per entity type.
+// It returns ErrInvalidEntityType if typeID is out of range.
+func WithEntityType(typeID int) Option {
+	return func(s *snowflake) error {
+		if typeID < 0 || typeID > maxMachineID {
+			return fmt.Errorf("%w: %d is not from 0 to %d", ErrInvalidEntityType, typeID, maxMachineID)
+		}
+		s.machineID = typeID
+		return nil
+	}
+}
+
+// EntityType returns the entity type of the ID generated with WithEntityType, read from bits 12-16.
+func EntityType(id SnowflakeID) int {
+	return ExtractMachineID(int64(id))
+}
+
+// RegisterEntityType registers name as the name of the entity type typeID for EntityTypeName.
+// Register the types in an init function, e.g., RegisterEntityType("user", 1).
+// It returns ErrInvalidEntityType if typeID is out of range, or is already registered with another name.
+func RegisterEntityType(name string, typeID int) error {
+	if typeID < 0 || typeID > maxMachineID {
+		return fmt.Errorf("%w: %d is not from 0 to %d", ErrInvalidEntityType, typeID, maxMachineID)
+	}
+
+	entityTypesMutex.Lock()
+	defer entityTypesMutex.Unlock()
+	if registered, ok := entityTypes[typeID]; ok && registered != name {
+		return fmt.Errorf("%w: %d is already registered as %q", ErrInvalidEntityType, typeID, registered)
+	}
+	entityTypes[typeID] = name
+	return nil
+}
+
+// EntityTypeName returns the name of the entity type of the ID registered by RegisterEntityType,
+// and whether it is registered.
+func EntityTypeName(id SnowflakeID) (string, bool) {
+	entityTypesMutex.RLock()
+	defer entityTypesMutex.RUnlock()
+	name, ok := entityTypes[EntityType(id)]
+	return name, ok
+}
@@ -0,0 +1,82 @@
+package idgenerator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithEntityType(t *testing.T) {
+	tests := []struct {
+		name    string
+		typeID  int
+		wantErr error
+	}{
+		{"Zero", 0, nil},
+		{"Maximum", 31, nil},
+		{"Error negative", -1, ErrInvalidEntityType},
+		{"Error over 31", 32, ErrInvalidEntityType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(WithDatacenterID(7), WithEntityType(tt.typeID))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewGenerator() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			id, err := g.Next()
+			if err != nil {
+				t.Fatalf("Next() error = %v", err)
+			}
+			if got := EntityType(id); got != tt.typeID {
+				t.Errorf("EntityType() = %v, want %v", got, tt.typeID)
+			}
+			if got := ExtractDatacenterID(int64(id)); got != 7 {
+				t.Errorf("ExtractDatacenterID() = %v, want %v", got, 7)
+			}
+		})
+	}
+}
+
+func TestRegisterEntityType(t *testing.T) {
+	t.Cleanup(func() {
+		entityTypesMutex.Lock()
+		defer entityTypesMutex.Unlock()
+		entityTypes = map[int]string{}
+	})
+	if err := RegisterEntityType("user", 1); err != nil {
+		t.Fatalf("RegisterEntityType() error = %v", err)
+	}
+	if err := RegisterEntityType("user", 1); err != nil {
+		t.Errorf("RegisterEntityType() with the same name error = %v, want nil", err)
+	}
+	if err := RegisterEntityType("order", 1); !errors.Is(err, ErrInvalidEntityType) {
+		t.Errorf("RegisterEntityType() with another name error = %v, want %v", err, ErrInvalidEntityType)
+	}
+	if err := RegisterEntityType("product", 32); !errors.Is(err, ErrInvalidEntityType) {
+		t.Errorf("RegisterEntityType() error = %v, want %v", err, ErrInvalidEntityType)
+	}
+
+	tests := []struct {
+		name     string
+		typeID   int
+		wantName string
+		wantOK   bool
+	}{
+		{"Registered", 1, "user", true},
+		{"Unregistered", 2, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := NewSnowflakeID(WithEntityType(tt.typeID))
+			if err != nil {
+				t.Fatalf("NewSnowflakeID() error = %v", err)
+			}
+			name, ok := EntityTypeName(SnowflakeID(id))
+			if name != tt.wantName || ok != tt.wantOK {
+				t.Errorf("EntityTypeName() = %q, %v, want %q, %v", name, ok, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
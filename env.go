@@ -0,0 +1,60 @@
+package idgenerator
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// WithDatacenterIDFromEnv specifies the datacenter ID of Snowflake ID from the environment variable named key.
+// It returns an error if the variable is not set or is not a valid datacenter ID.
+func WithDatacenterIDFromEnv(key string) Option {
+	return withIntFromEnv(key, WithDatacenterID)
+}
+
+// WithMachineIDFromEnv specifies the machine ID of Snowflake ID from the environment variable named key.
+// It returns an error if the variable is not set or is not a valid machine ID.
+func WithMachineIDFromEnv(key string) Option {
+	return withIntFromEnv(key, WithMachineID)
+}
+
+// WithWorkerIDFromEnv specifies the 10-bit worker ID of Snowflake ID from the environment variable named key.
+// It returns an error if the variable is not set or is not a valid worker ID.
+func WithWorkerIDFromEnv(key string) Option {
+	return withIntFromEnv(key, WithWorkerID)
+}
+
+// WithBaseTimeFromEnv changes the Snowflake base time to the RFC 3339 timestamp in the environment variable named key.
+// If the variable is not set, the default base time is kept.
+// It returns an error if the timestamp is malformed or not in UTC (e.g., "2024-01-01T00:00:00Z").
+func WithBaseTimeFromEnv(key string) Option {
+	return func(s *snowflake) error {
+		env, ok := os.LookupEnv(key)
+		if !ok {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, env)
+		if err != nil {
+			return fmt.Errorf("%w: %s=%q is not an RFC 3339 timestamp", ErrInvalidEnv, key, env)
+		}
+		if _, offset := t.Zone(); offset != 0 {
+			return fmt.Errorf("%w: %s=%q is not in UTC", ErrInvalidEnv, key, env)
+		}
+		return WithBaseTime(t.UTC())(s)
+	}
+}
+
+func withIntFromEnv(key string, with func(int) Option) Option {
+	return func(s *snowflake) error {
+		env, ok := os.LookupEnv(key)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrEnvNotFound, key)
+		}
+		v, err := strconv.Atoi(env)
+		if err != nil {
+			return fmt.Errorf("%w: %s=%q", ErrInvalidEnv, key, env)
+		}
+		return with(v)(s)
+	}
+}
@@ -0,0 +1,88 @@
+package idgenerator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithIDFromEnv(t *testing.T) {
+	const key = "IDGENERATOR_TEST_ID"
+	tests := []struct {
+		name             string
+		opt              func(string) Option
+		env              string
+		setEnv           bool
+		wantDatacenterID int
+		wantMachineID    int
+		wantErr          error
+	}{
+		{"WithDatacenterIDFromEnv:31", WithDatacenterIDFromEnv, "31", true, 31, 0, nil},
+		{"WithMachineIDFromEnv:15", WithMachineIDFromEnv, "15", true, 0, 15, nil},
+		{"WithWorkerIDFromEnv:1007", WithWorkerIDFromEnv, "1007", true, 31, 15, nil},
+		{"Error not set", WithDatacenterIDFromEnv, "", false, 0, 0, ErrEnvNotFound},
+		{"Error not a number", WithMachineIDFromEnv, "abc", true, 0, 0, ErrInvalidEnv},
+		{"Error empty", WithWorkerIDFromEnv, "", true, 0, 0, ErrInvalidEnv},
+		{"Error invalid datacenter ID", WithDatacenterIDFromEnv, "32", true, 0, 0, ErrInvalidDatacenterID},
+		{"Error invalid machine ID", WithMachineIDFromEnv, "-1", true, 0, 0, ErrInvalidMachineID},
+		{"Error invalid worker ID", WithWorkerIDFromEnv, "1024", true, 0, 0, ErrInvalidWorkerID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setEnv {
+				t.Setenv(key, tt.env)
+			}
+			s := &snowflake{}
+			err := tt.opt(key)(s)
+			if err == nil {
+				err = s.validate()
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("option error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if s.datacenterID != tt.wantDatacenterID {
+				t.Errorf("option datacenter ID = %v, want %v", s.datacenterID, tt.wantDatacenterID)
+			}
+			if s.machineID != tt.wantMachineID {
+				t.Errorf("option machine ID = %v, want %v", s.machineID, tt.wantMachineID)
+			}
+		})
+	}
+}
+
+func TestWithBaseTimeFromEnv(t *testing.T) {
+	const key = "IDGENERATOR_TEST_BASE_TIME"
+	tests := []struct {
+		name    string
+		env     string
+		setEnv  bool
+		want    time.Time
+		wantErr error
+	}{
+		{"UTC", "2020-01-01T00:00:00Z", true, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"Zero offset", "2020-01-01T00:00:00+00:00", true, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"Not set", "", false, defaultBaseTime, nil},
+		{"Error not UTC", "2020-01-01T09:00:00+09:00", true, defaultBaseTime, ErrInvalidEnv},
+		{"Error malformed", "2020-01-01", true, defaultBaseTime, ErrInvalidEnv},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setEnv {
+				t.Setenv(key, tt.env)
+			}
+			s := &snowflake{}
+			err := WithBaseTimeFromEnv(key)(s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("WithBaseTimeFromEnv() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got := s.getBaseTime(); !got.Equal(tt.want) {
+				t.Errorf("WithBaseTimeFromEnv() base time = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Package etcd assigns worker IDs to Generators with leases on etcd,
+// for environments such as Kubernetes where processes come and go and cannot have static worker IDs.
+//
+// A WorkerIDLeaser claims an unused worker ID with a key like /idgenerator/workers/42,
+// which holds the hostname of the process and is kept alive while the process runs:
+//
+//	l := etcd.NewWorkerIDLeaser(client)
+//	defer l.Release()
+//	g, err := idgenerator.NewGenerator(etcd.WithWorkerIDFromEtcd(l))
+//
+// If the process dies without Release, the key expires after the TTL of the lease, and the worker ID can be claimed again.
+//
+// It is a separate module, so that the idgenerator package does not depend on the etcd client.
+package etcd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	defaultPrefix  = "/idgenerator/workers/"
+	defaultTTL     = 10 * time.Second
+	defaultMaxID   = 1023
+	releaseTimeout = 5 * time.Second
+	noWorkerID     = -1
+)
+
+// ErrNoWorkerID is returned when all the worker IDs are claimed.
+var ErrNoWorkerID = errors.New("no worker ID available")
+
+// WorkerIDLeaser claims a worker ID on etcd and keeps it while the process runs.
+// A WorkerIDLeaser is safe for concurrent use by multiple goroutines.
+type WorkerIDLeaser struct {
+	client   *clientv3.Client
+	prefix   string
+	ttl      time.Duration
+	maxID    int
+	hostname string
+
+	id      int
+	leaseID clientv3.LeaseID
+	cancel  context.CancelFunc
+
+	mutex sync.Mutex
+}
+
+// LeaserOption configures a WorkerIDLeaser.
+type LeaserOption func(*WorkerIDLeaser)
+
+// WithPrefix specifies the prefix of the keys. The default is "/idgenerator/workers/".
+func WithPrefix(prefix string) LeaserOption {
+	return func(l *WorkerIDLeaser) {
+		l.prefix = prefix
+	}
+}
+
+// WithTTL specifies the TTL of the lease, which is rounded up to seconds. The default is 10 seconds.
+// A worker ID of a process that died without Release can be claimed again after the TTL.
+func WithTTL(d time.Duration) LeaserOption {
+	return func(l *WorkerIDLeaser) {
+		l.ttl = d
+	}
+}
+
+// WithMaxID specifies the largest worker ID to claim. The default is 1023, the largest 10-bit worker ID.
+func WithMaxID(id int) LeaserOption {
+	return func(l *WorkerIDLeaser) {
+		l.maxID = id
+	}
+}
+
+// WithHostname specifies the value of the key. The default is the hostname of the process, e.g., the pod name.
+func WithHostname(hostname string) LeaserOption {
+	return func(l *WorkerIDLeaser) {
+		l.hostname = hostname
+	}
+}
+
+// NewWorkerIDLeaser returns a new WorkerIDLeaser claiming a worker ID on client.
+func NewWorkerIDLeaser(client *clientv3.Client, opts ...LeaserOption) *WorkerIDLeaser {
+	hostname, _ := os.Hostname()
+	l := &WorkerIDLeaser{
+		client:   client,
+		prefix:   defaultPrefix,
+		ttl:      defaultTTL,
+		maxID:    defaultMaxID,
+		hostname: hostname,
+		id:       noWorkerID,
+	}
+	for _, f := range opts {
+		f(l)
+	}
+	return l
+}
+
+// AcquireID claims the smallest unclaimed worker ID from 0 to the max ID, and returns it.
+// If a worker ID is already claimed by l, AcquireID returns it.
+//
+// The lease is renewed in the background until ctx is done or Release is called, and then the key is deleted.
+// It returns ErrNoWorkerID if all the worker IDs are claimed.
+func (l *WorkerIDLeaser) AcquireID(ctx context.Context) (int, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.id != noWorkerID {
+		return l.id, nil
+	}
+
+	ttl := int64((l.ttl + time.Second - 1) / time.Second)
+	lease, err := l.client.Grant(ctx, ttl)
+	if err != nil {
+		return 0, err
+	}
+	id, err := l.claim(ctx, lease.ID)
+	if err != nil {
+		l.revoke(lease.ID)
+		return 0, err
+	}
+
+	keepAliveCtx, cancel := context.WithCancel(ctx)
+	ch, err := l.client.KeepAlive(keepAliveCtx, lease.ID)
+	if err != nil {
+		cancel()
+		l.revoke(lease.ID)
+		return 0, err
+	}
+	l.id, l.leaseID, l.cancel = id, lease.ID, cancel
+	go func() {
+		// Drain the responses until the keep-alive stops, and delete the key if it is not released yet.
+		for range ch {
+		}
+		l.release(lease.ID)
+	}()
+	return id, nil
+}
+
+func (l *WorkerIDLeaser) claim(ctx context.Context, leaseID clientv3.LeaseID) (int, error) {
+	for id := 0; id <= l.maxID; id++ {
+		key := l.key(id)
+		resp, err := l.client.Txn(ctx).
+			If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+			Then(clientv3.OpPut(key, l.hostname, clientv3.WithLease(leaseID))).
+			Commit()
+		if err != nil {
+			return 0, err
+		}
+		if resp.Succeeded {
+			return id, nil
+		}
+	}
+	return 0, ErrNoWorkerID
+}
+
+func (l *WorkerIDLeaser) key(id int) string {
+	return l.prefix + strconv.Itoa(id)
+}
+
+// ID returns the claimed worker ID, or false if no worker ID is claimed.
+// It also returns false after the lease is lost, e.g., when etcd is unreachable for longer than the TTL.
+func (l *WorkerIDLeaser) ID() (int, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.id, l.id != noWorkerID
+}
+
+// Release stops renewing the lease and deletes the key of the claimed worker ID.
+// Stop generating IDs with the worker ID before Release, since another process may claim it right after.
+func (l *WorkerIDLeaser) Release() error {
+	l.mutex.Lock()
+	leaseID, cancel := l.leaseID, l.cancel
+	l.mutex.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	return l.release(leaseID)
+}
+
+// release revokes the lease, which deletes the key, if it is still the current lease.
+func (l *WorkerIDLeaser) release(leaseID clientv3.LeaseID) error {
+	l.mutex.Lock()
+	if l.leaseID != leaseID || leaseID == clientv3.NoLease {
+		l.mutex.Unlock()
+		return nil
+	}
+	l.id, l.leaseID, l.cancel = noWorkerID, clientv3.NoLease, nil
+	l.mutex.Unlock()
+	return l.revoke(leaseID)
+}
+
+func (l *WorkerIDLeaser) revoke(leaseID clientv3.LeaseID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	_, err := l.client.Revoke(ctx, leaseID)
+	return err
+}
+
+// WithWorkerIDFromEtcd specifies the worker ID of a Generator claimed by l, as with idgenerator.WithWorkerID.
+// It claims a worker ID with AcquireID if l has not claimed one yet, and keeps it until l.Release is called.
+func WithWorkerIDFromEtcd(l *WorkerIDLeaser) idgenerator.Option {
+	return idgenerator.LazyOption(func() (idgenerator.Option, error) {
+		id, err := l.AcquireID(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return idgenerator.WithWorkerID(id), nil
+	})
+}
@@ -0,0 +1,134 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+)
+
+func newClient(t *testing.T) *clientv3.Client {
+	t.Helper()
+	cfg := embed.NewConfig()
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	clientURL, _ := url.Parse("http://127.0.0.1:0")
+	peerURL, _ := url.Parse("http://127.0.0.1:0")
+	cfg.ListenClientUrls, cfg.AdvertiseClientUrls = []url.URL{*clientURL}, []url.URL{*clientURL}
+	cfg.ListenPeerUrls, cfg.AdvertisePeerUrls = []url.URL{*peerURL}, []url.URL{*peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	e, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatalf("StartEtcd() error = %v", err)
+	}
+	t.Cleanup(e.Close)
+	select {
+	case <-e.Server.ReadyNotify():
+	case <-time.After(10 * time.Second):
+		t.Fatal("etcd is not ready")
+	}
+
+	client, err := clientv3.New(clientv3.Config{Endpoints: []string{e.Clients[0].Addr().String()}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestWorkerIDLeaser(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	l1 := NewWorkerIDLeaser(client, WithHostname("pod-1"), WithMaxID(1))
+	l2 := NewWorkerIDLeaser(client, WithHostname("pod-2"), WithMaxID(1))
+	l3 := NewWorkerIDLeaser(client, WithHostname("pod-3"), WithMaxID(1))
+
+	for i, l := range []*WorkerIDLeaser{l1, l2} {
+		id, err := l.AcquireID(ctx)
+		if err != nil {
+			t.Fatalf("AcquireID() error = %v", err)
+		}
+		if id != i {
+			t.Errorf("AcquireID() = %v, want %v", id, i)
+		}
+	}
+	// The claimed worker ID is returned again.
+	if id, err := l1.AcquireID(ctx); err != nil || id != 0 {
+		t.Errorf("AcquireID() = %v, %v, want %v", id, err, 0)
+	}
+
+	resp, err := client.Get(ctx, "/idgenerator/workers/1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != "pod-2" {
+		t.Errorf("Get() = %v, want %v", resp.Kvs, "pod-2")
+	}
+
+	if _, err := l3.AcquireID(ctx); !errors.Is(err, ErrNoWorkerID) {
+		t.Errorf("AcquireID() error = %v, want %v", err, ErrNoWorkerID)
+	}
+
+	// The released worker ID can be claimed again.
+	if err := l1.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, ok := l1.ID(); ok {
+		t.Error("ID() ok = true after Release, want false")
+	}
+	if id, err := l3.AcquireID(ctx); err != nil || id != 0 {
+		t.Errorf("AcquireID() = %v, %v, want %v", id, err, 0)
+	}
+}
+
+func TestWorkerIDLeaser_ContextDone(t *testing.T) {
+	client := newClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	l := NewWorkerIDLeaser(client)
+	if _, err := l.AcquireID(ctx); err != nil {
+		t.Fatalf("AcquireID() error = %v", err)
+	}
+	cancel()
+
+	// The key is deleted in the background after ctx is done.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := client.Get(context.Background(), "/idgenerator/workers/0")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if len(resp.Kvs) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("key is not deleted after ctx is done")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWithWorkerIDFromEtcd(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+	other := NewWorkerIDLeaser(client)
+	if _, err := other.AcquireID(ctx); err != nil {
+		t.Fatalf("AcquireID() error = %v", err)
+	}
+
+	l := NewWorkerIDLeaser(client)
+	defer l.Release()
+	g, err := idgenerator.NewGenerator(WithWorkerIDFromEtcd(l))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if got, want := g.DatacenterID()<<5|g.MachineID(), 1; got != want {
+		t.Errorf("worker ID = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/kawabatas/go-id-generator/example/kafka
+
+go 1.23
+
+require (
+	github.com/kawabatas/go-id-generator v0.0.0-00010101000000-000000000000
+	github.com/segmentio/kafka-go v0.4.51
+)
+
+require (
+	github.com/klauspost/compress v1.15.9 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+)
+
+replace github.com/kawabatas/go-id-generator => ../../
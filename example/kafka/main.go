@@ -0,0 +1,84 @@
+// This example produces messages keyed by Snowflake IDs to Kafka with segmentio/kafka-go,
+// and reads back the messages produced in the last minute from a partition.
+//
+//	go run . -brokers localhost:9092 -topic events
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	sf "github.com/kawabatas/go-id-generator"
+	"github.com/segmentio/kafka-go"
+)
+
+func main() {
+	brokers := flag.String("brokers", "localhost:9092", "Kafka broker address")
+	topic := flag.String("topic", "events", "Kafka topic")
+	flag.Parse()
+	ctx := context.Background()
+
+	g, err := sf.NewGenerator(sf.WithRandomEnabled())
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := &kafka.Writer{Addr: kafka.TCP(*brokers), Topic: *topic, Balancer: &kafka.Hash{}}
+	defer w.Close()
+	for i := 0; i < 10; i++ {
+		id, err := g.Next()
+		if err != nil {
+			log.Fatal(err)
+		}
+		// The message timestamp is the time of the ID, so that offsets can be looked up by the time of the IDs.
+		msg := kafka.Message{
+			Key:   sf.GenerateKafkaKey(id),
+			Value: []byte(fmt.Sprintf("event %d", i)),
+			Time:  sf.ExtractTime(id.Int64(), time.Time{}),
+		}
+		if err := w.WriteMessages(ctx, msg); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	to := time.Now()
+	from := to.Add(-time.Minute)
+	minID, err := sf.MinIDForTime(from, time.Time{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	maxID, err := sf.MaxIDForTime(to, time.Time{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	minKey, maxKey := sf.GenerateKafkaKey(sf.SnowflakeID(minID)), sf.GenerateKafkaKey(sf.SnowflakeID(maxID))
+
+	r := kafka.NewReader(kafka.ReaderConfig{Brokers: []string{*brokers}, Topic: *topic, Partition: 0})
+	defer r.Close()
+	if err := r.SetOffsetAt(ctx, from); err != nil {
+		log.Fatal(err)
+	}
+	readCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	for {
+		msg, err := r.ReadMessage(readCtx)
+		if err != nil {
+			break
+		}
+		// The keys of this producer are in increasing order in the partition.
+		if bytes.Compare(msg.Key, maxKey) > 0 {
+			break
+		}
+		if bytes.Compare(msg.Key, minKey) < 0 {
+			continue
+		}
+		id, err := sf.ParseKafkaKey(msg.Key)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%d: %s\n", id, msg.Value)
+	}
+}
@@ -0,0 +1,30 @@
+// Package flatbuffers reads and writes Snowflake IDs in FlatBuffers.
+//
+// A SnowflakeID is a long, i.e., a 64-bit signed integer, in a FlatBuffers schema:
+//
+//	table Entity {
+//	  id: long;
+//	}
+//
+// With the code generated by flatc, convert the ID with int64(id) and SnowflakeID(entity.Id()).
+// AppendSnowflakeID and ReadSnowflakeID are for code building and reading buffers without flatc,
+// e.g., vectors of IDs.
+package flatbuffers
+
+import (
+	flatbuffers "github.com/google/flatbuffers/go"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// AppendSnowflakeID prepends id to the buffer being built by b, as FlatBuffers builds a buffer from the end,
+// and returns the offset of id from the end of the buffer.
+func AppendSnowflakeID(b *flatbuffers.Builder, id idgenerator.SnowflakeID) flatbuffers.UOffsetT {
+	b.PrependInt64(int64(id))
+	return b.Offset()
+}
+
+// ReadSnowflakeID reads the ID at offset of buf, which is the position from the start of buf,
+// e.g., the position of a table plus the offset of the field in the table.
+func ReadSnowflakeID(buf []byte, offset int) idgenerator.SnowflakeID {
+	return idgenerator.SnowflakeID(flatbuffers.GetInt64(buf[offset:]))
+}
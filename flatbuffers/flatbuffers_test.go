@@ -0,0 +1,83 @@
+package flatbuffers
+
+import (
+	"reflect"
+	"testing"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// The test table is:
+//
+//	table Entity {
+//	  id: long;
+//	  related: [long];
+//	}
+const (
+	entityIDSlot      = 0
+	entityRelatedSlot = 1
+	// entityFields is the number of the fields of Entity.
+	entityFields = 2
+)
+
+func buildEntity(id idgenerator.SnowflakeID, related []idgenerator.SnowflakeID) []byte {
+	b := flatbuffers.NewBuilder(0)
+	b.StartVector(8, len(related), 8)
+	for i := len(related) - 1; i >= 0; i-- {
+		AppendSnowflakeID(b, related[i])
+	}
+	relatedOffset := b.EndVector(len(related))
+
+	b.StartObject(entityFields)
+	b.PrependInt64Slot(entityIDSlot, int64(id), 0)
+	b.PrependUOffsetTSlot(entityRelatedSlot, relatedOffset, 0)
+	b.Finish(b.EndObject())
+	return b.FinishedBytes()
+}
+
+func readEntity(buf []byte) (id idgenerator.SnowflakeID, related []idgenerator.SnowflakeID) {
+	tab := flatbuffers.Table{Bytes: buf, Pos: flatbuffers.GetUOffsetT(buf)}
+	if o := tab.Offset(flatbuffers.VOffsetT(4 + 2*entityIDSlot)); o != 0 {
+		id = ReadSnowflakeID(buf, int(tab.Pos)+int(o))
+	}
+	if o := tab.Offset(flatbuffers.VOffsetT(4 + 2*entityRelatedSlot)); o != 0 {
+		start := int(tab.Vector(flatbuffers.UOffsetT(o)))
+		for i := 0; i < tab.VectorLen(flatbuffers.UOffsetT(o)); i++ {
+			related = append(related, ReadSnowflakeID(buf, start+8*i))
+		}
+	}
+	return id, related
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      idgenerator.SnowflakeID
+		related []idgenerator.SnowflakeID
+	}{
+		{"Example", 11234023837724673, []idgenerator.SnowflakeID{11234023837724674, 11234023837724675}},
+		{"Maximum value", 1<<63 - 1, []idgenerator.SnowflakeID{1<<63 - 1}},
+		{"Negative", -1, []idgenerator.SnowflakeID{-1, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, related := readEntity(buildEntity(tt.id, tt.related))
+			if id != tt.id {
+				t.Errorf("ReadSnowflakeID() = %v, want %v", id, tt.id)
+			}
+			if !reflect.DeepEqual(related, tt.related) {
+				t.Errorf("ReadSnowflakeID() of the vector = %v, want %v", related, tt.related)
+			}
+		})
+	}
+}
+
+func TestAppendSnowflakeID(t *testing.T) {
+	b := flatbuffers.NewBuilder(0)
+	offset := AppendSnowflakeID(b, 11234023837724673)
+	buf := b.Bytes
+	if got := ReadSnowflakeID(buf, len(buf)-int(offset)); got != 11234023837724673 {
+		t.Errorf("ReadSnowflakeID() = %v, want %v", got, 11234023837724673)
+	}
+}
@@ -0,0 +1,9 @@
+module github.com/kawabatas/go-id-generator/flatbuffers
+
+go 1.22.0
+
+require github.com/kawabatas/go-id-generator v0.0.0-00010101000000-000000000000
+
+require github.com/google/flatbuffers v25.12.19+incompatible
+
+replace github.com/kawabatas/go-id-generator => ../
@@ -0,0 +1,128 @@
+// Package gcp configures Generators from the Compute Engine metadata server,
+// so that instances started from the same template get their node IDs without per-instance configuration:
+//
+//	ctx := context.Background()
+//	g, err := idgenerator.NewGenerator(
+//		gcp.WithGCPZoneAsDatacenterID(ctx),
+//		gcp.WithMachineIDFromGCEMetadata(ctx),
+//	)
+//
+// The metadata server is metadata.google.internal, or the host in the GCE_METADATA_HOST environment variable if it is set.
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+const (
+	defaultHost     = "metadata.google.internal"
+	hostEnv         = "GCE_METADATA_HOST"
+	instanceIDPath  = "/computeMetadata/v1/instance/id"
+	zonePath        = "/computeMetadata/v1/instance/zone"
+	flavorHeader    = "Metadata-Flavor"
+	flavor          = "Google"
+	timeout         = 2 * time.Second
+	maxMachineID    = 31
+	maxDatacenterID = 31
+)
+
+var (
+	// ErrNotOnGCE is returned when the metadata server is not reachable or does not respond as the one of Compute Engine.
+	ErrNotOnGCE = errors.New("not running on Compute Engine")
+	// ErrInvalidZone is returned when the zone cannot be mapped to a datacenter ID.
+	ErrInvalidZone = errors.New("invalid zone")
+)
+
+// WithMachineIDFromGCEMetadata specifies the machine ID of Snowflake ID from the Compute Engine instance ID.
+// The FNV-32a hash of the numeric instance ID is reduced to a 5-bit machine ID by modulo,
+// so instances may collide with the same probability as idgenerator.WithMachineIDFromHostname.
+//
+// It fails fast with an error wrapping ErrNotOnGCE if the metadata server does not respond within 2 seconds,
+// e.g., when it is not running on Google Cloud, rather than generating IDs with a wrong machine ID.
+func WithMachineIDFromGCEMetadata(ctx context.Context) idgenerator.Option {
+	return idgenerator.LazyOption(func() (idgenerator.Option, error) {
+		instanceID, err := fetchMetadata(ctx, instanceIDPath)
+		if err != nil {
+			return nil, err
+		}
+		h := fnv.New32a()
+		h.Write([]byte(instanceID))
+		return idgenerator.WithMachineID(int(h.Sum32() % (maxMachineID + 1))), nil
+	})
+}
+
+// WithGCPZoneAsDatacenterID specifies the datacenter ID of Snowflake ID from the zone of the Compute Engine instance.
+// The letter at the end of the zone name is mapped to the datacenter ID, e.g., 0 for "us-central1-a" and 2 for "us-central1-c",
+// so the zones in a region get different datacenter IDs, while those in different regions may not.
+//
+// It fails fast with an error wrapping ErrNotOnGCE as WithMachineIDFromGCEMetadata does,
+// and returns ErrInvalidZone if the zone name does not end with a letter from a to z.
+func WithGCPZoneAsDatacenterID(ctx context.Context) idgenerator.Option {
+	return idgenerator.LazyOption(func() (idgenerator.Option, error) {
+		zone, err := fetchMetadata(ctx, zonePath)
+		if err != nil {
+			return nil, err
+		}
+		id, err := datacenterIDFromZone(zone)
+		if err != nil {
+			return nil, err
+		}
+		return idgenerator.WithDatacenterID(id), nil
+	})
+}
+
+// datacenterIDFromZone maps a zone like "projects/123456789/zones/us-central1-a" to a datacenter ID.
+func datacenterIDFromZone(zone string) (int, error) {
+	name := zone[strings.LastIndex(zone, "/")+1:]
+	if name == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidZone, zone)
+	}
+	c := name[len(name)-1]
+	if c < 'a' || c > 'z' || int(c-'a') > maxDatacenterID {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidZone, zone)
+	}
+	return int(c - 'a'), nil
+}
+
+// fetchMetadata returns the value at path of the metadata server.
+func fetchMetadata(ctx context.Context, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	host := os.Getenv(hostEnv)
+	if host == "" {
+		host = defaultHost
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+host+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(flavorHeader, flavor)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNotOnGCE, err)
+	}
+	defer resp.Body.Close()
+	// The metadata server always responds with the header, which a captive portal or a proxy does not.
+	if resp.Header.Get(flavorHeader) != flavor {
+		return "", fmt.Errorf("%w: no %s header in the response", ErrNotOnGCE, flavorHeader)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s for %s", ErrNotOnGCE, resp.Status, path)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
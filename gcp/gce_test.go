@@ -0,0 +1,111 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// stubMetadataServer starts a metadata server returning metadata, and points GCE_METADATA_HOST to it.
+func stubMetadataServer(t *testing.T, flavorHeaderSet bool, metadata map[string]string) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if flavorHeaderSet {
+			w.Header().Set(flavorHeader, flavor)
+		}
+		if r.Header.Get(flavorHeader) != flavor {
+			http.Error(w, "missing Metadata-Flavor header", http.StatusForbidden)
+			return
+		}
+		v, ok := metadata[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(v))
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv(hostEnv, strings.TrimPrefix(srv.URL, "http://"))
+}
+
+func TestWithMachineIDFromGCEMetadata(t *testing.T) {
+	stubMetadataServer(t, true, map[string]string{
+		instanceIDPath: "4520031799277581759",
+		zonePath:       "projects/123456789/zones/us-central1-c",
+	})
+	ctx := context.Background()
+	g, err := idgenerator.NewGenerator(
+		WithGCPZoneAsDatacenterID(ctx),
+		WithMachineIDFromGCEMetadata(ctx),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if got, want := g.DatacenterID(), 2; got != want {
+		t.Errorf("DatacenterID() = %v, want %v", got, want)
+	}
+	if got, want := g.MachineID(), 30; got != want {
+		t.Errorf("MachineID() = %v, want %v", got, want)
+	}
+}
+
+func TestWithMachineIDFromGCEMetadata_Error(t *testing.T) {
+	tests := []struct {
+		name            string
+		flavorHeaderSet bool
+		metadata        map[string]string
+	}{
+		{"Not the metadata server", false, map[string]string{instanceIDPath: "4520031799277581759"}},
+		{"Not found", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubMetadataServer(t, tt.flavorHeaderSet, tt.metadata)
+			_, err := idgenerator.NewGenerator(WithMachineIDFromGCEMetadata(context.Background()))
+			if !errors.Is(err, ErrNotOnGCE) {
+				t.Errorf("NewGenerator() error = %v, want %v", err, ErrNotOnGCE)
+			}
+		})
+	}
+
+	t.Run("Unreachable", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+		t.Setenv(hostEnv, strings.TrimPrefix(srv.URL, "http://"))
+		_, err := idgenerator.NewGenerator(WithGCPZoneAsDatacenterID(context.Background()))
+		if !errors.Is(err, ErrNotOnGCE) {
+			t.Errorf("NewGenerator() error = %v, want %v", err, ErrNotOnGCE)
+		}
+	})
+}
+
+func TestDatacenterIDFromZone(t *testing.T) {
+	tests := []struct {
+		zone    string
+		want    int
+		wantErr bool
+	}{
+		{"projects/123456789/zones/us-central1-a", 0, false},
+		{"projects/123456789/zones/asia-northeast1-f", 5, false},
+		{"us-east1-b", 1, false},
+		{"projects/123456789/zones/", 0, true},
+		{"projects/123456789/zones/us-central1", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			got, err := datacenterIDFromZone(tt.zone)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("datacenterIDFromZone() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("datacenterIDFromZone() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
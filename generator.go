@@ -0,0 +1,428 @@
+package idgenerator
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	mathrand "math/rand"
+	"sync"
+	"time"
+)
+
+// IDGenerator is the interface that generates Snowflake IDs.
+// Depend on IDGenerator instead of *Generator to replace it with MockGenerator in tests.
+type IDGenerator interface {
+	Next() (SnowflakeID, error)
+	NextN(n int) ([]SnowflakeID, error)
+}
+
+var _ IDGenerator = (*Generator)(nil)
+
+// Generator generates unique Snowflake IDs.
+//
+// Within the same millisecond (or the time unit of the layout), the sequence number is incremented for each ID.
+// When the sequence number is exhausted, Generator behaves as specified by WithSequenceExhaustionPolicy.
+// A Generator is safe for concurrent use by multiple goroutines.
+type Generator struct {
+	datacenterID  int
+	machineID     int
+	baseTime      time.Time
+	layout        Layout
+	clock         ClockSource
+	exhaustion    SequenceExhaustionPolicy
+	randFallback  bool
+	skewTolerance int64
+	metrics       Metrics
+	interceptor   Interceptor
+	limiter       *rateLimiter
+	audit         *auditLog
+	observers     []func(id SnowflakeID, elapsed time.Duration)
+	obfuscator    *feistel
+	reservedBit   SnowflakeID
+
+	sequenceProvider SequenceProvider
+
+	chanBufferSize int
+	errs           chan error
+
+	lastTimestamp  int64
+	sequenceNumber int
+	// generatedAt is the time read from the clock for the last generated ID.
+	generatedAt time.Time
+	// released is the IDs of aborted reservations to be reused by Next, in the order they are aborted.
+	released []RecordedID
+
+	mutex sync.Mutex
+}
+
+// NewGenerator returns a new Generator.
+//
+// All options are validated once here, not on each call of Next.
+// The timestamp is managed by the Generator, so WithTimestamp returns ErrUnsupportedOption.
+// WithShardCount, which is for GeneratorPool, also returns ErrUnsupportedOption.
+// WithSequenceNumber specifies the sequence number of the first generated ID.
+func NewGenerator(opts ...Option) (*Generator, error) {
+	s := &snowflake{}
+	for _, f := range opts {
+		if err := f(s); err != nil {
+			return nil, err
+		}
+	}
+	if s.timestamp != 0 || s.shardCount != 0 {
+		return nil, ErrUnsupportedOption
+	}
+
+	layout := s.getLayout()
+	if s.random {
+		if s.datacenterID == 0 {
+			s.datacenterID = mathrand.Intn(layout.maxDatacenterID() + 1)
+		}
+		if s.machineID == 0 {
+			s.machineID = mathrand.Intn(layout.maxMachineID() + 1)
+		}
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	return &Generator{
+		datacenterID:  s.datacenterID,
+		machineID:     s.machineID,
+		baseTime:      s.getBaseTime(),
+		layout:        layout,
+		clock:         s.getClock(),
+		exhaustion:    s.exhaustion,
+		randFallback:  s.cryptoRandomFallback,
+		skewTolerance: int64(s.clockSkewTolerance / layout.timeUnit()),
+		metrics:       s.getMetrics(),
+		interceptor:   chainInterceptors(s.interceptors),
+		limiter:       newRateLimiter(s.rateInterval),
+		audit:         newAuditLog(s.auditLogCapacity),
+		observers:     s.observers,
+		obfuscator:    s.getObfuscator(),
+		reservedBit:   s.getReservedBit(),
+
+		sequenceProvider: s.sequenceProvider,
+
+		chanBufferSize: s.getChanBufferSize(),
+		errs:           make(chan error, 1),
+
+		sequenceNumber: s.sequenceNumber,
+	}, nil
+}
+
+// NewGeneratorWithLayout returns a new Generator that generates IDs in layout.
+// It is the same as NewGenerator with WithLayout(layout) followed by opts.
+func NewGeneratorWithLayout(layout Layout, opts ...Option) (*Generator, error) {
+	return NewGenerator(append([]Option{WithLayout(layout)}, opts...)...)
+}
+
+// WithClockSkewTolerance allows the clock to move backward up to d.
+// Within the tolerance, Generator keeps using the last timestamp and increments the sequence number.
+// Beyond the tolerance, Next returns ErrClockMovedBackward. By default, no backward step is allowed.
+func WithClockSkewTolerance(d time.Duration) Option {
+	return func(s *snowflake) error {
+		if d < 0 {
+			return ErrInvalidClockSkewTolerance
+		}
+		s.clockSkewTolerance = d
+		return nil
+	}
+}
+
+// SequenceExhaustionPolicy specifies what Generator does when the sequence number of the current millisecond is exhausted.
+type SequenceExhaustionPolicy struct {
+	noWait  bool
+	timeout time.Duration
+}
+
+var (
+	// SpinWait waits until the clock advances to the next millisecond. This is the default policy.
+	SpinWait = SequenceExhaustionPolicy{}
+	// ReturnError returns ErrSequenceExhausted without waiting.
+	ReturnError = SequenceExhaustionPolicy{noWait: true}
+)
+
+// BlockWithTimeout waits until the clock advances to the next millisecond,
+// and returns ErrSequenceExhausted if it does not advance within d. A zero d means no timeout, same as SpinWait.
+func BlockWithTimeout(d time.Duration) SequenceExhaustionPolicy {
+	return SequenceExhaustionPolicy{timeout: d}
+}
+
+// WithSequenceExhaustionPolicy specifies what Generator does when the sequence number is exhausted.
+func WithSequenceExhaustionPolicy(p SequenceExhaustionPolicy) Option {
+	return func(s *snowflake) error {
+		if p.timeout < 0 {
+			return ErrInvalidWaitTimeout
+		}
+		s.exhaustion = p
+		return nil
+	}
+}
+
+// WithCryptoRandomFallback makes a Generator fill the sequence number with random bits from crypto/rand
+// when the sequence number of the current millisecond is exhausted, instead of waiting for the next millisecond.
+// It takes precedence over the SequenceExhaustionPolicy.
+//
+// This trades uniqueness for latency: an ID generated this way has the same timestamp, datacenter ID, and machine ID
+// as the other IDs of the millisecond, so it collides with one of them or with another random ID
+// with a probability of about n/2^sequenceBits each, where n is the number of IDs of the millisecond.
+// Use it only when occasional duplicates are acceptable or detected, e.g., by a unique constraint.
+// Metrics.ObserveSequenceFallback is called each time the random sequence number is used.
+func WithCryptoRandomFallback() Option {
+	return func(s *snowflake) error {
+		s.cryptoRandomFallback = true
+		return nil
+	}
+}
+
+// DatacenterID returns the datacenter ID of the generated IDs.
+func (g *Generator) DatacenterID() int {
+	return g.datacenterID
+}
+
+// MachineID returns the machine ID of the generated IDs.
+func (g *Generator) MachineID() int {
+	return g.machineID
+}
+
+// Layout returns the bit layout of the generated IDs.
+func (g *Generator) Layout() Layout {
+	return g.layout.withoutTag()
+}
+
+// MaxSequenceNumber returns the largest sequence number of the generated IDs.
+// With WithLayoutVersion, or WithReservedBit(true) in LayoutTwitterSnowflake, it is narrower than the sequence number of Layout,
+// whose top bits hold the tag of bit 63, so mask the sequence number read in Layout with it.
+func (g *Generator) MaxSequenceNumber() int {
+	return g.layout.maxSequenceNumber()
+}
+
+// ExtractSequenceNumber returns the sequence number of id generated by g.
+// Unlike the one of Layout, it undoes WithObfuscation and excludes the tag bits of WithLayoutVersion and WithReservedBit(true).
+func (g *Generator) ExtractSequenceNumber(id SnowflakeID) int {
+	id = ClearReservedBit(id)
+	if g.obfuscator != nil {
+		id = SnowflakeID(g.obfuscator.decrypt(uint64(id)))
+	}
+	return g.layout.ExtractSequenceNumber(int64(id))
+}
+
+// Next returns a new generated Snowflake ID.
+// It is the same as NextCtx with context.Background().
+func (g *Generator) Next() (SnowflakeID, error) {
+	return g.NextCtx(context.Background())
+}
+
+// NextCtx returns a new generated Snowflake ID.
+// If ctx is done while waiting for the next millisecond on sequence exhaustion, NextCtx returns ctx.Err().
+func (g *Generator) NextCtx(ctx context.Context) (SnowflakeID, error) {
+	if g.interceptor == nil {
+		return g.lockedNext(ctx)
+	}
+	ids, err := g.interceptor(ctx, g, "Next", func(ctx context.Context) ([]SnowflakeID, error) {
+		id, err := g.lockedNext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []SnowflakeID{id}, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+func (g *Generator) lockedNext(ctx context.Context) (SnowflakeID, error) {
+	id, _, err := g.lockedNextRecorded(ctx)
+	return id, err
+}
+
+// lockedNextRecorded generates a new Snowflake ID, and returns it with the time read from the clock for it.
+func (g *Generator) lockedNextRecorded(ctx context.Context) (SnowflakeID, time.Time, error) {
+	start := time.Now()
+	if err := g.limiter.wait(ctx, 1); err != nil {
+		return 0, time.Time{}, err
+	}
+	g.mutex.Lock()
+	if r, ok := g.reuseReleased(); ok {
+		g.mutex.Unlock()
+		g.observe(time.Since(start), r.ID)
+		return r.ID, r.Time, nil
+	}
+	id, err := g.next(ctx)
+	at := g.generatedAt
+	g.mutex.Unlock()
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	g.observe(time.Since(start), id)
+	return id, at, nil
+}
+
+// NextUint64 returns a new generated Snowflake ID as uint64.
+// See NewSnowflakeIDUint64 for when to prefer this form.
+func (g *Generator) NextUint64() (uint64, error) {
+	id, err := g.Next()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(id), nil
+}
+
+// NextN returns n new generated Snowflake IDs in increasing order.
+// It is the same as NextNCtx with context.Background().
+func (g *Generator) NextN(n int) ([]SnowflakeID, error) {
+	return g.NextNCtx(context.Background(), n)
+}
+
+// NextNCtx returns n new generated Snowflake IDs in increasing order.
+//
+// NextNCtx acquires the lock only once, so it is cheaper than calling NextCtx n times.
+// When the sequence number is exhausted, NextNCtx waits for the next millisecond like NextCtx,
+// and returns an error without any IDs if the sequence exhaustion policy gives up or ctx is done.
+func (g *Generator) NextNCtx(ctx context.Context, n int) ([]SnowflakeID, error) {
+	if n <= 0 {
+		return nil, ErrInvalidCount
+	}
+	if g.interceptor == nil {
+		return g.lockedNextN(ctx, n)
+	}
+	return g.interceptor(ctx, g, "NextN", func(ctx context.Context) ([]SnowflakeID, error) {
+		return g.lockedNextN(ctx, n)
+	})
+}
+
+func (g *Generator) lockedNextN(ctx context.Context, n int) ([]SnowflakeID, error) {
+	start := time.Now()
+	if err := g.limiter.wait(ctx, n); err != nil {
+		return nil, err
+	}
+	ids, err := g.nextN(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+
+	g.observe(time.Since(start), ids...)
+	return ids, nil
+}
+
+func (g *Generator) nextN(ctx context.Context, n int) ([]SnowflakeID, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	ids := make([]SnowflakeID, n)
+	for i := range ids {
+		id, err := g.next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// next generates a new Snowflake ID. The caller must hold g.mutex.
+func (g *Generator) next(ctx context.Context) (SnowflakeID, error) {
+	g.generatedAt = g.clock.Now()
+	ts, err := elapsedTimestamp(g.generatedAt.UTC(), g.baseTime, g.layout)
+	if err != nil {
+		return 0, err
+	}
+	if ts < g.lastTimestamp {
+		g.metrics.ObserveClockSkew(g)
+		if g.lastTimestamp-ts > g.skewTolerance {
+			return 0, ErrClockMovedBackward
+		}
+		ts = g.lastTimestamp
+	}
+	if g.sequenceProvider != nil {
+		return g.nextFromProvider(ctx, ts)
+	}
+
+	if ts == g.lastTimestamp {
+		g.sequenceNumber++
+		if g.sequenceNumber > g.layout.maxSequenceNumber() {
+			g.metrics.ObserveSequenceOverflow(g)
+			if g.randFallback {
+				// Keep the sequence number exhausted, so that the following IDs of the millisecond are also random.
+				g.sequenceNumber = g.layout.maxSequenceNumber()
+				return g.nextRandomSequence(ts)
+			}
+			ts, err = g.waitNextTimestamp(ctx)
+			if err != nil {
+				return 0, err
+			}
+			g.sequenceNumber = 0
+		}
+	} else if g.lastTimestamp != 0 {
+		// The sequence number of the first ID is the one specified by WithSequenceNumber.
+		g.sequenceNumber = 0
+	}
+	g.lastTimestamp = ts
+
+	return g.emit(ts, g.sequenceNumber), nil
+}
+
+// emit composes the ID to return at ts with the sequence number seq, and records it. The caller must hold g.mutex.
+// The metrics and the audit log see the ID as returned, after WithObfuscation and WithReservedBit.
+func (g *Generator) emit(ts int64, seq int) SnowflakeID {
+	id := g.obfuscate(SnowflakeID(g.layout.compose(ts, g.datacenterID, g.machineID, seq))) | g.reservedBit
+	g.metrics.ObserveID(g, id)
+	g.audit.record(id, seq)
+	return id
+}
+
+// nextRandomSequence generates a Snowflake ID at ts with a random sequence number. The caller must hold g.mutex.
+func (g *Generator) nextRandomSequence(ts int64) (SnowflakeID, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	seq := int(binary.BigEndian.Uint64(b[:]) & uint64(g.layout.maxSequenceNumber()))
+
+	g.metrics.ObserveSequenceFallback(g)
+	return g.emit(ts, seq), nil
+}
+
+// waitNextTimestamp blocks until the elapsed timestamp moves past the last timestamp,
+// according to the sequence exhaustion policy. It returns ctx.Err() if ctx is done while waiting.
+//
+// The timestamp is read from the clock source, while the timeout is measured in real time,
+// so that a frozen SimulatedClock does not make the timeout never expire.
+func (g *Generator) waitNextTimestamp(ctx context.Context) (int64, error) {
+	if g.exhaustion.noWait {
+		return 0, ErrSequenceExhausted
+	}
+	var deadline time.Time
+	if g.exhaustion.timeout > 0 {
+		deadline = time.Now().Add(g.exhaustion.timeout)
+	}
+	for {
+		now := g.clock.Now()
+		ts, err := elapsedTimestamp(now.UTC(), g.baseTime, g.layout)
+		if err != nil {
+			return 0, err
+		}
+		if ts > g.lastTimestamp {
+			g.generatedAt = now
+			return ts, nil
+		}
+
+		wait := addTimeUnits(g.baseTime, g.lastTimestamp+1, g.layout.timeUnit()).Sub(now)
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return 0, ErrSequenceExhausted
+			}
+			wait = min(wait, remaining)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return 0, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package idgenerator
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+func TestGenerator_Next_Unique(t *testing.T) {
+	for _, goroutines := range []int{1, 8, 32, 128} {
+		for _, idsPerGoroutine := range []int{100, 1000} {
+			t.Run(fmt.Sprintf("N=%d M=%d", goroutines, idsPerGoroutine), func(t *testing.T) {
+				property := func(datacenterID, machineID uint8) bool {
+					g, err := NewGenerator(
+						WithDatacenterID(int(datacenterID)%(maxDatacenterID+1)),
+						WithMachineID(int(machineID)%(maxMachineID+1)),
+					)
+					if err != nil {
+						t.Errorf("NewGenerator() error = %v", err)
+						return false
+					}
+					return assertUnique(t, generateConcurrently(t, g, goroutines, idsPerGoroutine))
+				}
+				if err := quick.Check(property, &quick.Config{MaxCount: 5}); err != nil {
+					t.Error(err)
+				}
+			})
+		}
+	}
+}
+
+// generateConcurrently calls g.Next idsPerGoroutine times in each of goroutines goroutines,
+// and returns the IDs generated by each goroutine.
+func generateConcurrently(t *testing.T, g *Generator, goroutines, idsPerGoroutine int) [][]SnowflakeID {
+	t.Helper()
+	results := make([][]SnowflakeID, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = make([]SnowflakeID, 0, idsPerGoroutine)
+			for j := 0; j < idsPerGoroutine; j++ {
+				id, err := g.Next()
+				if err != nil {
+					t.Errorf("Next() error = %v", err)
+					return
+				}
+				results[i] = append(results[i], id)
+			}
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// assertUnique reports every duplicated ID with where it was generated and its fields, and returns whether all IDs are unique.
+func assertUnique(t *testing.T, results [][]SnowflakeID) bool {
+	t.Helper()
+	type position struct{ goroutine, index int }
+	seen := make(map[SnowflakeID]position)
+	unique := true
+	for i, ids := range results {
+		for j, id := range ids {
+			if p, ok := seen[id]; ok {
+				unique = false
+				first := results[p.goroutine][p.index]
+				t.Errorf("Next() returned duplicated ID: %v (goroutine %d, #%d, timestamp %v, sequence %d) and %v (goroutine %d, #%d, timestamp %v, sequence %d)",
+					first, p.goroutine, p.index, ExtractTime(int64(first), time.Time{}), ExtractSequenceNumber(int64(first)),
+					id, i, j, ExtractTime(int64(id), time.Time{}), ExtractSequenceNumber(int64(id)))
+				continue
+			}
+			seen[id] = position{i, j}
+		}
+	}
+	return unique
+}
@@ -0,0 +1,494 @@
+package idgenerator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewGenerator(t *testing.T) {
+	type args struct {
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"WithDatacenterID:31 WithMachineID:15 WithBaseTime:2020-01-01",
+			args{[]Option{
+				WithDatacenterID(31),
+				WithMachineID(15),
+				WithBaseTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+			}},
+			false,
+		},
+		{
+			"WithRandomEnabled",
+			args{[]Option{
+				WithRandomEnabled(),
+			}},
+			false,
+		},
+		{
+			"WithSequenceExhaustionPolicy:BlockWithTimeout(1s)",
+			args{[]Option{
+				WithSequenceExhaustionPolicy(BlockWithTimeout(time.Second)),
+			}},
+			false,
+		},
+		{
+			"Error invalid datacenter ID",
+			args{[]Option{
+				WithDatacenterID(32),
+			}},
+			true,
+		},
+		{
+			"Error invalid wait timeout",
+			args{[]Option{
+				WithSequenceExhaustionPolicy(BlockWithTimeout(-time.Second)),
+			}},
+			true,
+		},
+		{
+			"Error invalid clock skew tolerance",
+			args{[]Option{
+				WithClockSkewTolerance(-time.Millisecond),
+			}},
+			true,
+		},
+		{
+			"Error unsupported timestamp",
+			args{[]Option{
+				WithTimestamp(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+			}},
+			true,
+		},
+		{
+			"WithSequenceNumber:1",
+			args{[]Option{
+				WithSequenceNumber(1),
+			}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenerator(tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGenerator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerator_Next(t *testing.T) {
+	g, err := NewGenerator(WithDatacenterID(31), WithMachineID(15))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	const goroutines, idsPerGoroutine = 8, 2000
+	results := make([][]SnowflakeID, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < idsPerGoroutine; j++ {
+				id, err := g.Next()
+				if err != nil {
+					t.Errorf("Next() error = %v", err)
+					return
+				}
+				results[i] = append(results[i], id)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[SnowflakeID]struct{}, goroutines*idsPerGoroutine)
+	for _, ids := range results {
+		for j, id := range ids {
+			if _, ok := seen[id]; ok {
+				t.Fatalf("Next() returned duplicated ID %v", id)
+			}
+			seen[id] = struct{}{}
+			if j > 0 && id <= ids[j-1] {
+				t.Fatalf("Next() returned %v after %v, want increasing IDs", id, ids[j-1])
+			}
+			if got := int(id>>datacenterBitShift) & maxDatacenterID; got != 31 {
+				t.Fatalf("Next() datacenter ID = %v, want %v", got, 31)
+			}
+			if got := int(id>>machineBitShift) & maxMachineID; got != 15 {
+				t.Fatalf("Next() machine ID = %v, want %v", got, 15)
+			}
+		}
+	}
+}
+
+func TestGenerator_Next_WithClock(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		want    SnowflakeID
+	}{
+		{"First ID", 0, 11234023837724672},
+		{"Same millisecond", 0, 11234023837724673},
+		{"Next millisecond", time.Millisecond, 11234023841918976},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c.Advance(tt.advance)
+			got, err := g.Next()
+			if err != nil {
+				t.Fatalf("Next() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_Next_WithSequenceNumber(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c), WithSequenceNumber(100))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	for _, want := range []int{100, 101} {
+		id, err := g.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if got := ExtractSequenceNumber(int64(id)); got != want {
+			t.Errorf("Next() sequence number = %v, want %v", got, want)
+		}
+	}
+	c.Advance(time.Millisecond)
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if got := ExtractSequenceNumber(int64(id)); got != 0 {
+		t.Errorf("Next() sequence number = %v, want %v", got, 0)
+	}
+}
+
+func TestGenerator_Next_Error(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if _, err := g.Next(); err != ErrInvalidTimestamp {
+		t.Errorf("Next() error = %v, want %v", err, ErrInvalidTimestamp)
+	}
+}
+
+func TestGenerator_Next_WithSequenceExhaustionPolicy(t *testing.T) {
+	type args struct {
+		policy SequenceExhaustionPolicy
+	}
+	tests := []struct {
+		name    string
+		args    args
+		advance bool
+		wantErr error
+	}{
+		{"SpinWait", args{SpinWait}, true, nil},
+		{"BlockWithTimeout", args{BlockWithTimeout(time.Minute)}, true, nil},
+		{"Error ReturnError", args{ReturnError}, false, ErrSequenceExhausted},
+		{"Error BlockWithTimeout", args{BlockWithTimeout(10 * time.Millisecond)}, false, ErrSequenceExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+			g, err := NewGenerator(WithClock(c), WithSequenceExhaustionPolicy(tt.args.policy))
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			// Exhaust the sequence number of the current millisecond.
+			if _, err := g.NextN(maxSequenceNumber + 1); err != nil {
+				t.Fatalf("NextN() error = %v", err)
+			}
+			if tt.advance {
+				time.AfterFunc(10*time.Millisecond, func() { c.Advance(time.Millisecond) })
+			}
+
+			id, err := g.Next()
+			if err != tt.wantErr {
+				t.Fatalf("Next() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := ExtractTime(int64(id), time.Time{}), c.Now(); !got.Equal(want) {
+				t.Errorf("Next() time = %v, want %v", got, want)
+			}
+			if got := ExtractSequenceNumber(int64(id)); got != 0 {
+				t.Errorf("Next() sequence number = %v, want %v", got, 0)
+			}
+		})
+	}
+}
+
+func TestGenerator_Next_WithCryptoRandomFallback(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	m := &countMetrics{}
+	g, err := NewGenerator(WithClock(c), WithMetrics(m), WithDatacenterID(31), WithMachineID(15), WithCryptoRandomFallback())
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	// Exhaust the sequence number of the current millisecond.
+	if _, err := g.NextN(maxSequenceNumber + 1); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+
+	// The clock is frozen, so Next would never return without the fallback.
+	for i := 1; i <= 3; i++ {
+		id, err := g.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if got, want := ExtractTime(int64(id), time.Time{}), c.Now(); !got.Equal(want) {
+			t.Errorf("Next() time = %v, want %v", got, want)
+		}
+		if got := ExtractDatacenterID(int64(id)); got != 31 {
+			t.Errorf("Next() datacenter ID = %v, want %v", got, 31)
+		}
+		if got := ExtractMachineID(int64(id)); got != 15 {
+			t.Errorf("Next() machine ID = %v, want %v", got, 15)
+		}
+		if m.sequenceFallbacks != i {
+			t.Errorf("sequence fallbacks = %v, want %v", m.sequenceFallbacks, i)
+		}
+	}
+
+	// The sequence number starts from 0 again in the next millisecond.
+	c.Advance(time.Millisecond)
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if got := ExtractSequenceNumber(int64(id)); got != 0 {
+		t.Errorf("Next() sequence number = %v, want %v", got, 0)
+	}
+	if m.sequenceFallbacks != 3 {
+		t.Errorf("sequence fallbacks = %v, want %v", m.sequenceFallbacks, 3)
+	}
+}
+
+func TestGenerator_NextCtx(t *testing.T) {
+	type args struct {
+		ctx func() (context.Context, context.CancelFunc)
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			"Error cancelled",
+			args{func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(10*time.Millisecond, cancel)
+				return ctx, cancel
+			}},
+			context.Canceled,
+		},
+		{
+			"Error deadline exceeded",
+			args{func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			}},
+			context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+			g, err := NewGenerator(WithClock(c), WithSequenceExhaustionPolicy(SpinWait))
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			// Exhaust the sequence number of the current millisecond, and the clock never advances.
+			if _, err := g.NextN(maxSequenceNumber + 1); err != nil {
+				t.Fatalf("NextN() error = %v", err)
+			}
+			ctx, cancel := tt.args.ctx()
+			defer cancel()
+
+			start := time.Now()
+			if _, err := g.NextCtx(ctx); err != tt.wantErr {
+				t.Errorf("NextCtx() error = %v, want %v", err, tt.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("NextCtx() returned after %v, want promptly", elapsed)
+			}
+			if _, err := g.NextNCtx(ctx, 1); err != tt.wantErr {
+				t.Errorf("NextNCtx() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// The Generator is still usable after the cancellation.
+			c.Advance(time.Millisecond)
+			if _, err := g.Next(); err != nil {
+				t.Errorf("Next() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerator_NextN(t *testing.T) {
+	type args struct {
+		n int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{"n:1", args{1}, false},
+		{"n:10000", args{10000}, false},
+		{"Error n:0", args{0}, true},
+		{"Error n:-1", args{-1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator()
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			got, err := g.NextN(tt.args.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NextN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != tt.args.n {
+				t.Fatalf("NextN() len = %v, want %v", len(got), tt.args.n)
+			}
+			for i := 1; i < len(got); i++ {
+				if got[i] <= got[i-1] {
+					t.Fatalf("NextN() returned %v after %v, want increasing IDs", got[i], got[i-1])
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_NextUint64(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	prev, err := g.NextUint64()
+	if err != nil {
+		t.Fatalf("NextUint64() error = %v", err)
+	}
+	got, err := g.NextUint64()
+	if err != nil {
+		t.Fatalf("NextUint64() error = %v", err)
+	}
+	if got <= prev {
+		t.Errorf("NextUint64() returned %v after %v, want increasing IDs", got, prev)
+	}
+	if got>>63 != 0 {
+		t.Errorf("NextUint64() = %064b, want the most significant bit unset", got)
+	}
+}
+
+func TestGenerator_Next_WithClockSkewTolerance(t *testing.T) {
+	type args struct {
+		tolerance time.Duration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{"Within tolerance", args{time.Second}, nil},
+		{"Error no tolerance", args{0}, ErrClockMovedBackward},
+		{"Error beyond tolerance", args{time.Millisecond}, ErrClockMovedBackward},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+			g, err := NewGenerator(WithClock(c), WithClockSkewTolerance(tt.args.tolerance))
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			last, err := g.Next()
+			if err != nil {
+				t.Fatalf("Next() error = %v", err)
+			}
+			c.Advance(-100 * time.Millisecond)
+
+			id, err := g.Next()
+			if err != tt.wantErr {
+				t.Fatalf("Next() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if id != last+1 {
+				t.Errorf("Next() = %v, want %v", id, last+1)
+			}
+		})
+	}
+}
+
+func TestGenerator_Next_WithClockSkewTolerance_Microsecond(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGeneratorWithLayout(LayoutMicrosecond, WithClock(c), WithClockSkewTolerance(time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	// The tolerance is converted to the time unit of the layout.
+	c.Advance(-500 * time.Microsecond)
+	if _, err := g.Next(); err != nil {
+		t.Errorf("Next() error = %v, want %v", err, nil)
+	}
+	c.Advance(-time.Millisecond)
+	if _, err := g.Next(); err != ErrClockMovedBackward {
+		t.Errorf("Next() error = %v, want %v", err, ErrClockMovedBackward)
+	}
+}
+
+func BenchmarkGenerator_Next_SpinWait(b *testing.B) {
+	g, err := NewGenerator(WithSequenceExhaustionPolicy(SpinWait))
+	if err != nil {
+		b.Fatalf("NewGenerator() error = %v", err)
+	}
+	var prev SnowflakeID
+	var sequenceSum int
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		id, err := g.Next()
+		if err != nil {
+			b.Fatalf("Next() error = %v", err)
+		}
+		if id <= prev {
+			b.Fatalf("Next() returned %v after %v, want increasing IDs", id, prev)
+		}
+		prev = id
+		sequenceSum += int(id) & maxSequenceNumber
+	}
+	// Without bias, the sequence numbers of each millisecond are used from 0 without gaps.
+	b.ReportMetric(float64(sequenceSum)/float64(b.N), "seq/op")
+}
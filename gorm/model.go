@@ -0,0 +1,86 @@
+// Package gorm assigns Snowflake IDs to the primary keys of GORM models.
+//
+// Embed Model in an entity instead of gorm.Model:
+//
+//	type User struct {
+//		gorm.Model
+//		Name string
+//	}
+//
+// Model.BeforeCreate fills the ID with idgenerator.Generate before insert, if it is zero.
+// Use SetGenerator to generate the IDs of a specific model with another generator.
+//
+// It is a separate module, so that the idgenerator package does not depend on GORM.
+package gorm
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	gormio "gorm.io/gorm"
+)
+
+// Model is a base struct of GORM models with a Snowflake ID primary key, like gorm.Model.
+type Model struct {
+	ID        idgenerator.SnowflakeID `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gormio.DeletedAt `gorm:"index"`
+}
+
+// generators holds the generators set by SetGenerator by the model type.
+var generators sync.Map
+
+// SetGenerator makes Model.BeforeCreate generate the IDs of the models of the same type as model with g,
+// instead of idgenerator.Generate. model is a value or a pointer of the entity type, e.g., &User{}.
+// If g is nil, the IDs are generated by idgenerator.Generate again.
+func SetGenerator(model any, g idgenerator.IDGenerator) {
+	t := indirectType(reflect.TypeOf(model))
+	if g == nil {
+		generators.Delete(t)
+		return
+	}
+	generators.Store(t, g)
+}
+
+// BeforeCreate is a GORM hook that fills the ID with a new Snowflake ID if it is zero.
+// GORM finds the hook once per model type when it parses the schema.
+func (m *Model) BeforeCreate(tx *gormio.DB) error {
+	if m.ID != 0 {
+		return nil
+	}
+	var (
+		id  idgenerator.SnowflakeID
+		err error
+	)
+	if g, ok := generatorOf(tx); ok {
+		id, err = g.Next()
+	} else {
+		id, err = idgenerator.Generate()
+	}
+	if err != nil {
+		return err
+	}
+	m.ID = id
+	return nil
+}
+
+func generatorOf(tx *gormio.DB) (idgenerator.IDGenerator, bool) {
+	if tx.Statement == nil || tx.Statement.Schema == nil {
+		return nil, false
+	}
+	g, ok := generators.Load(tx.Statement.Schema.ModelType)
+	if !ok {
+		return nil, false
+	}
+	return g.(idgenerator.IDGenerator), true
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
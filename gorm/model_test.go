@@ -0,0 +1,91 @@
+package gorm
+
+import (
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	gormio "gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+type user struct {
+	Model
+	Name string
+}
+
+type order struct {
+	Model
+	Amount int
+}
+
+func openDB(t *testing.T) *gormio.DB {
+	t.Helper()
+	db, err := gormio.Open(tests.DummyDialector{}, &gormio.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	return db
+}
+
+func TestModel_BeforeCreate(t *testing.T) {
+	db := openDB(t)
+
+	u := user{Name: "alice"}
+	if err := db.Create(&u).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if u.ID == 0 {
+		t.Errorf("ID = %v, want non-zero", u.ID)
+	}
+
+	// An ID already set is kept.
+	u2 := user{Model: Model{ID: 1}, Name: "bob"}
+	if err := db.Create(&u2).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if u2.ID != 1 {
+		t.Errorf("ID = %v, want %v", u2.ID, 1)
+	}
+
+	// Each model of a batch gets its own ID.
+	users := []user{{Name: "carol"}, {Name: "dave"}}
+	if err := db.Create(&users).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if users[0].ID == 0 || users[1].ID == 0 || users[0].ID == users[1].ID {
+		t.Errorf("IDs = %v, %v, want distinct non-zero IDs", users[0].ID, users[1].ID)
+	}
+}
+
+func TestSetGenerator(t *testing.T) {
+	db := openDB(t)
+	mock := idgenerator.NewMockGenerator(100, 101)
+	SetGenerator(&order{}, mock)
+	defer SetGenerator(&order{}, nil)
+
+	o := order{Amount: 1}
+	if err := db.Create(&o).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if o.ID != 100 {
+		t.Errorf("ID = %v, want %v", o.ID, 100)
+	}
+
+	// Other models still use the default generator.
+	c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := idgenerator.NewGenerator(idgenerator.WithClock(c), idgenerator.WithDatacenterID(31), idgenerator.WithMachineID(15))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	idgenerator.SetDefault(g)
+	defer idgenerator.SetDefault(nil)
+
+	u := user{Name: "alice"}
+	if err := db.Create(&u).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if want := idgenerator.SnowflakeID(11234023837724672); u.ID != want {
+		t.Errorf("ID = %v, want %v", u.ID, want)
+	}
+}
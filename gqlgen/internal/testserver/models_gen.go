@@ -0,0 +1,18 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package testserver
+
+import (
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+type Mutation struct {
+}
+
+type Query struct {
+}
+
+type User struct {
+	ID   idgenerator.SnowflakeID `json:"id"`
+	Name string                  `json:"name"`
+}
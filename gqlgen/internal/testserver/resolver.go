@@ -0,0 +1,52 @@
+package testserver
+
+// THIS CODE WILL BE UPDATED WITH SCHEMA CHANGES. PREVIOUS IMPLEMENTATION FOR SCHEMA CHANGES WILL BE KEPT IN THE COMMENT SECTION. IMPLEMENTATION FOR UNCHANGED SCHEMA WILL BE KEPT.
+
+import (
+	"context"
+	"sync"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+type Resolver struct {
+	Generator *idgenerator.Generator
+
+	users map[idgenerator.SnowflakeID]*User
+	mutex sync.Mutex
+}
+
+// CreateUser is the resolver for the createUser field.
+func (r *mutationResolver) CreateUser(ctx context.Context, name string) (*User, error) {
+	id, err := r.Generator.NextCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u := &User{ID: id, Name: name}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.users == nil {
+		r.users = make(map[idgenerator.SnowflakeID]*User)
+	}
+	r.users[id] = u
+	return u, nil
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, id idgenerator.SnowflakeID) (*User, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.users[id], nil
+}
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type (
+	mutationResolver struct{ *Resolver }
+	queryResolver    struct{ *Resolver }
+)
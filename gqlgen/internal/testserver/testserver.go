@@ -0,0 +1,5 @@
+// Package testserver is a GraphQL server generated by gqlgen with the SnowflakeID scalar,
+// to test the scalar end-to-end. See gqlgen.yml for the binding of the scalar.
+package testserver
+
+//go:generate go run github.com/99designs/gqlgen generate
@@ -0,0 +1,61 @@
+package testserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func newClient(t *testing.T) *client.Client {
+	t.Helper()
+	c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := idgenerator.NewGenerator(idgenerator.WithClock(c), idgenerator.WithDatacenterID(31), idgenerator.WithMachineID(15))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	return client.New(handler.NewDefaultServer(NewExecutableSchema(Config{Resolvers: &Resolver{Generator: g}})))
+}
+
+func TestSnowflakeID(t *testing.T) {
+	c := newClient(t)
+
+	var created struct {
+		CreateUser struct {
+			ID   string
+			Name string
+		}
+	}
+	c.MustPost(`mutation { createUser(name: "alice") { id name } }`, &created)
+	// The ID is serialized as a string.
+	if want := "11234023837724672"; created.CreateUser.ID != want {
+		t.Errorf("createUser.id = %v, want %v", created.CreateUser.ID, want)
+	}
+
+	var queried struct {
+		User *struct {
+			ID   string
+			Name string
+		}
+	}
+	c.MustPost(`query($id: SnowflakeID!) { user(id: $id) { id name } }`, &queried, client.Var("id", created.CreateUser.ID))
+	if queried.User == nil || queried.User.Name != "alice" {
+		t.Errorf("user = %v, want %v", queried.User, "alice")
+	}
+
+	// An integer literal is accepted as well.
+	c.MustPost(`query { user(id: 11234023837724672) { id } }`, &queried)
+	if queried.User == nil || queried.User.ID != created.CreateUser.ID {
+		t.Errorf("user = %v, want %v", queried.User, created.CreateUser.ID)
+	}
+}
+
+func TestSnowflakeID_Invalid(t *testing.T) {
+	c := newClient(t)
+	var resp struct{ User *struct{ ID string } }
+	if err := c.Post(`query { user(id: "abc") { id } }`, &resp); err == nil {
+		t.Error("Post() error = nil, want error for an invalid ID")
+	}
+}
@@ -0,0 +1,59 @@
+// Package gqlgen provides the SnowflakeID GraphQL scalar for gqlgen.
+//
+// The scalar is serialized as a JSON string, since JavaScript numbers lose the precision of 64-bit integers.
+// Declare the scalar in the schema:
+//
+//	"A Snowflake ID, serialized as a decimal string."
+//	scalar SnowflakeID
+//
+// and bind it in gqlgen.yml:
+//
+//	models:
+//	  SnowflakeID:
+//	    model: github.com/kawabatas/go-id-generator/gqlgen.SnowflakeID
+//
+// gqlgen then uses MarshalSnowflakeID and UnmarshalSnowflakeID for the fields and arguments of the scalar,
+// whose Go type is idgenerator.SnowflakeID.
+//
+// It is a separate module, so that the idgenerator package does not depend on gqlgen.
+package gqlgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// MarshalSnowflakeID serializes id as a JSON string of the decimal ID.
+func MarshalSnowflakeID(id idgenerator.SnowflakeID) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		_, _ = io.WriteString(w, strconv.Quote(id.String()))
+	})
+}
+
+// UnmarshalSnowflakeID parses an input value of the scalar.
+// It accepts a string of the decimal ID, and an integer literal for clients that send IDs as numbers.
+func UnmarshalSnowflakeID(v any) (idgenerator.SnowflakeID, error) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = string(v)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case int:
+		s = strconv.Itoa(v)
+	default:
+		return 0, fmt.Errorf("%w: %T is not a string", idgenerator.ErrInvalidID, v)
+	}
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("%w: %q", idgenerator.ErrInvalidID, s)
+	}
+	return idgenerator.SnowflakeID(id), nil
+}
@@ -0,0 +1,48 @@
+package gqlgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func TestMarshalSnowflakeID(t *testing.T) {
+	var buf bytes.Buffer
+	MarshalSnowflakeID(11234023837724673).MarshalGQL(&buf)
+	if got, want := buf.String(), `"11234023837724673"`; got != want {
+		t.Errorf("MarshalSnowflakeID() = %v, want %v", got, want)
+	}
+}
+
+func TestUnmarshalSnowflakeID(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       any
+		want    idgenerator.SnowflakeID
+		wantErr error
+	}{
+		{"String", "11234023837724673", 11234023837724673, nil},
+		{"json.Number", json.Number("11234023837724673"), 11234023837724673, nil},
+		{"int64", int64(11234023837724673), 11234023837724673, nil},
+		{"int", 1, 1, nil},
+		{"Error invalid string", "abc", 0, idgenerator.ErrInvalidID},
+		{"Error negative", "-1", 0, idgenerator.ErrInvalidID},
+		{"Error float", 1.5, 0, idgenerator.ErrInvalidID},
+		{"Error nil", nil, 0, idgenerator.ErrInvalidID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalSnowflakeID(tt.v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UnmarshalSnowflakeID() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UnmarshalSnowflakeID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"google.golang.org/grpc"
+)
+
+// retryServiceConfig retries the calls failed with Unavailable, such as on sequence exhaustion or a lost connection.
+const retryServiceConfig = `{
+  "methodConfig": [{
+    "name": [{"service": "idgenerator.v1.IDGeneratorService"}],
+    "retryPolicy": {
+      "maxAttempts": 4,
+      "initialBackoff": "0.01s",
+      "maxBackoff": "1s",
+      "backoffMultiplier": 2,
+      "retryableStatusCodes": ["UNAVAILABLE"]
+    }
+  }]
+}`
+
+// Client fetches IDs from a server running Server.
+// A Client is safe for concurrent use by multiple goroutines.
+type Client struct {
+	conn   *grpc.ClientConn
+	client IDGeneratorServiceClient
+}
+
+var _ idgenerator.IDGenerator = (*Client)(nil)
+
+// NewClient returns a new Client connected to target.
+//
+// Calls failed with Unavailable are retried up to 3 times with exponential backoff.
+// The retry policy can be replaced with grpc.WithDefaultServiceConfig in opts.
+func NewClient(target string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithDefaultServiceConfig(retryServiceConfig)}, opts...)
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, client: NewIDGeneratorServiceClient(conn)}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Next fetches a new ID with GenerateID.
+func (c *Client) Next() (idgenerator.SnowflakeID, error) {
+	res, err := c.client.GenerateID(context.Background(), &GenerateIDRequest{})
+	if err != nil {
+		return 0, err
+	}
+	ids, err := parseIDs([]string{res.GetId()})
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// NextN fetches n new IDs with GenerateBatch.
+// n must not be greater than MaxBatchSize.
+func (c *Client) NextN(n int) ([]idgenerator.SnowflakeID, error) {
+	if n <= 0 || n > MaxBatchSize {
+		return nil, idgenerator.ErrInvalidCount
+	}
+	res, err := c.client.GenerateBatch(context.Background(), &GenerateBatchRequest{Count: int32(n)})
+	if err != nil {
+		return nil, err
+	}
+	return parseIDs(res.GetIds())
+}
+
+func parseIDs(ss []string) ([]idgenerator.SnowflakeID, error) {
+	ids := make([]idgenerator.SnowflakeID, len(ss))
+	for i, s := range ss {
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, idgenerator.ErrInvalidID
+		}
+		ids[i] = idgenerator.SnowflakeID(v)
+	}
+	return ids, nil
+}
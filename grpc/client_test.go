@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flakyServer fails the first failures calls with Unavailable.
+type flakyServer struct {
+	*Server
+	failures int32
+	calls    atomic.Int32
+}
+
+func (s *flakyServer) GenerateID(ctx context.Context, req *GenerateIDRequest) (*GenerateIDResponse, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	return s.Server.GenerateID(ctx, req)
+}
+
+func TestClient(t *testing.T) {
+	c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := idgenerator.NewGenerator(
+		idgenerator.WithClock(c),
+		idgenerator.WithDatacenterID(31),
+		idgenerator.WithMachineID(15),
+		idgenerator.WithSequenceExhaustionPolicy(idgenerator.ReturnError),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	client := startServer(t, NewServer(g))
+
+	id, err := client.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if id != 11234023837724672 {
+		t.Errorf("Next() = %v, want %v", id, 11234023837724672)
+	}
+
+	ids, err := client.NextN(4095)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if len(ids) != 4095 || ids[0] != id+1 || ids[len(ids)-1] != id+4095 {
+		t.Errorf("NextN() = [%v ... %v] (len %v), want [%v ... %v] (len %v)", ids[0], ids[len(ids)-1], len(ids), id+1, id+4095, 4095)
+	}
+
+	if _, err := client.Next(); status.Code(err) != codes.Unavailable {
+		t.Errorf("Next() error = %v, want code %v", err, codes.Unavailable)
+	}
+	if _, err := client.NextN(0); err != idgenerator.ErrInvalidCount {
+		t.Errorf("NextN() error = %v, want %v", err, idgenerator.ErrInvalidCount)
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	type args struct {
+		failures int32
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"No failure", args{0}, 1, false},
+		{"Retry 3 failures", args{3}, 4, false},
+		{"Error 4 failures", args{4}, 4, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := idgenerator.NewGenerator()
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			srv := &flakyServer{Server: NewServer(g), failures: tt.args.failures}
+			client := startServer(t, srv)
+
+			_, err = client.Next()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Next() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := srv.calls.Load(); got != tt.wantCalls {
+				t.Errorf("Next() calls = %v, want %v", got, tt.wantCalls)
+			}
+		})
+	}
+}
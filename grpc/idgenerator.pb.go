@@ -0,0 +1,264 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: idgenerator.proto
+
+package grpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GenerateIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateIDRequest) Reset() {
+	*x = GenerateIDRequest{}
+	mi := &file_idgenerator_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateIDRequest) ProtoMessage() {}
+
+func (x *GenerateIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idgenerator_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateIDRequest.ProtoReflect.Descriptor instead.
+func (*GenerateIDRequest) Descriptor() ([]byte, []int) {
+	return file_idgenerator_proto_rawDescGZIP(), []int{0}
+}
+
+type GenerateIDResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID in decimal, since a JavaScript number loses precision over 2^53.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateIDResponse) Reset() {
+	*x = GenerateIDResponse{}
+	mi := &file_idgenerator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateIDResponse) ProtoMessage() {}
+
+func (x *GenerateIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idgenerator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateIDResponse.ProtoReflect.Descriptor instead.
+func (*GenerateIDResponse) Descriptor() ([]byte, []int) {
+	return file_idgenerator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GenerateIDResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GenerateBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateBatchRequest) Reset() {
+	*x = GenerateBatchRequest{}
+	mi := &file_idgenerator_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateBatchRequest) ProtoMessage() {}
+
+func (x *GenerateBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idgenerator_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateBatchRequest.ProtoReflect.Descriptor instead.
+func (*GenerateBatchRequest) Descriptor() ([]byte, []int) {
+	return file_idgenerator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GenerateBatchRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GenerateBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The IDs in decimal.
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateBatchResponse) Reset() {
+	*x = GenerateBatchResponse{}
+	mi := &file_idgenerator_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateBatchResponse) ProtoMessage() {}
+
+func (x *GenerateBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idgenerator_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateBatchResponse.ProtoReflect.Descriptor instead.
+func (*GenerateBatchResponse) Descriptor() ([]byte, []int) {
+	return file_idgenerator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GenerateBatchResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+var File_idgenerator_proto protoreflect.FileDescriptor
+
+const file_idgenerator_proto_rawDesc = "" +
+	"\n" +
+	"\x11idgenerator.proto\x12\x0eidgenerator.v1\"\x13\n" +
+	"\x11GenerateIDRequest\"$\n" +
+	"\x12GenerateIDResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
+	"\x14GenerateBatchRequest\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\")\n" +
+	"\x15GenerateBatchResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids2\xc7\x01\n" +
+	"\x12IDGeneratorService\x12S\n" +
+	"\n" +
+	"GenerateID\x12!.idgenerator.v1.GenerateIDRequest\x1a\".idgenerator.v1.GenerateIDResponse\x12\\\n" +
+	"\rGenerateBatch\x12$.idgenerator.v1.GenerateBatchRequest\x1a%.idgenerator.v1.GenerateBatchResponseB+Z)github.com/kawabatas/go-id-generator/grpcb\x06proto3"
+
+var (
+	file_idgenerator_proto_rawDescOnce sync.Once
+	file_idgenerator_proto_rawDescData []byte
+)
+
+func file_idgenerator_proto_rawDescGZIP() []byte {
+	file_idgenerator_proto_rawDescOnce.Do(func() {
+		file_idgenerator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_idgenerator_proto_rawDesc), len(file_idgenerator_proto_rawDesc)))
+	})
+	return file_idgenerator_proto_rawDescData
+}
+
+var file_idgenerator_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_idgenerator_proto_goTypes = []any{
+	(*GenerateIDRequest)(nil),     // 0: idgenerator.v1.GenerateIDRequest
+	(*GenerateIDResponse)(nil),    // 1: idgenerator.v1.GenerateIDResponse
+	(*GenerateBatchRequest)(nil),  // 2: idgenerator.v1.GenerateBatchRequest
+	(*GenerateBatchResponse)(nil), // 3: idgenerator.v1.GenerateBatchResponse
+}
+var file_idgenerator_proto_depIdxs = []int32{
+	0, // 0: idgenerator.v1.IDGeneratorService.GenerateID:input_type -> idgenerator.v1.GenerateIDRequest
+	2, // 1: idgenerator.v1.IDGeneratorService.GenerateBatch:input_type -> idgenerator.v1.GenerateBatchRequest
+	1, // 2: idgenerator.v1.IDGeneratorService.GenerateID:output_type -> idgenerator.v1.GenerateIDResponse
+	3, // 3: idgenerator.v1.IDGeneratorService.GenerateBatch:output_type -> idgenerator.v1.GenerateBatchResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_idgenerator_proto_init() }
+func file_idgenerator_proto_init() {
+	if File_idgenerator_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_idgenerator_proto_rawDesc), len(file_idgenerator_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_idgenerator_proto_goTypes,
+		DependencyIndexes: file_idgenerator_proto_depIdxs,
+		MessageInfos:      file_idgenerator_proto_msgTypes,
+	}.Build()
+	File_idgenerator_proto = out.File
+	file_idgenerator_proto_goTypes = nil
+	file_idgenerator_proto_depIdxs = nil
+}
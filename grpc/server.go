@@ -0,0 +1,73 @@
+// Package grpc serves Snowflake IDs of an idgenerator.Generator over gRPC,
+// for a centralized ID generation service.
+//
+// The service is defined in idgenerator.proto. IDs are returned as strings to preserve precision.
+// It is a separate module, so that the idgenerator package does not depend on gRPC.
+package grpc
+
+//go:generate buf generate
+
+import (
+	"context"
+	"errors"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaxBatchSize is the maximum count of a single GenerateBatch request.
+const MaxBatchSize = 10000
+
+// Server implements IDGeneratorServiceServer with a Generator.
+type Server struct {
+	UnimplementedIDGeneratorServiceServer
+
+	g *idgenerator.Generator
+}
+
+var _ IDGeneratorServiceServer = (*Server)(nil)
+
+// NewServer returns a new Server that generates IDs with g.
+// Register it with RegisterIDGeneratorServiceServer.
+func NewServer(g *idgenerator.Generator) *Server {
+	return &Server{g: g}
+}
+
+// GenerateID returns a new ID.
+func (s *Server) GenerateID(ctx context.Context, req *GenerateIDRequest) (*GenerateIDResponse, error) {
+	id, err := s.g.NextCtx(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &GenerateIDResponse{Id: id.String()}, nil
+}
+
+// GenerateBatch returns count new IDs in increasing order.
+func (s *Server) GenerateBatch(ctx context.Context, req *GenerateBatchRequest) (*GenerateBatchResponse, error) {
+	if req.GetCount() <= 0 || req.GetCount() > MaxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "count must be between 1 and %d", MaxBatchSize)
+	}
+	ids, err := s.g.NextNCtx(ctx, int(req.GetCount()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &GenerateBatchResponse{Ids: make([]string, len(ids))}
+	for i, id := range ids {
+		res.Ids[i] = id.String()
+	}
+	return res, nil
+}
+
+// toStatus converts a Generator error to a gRPC status.
+// Sequence exhaustion is Unavailable, so that clients retry it.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, idgenerator.ErrSequenceExhausted):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
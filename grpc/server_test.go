@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startServer serves srv on an in-memory connection and returns a client connected to it.
+func startServer(t *testing.T, srv IDGeneratorServiceServer) *Client {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterIDGeneratorServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	c, err := NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestServer(t *testing.T) {
+	type args struct {
+		count int32
+	}
+	tests := []struct {
+		name     string
+		prepare  func(c *idgenerator.SimulatedClock, g *idgenerator.Generator)
+		args     args
+		want     []string
+		wantCode codes.Code
+	}{
+		{"GenerateID", nil, args{0}, []string{"11234023837724672"}, codes.OK},
+		{"GenerateBatch count:3", nil, args{3}, []string{"11234023837724672", "11234023837724673", "11234023837724674"}, codes.OK},
+		{"Error GenerateBatch count:-1", nil, args{-1}, nil, codes.InvalidArgument},
+		{"Error GenerateBatch count:10001", nil, args{10001}, nil, codes.InvalidArgument},
+		{
+			"Error sequence exhausted",
+			func(c *idgenerator.SimulatedClock, g *idgenerator.Generator) {
+				g.NextN(4096)
+			},
+			args{0},
+			nil,
+			codes.Unavailable,
+		},
+		{
+			"Error clock moved backward",
+			func(c *idgenerator.SimulatedClock, g *idgenerator.Generator) {
+				g.Next()
+				c.Advance(-time.Millisecond)
+			},
+			args{0},
+			nil,
+			codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+			g, err := idgenerator.NewGenerator(
+				idgenerator.WithClock(c),
+				idgenerator.WithDatacenterID(31),
+				idgenerator.WithMachineID(15),
+				idgenerator.WithSequenceExhaustionPolicy(idgenerator.ReturnError),
+			)
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			if tt.prepare != nil {
+				tt.prepare(c, g)
+			}
+			s := NewServer(g)
+
+			var got []string
+			if tt.args.count == 0 {
+				var res *GenerateIDResponse
+				res, err = s.GenerateID(context.Background(), &GenerateIDRequest{})
+				if err == nil {
+					got = []string{res.GetId()}
+				}
+			} else {
+				var res *GenerateBatchResponse
+				res, err = s.GenerateBatch(context.Background(), &GenerateBatchRequest{Count: tt.args.count})
+				if err == nil {
+					got = res.GetIds()
+				}
+			}
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("Server error = %v, want code %v", err, tt.wantCode)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Server = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Server = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// ErrUnexpectedStatus is returned by Client when the server responds with an unexpected status code.
+var ErrUnexpectedStatus = errors.New("unexpected status")
+
+// Client fetches IDs from a server running Handler.
+// A Client is safe for concurrent use by multiple goroutines.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+var _ idgenerator.IDGenerator = (*Client)(nil)
+
+// NewClient returns a new Client for the server at baseURL, such as "http://localhost:8080".
+// If httpClient is nil, http.DefaultClient is used.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: strings.TrimSuffix(baseURL, "/"), httpClient: httpClient}
+}
+
+// Next fetches a new ID from GET /id.
+func (c *Client) Next() (idgenerator.SnowflakeID, error) {
+	var res idResponse
+	if err := c.get("/id", &res); err != nil {
+		return 0, err
+	}
+	return res.ID, nil
+}
+
+// NextN fetches n new IDs from GET /ids.
+// n must not be greater than MaxBatchSize.
+func (c *Client) NextN(n int) ([]idgenerator.SnowflakeID, error) {
+	if n <= 0 || n > MaxBatchSize {
+		return nil, idgenerator.ErrInvalidCount
+	}
+	var res idsResponse
+	if err := c.get("/ids?"+url.Values{"n": {strconv.Itoa(n)}}.Encode(), &res); err != nil {
+		return nil, err
+	}
+	return res.IDs, nil
+}
+
+// get sends a GET request to path and decodes the JSON response into v.
+// A 503 response is returned as idgenerator.ErrSequenceExhausted, so that it can be retried later.
+func (c *Client) get(path string, v any) error {
+	res, err := c.httpClient.Get(c.baseURL + path)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		var e errorResponse
+		json.NewDecoder(res.Body).Decode(&e)
+		if res.StatusCode == http.StatusServiceUnavailable {
+			return fmt.Errorf("%w: %s", idgenerator.ErrSequenceExhausted, e.Error)
+		}
+		return fmt.Errorf("%w: %d %s", ErrUnexpectedStatus, res.StatusCode, e.Error)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
@@ -0,0 +1,54 @@
+package httphandler
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func TestClient(t *testing.T) {
+	c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := idgenerator.NewGenerator(
+		idgenerator.WithClock(c),
+		idgenerator.WithDatacenterID(31),
+		idgenerator.WithMachineID(15),
+		idgenerator.WithSequenceExhaustionPolicy(idgenerator.ReturnError),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	srv := httptest.NewServer(Handler(g))
+	defer srv.Close()
+	client := NewClient(srv.URL+"/", nil)
+
+	id, err := client.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if id != 11234023837724672 {
+		t.Errorf("Next() = %v, want %v", id, 11234023837724672)
+	}
+
+	ids, err := client.NextN(4095)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if len(ids) != 4095 || ids[0] != id+1 || ids[len(ids)-1] != id+4095 {
+		t.Errorf("NextN() = [%v ... %v] (len %v), want [%v ... %v] (len %v)", ids[0], ids[len(ids)-1], len(ids), id+1, id+4095, 4095)
+	}
+
+	if _, err := client.Next(); !errors.Is(err, idgenerator.ErrSequenceExhausted) {
+		t.Errorf("Next() error = %v, want %v", err, idgenerator.ErrSequenceExhausted)
+	}
+	if _, err := client.NextN(0); err != idgenerator.ErrInvalidCount {
+		t.Errorf("NextN() error = %v, want %v", err, idgenerator.ErrInvalidCount)
+	}
+
+	notFound := NewClient(srv.URL+"/unknown", nil)
+	if _, err := notFound.Next(); !errors.Is(err, ErrUnexpectedStatus) {
+		t.Errorf("Next() error = %v, want %v", err, ErrUnexpectedStatus)
+	}
+}
@@ -0,0 +1,81 @@
+// Package httphandler serves Snowflake IDs of an idgenerator.Generator over HTTP,
+// for a centralized ID generation service.
+//
+// IDs are encoded as JSON strings, since a JavaScript number loses precision over 2^53.
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// MaxBatchSize is the maximum number of IDs returned by a single GET /ids request.
+const MaxBatchSize = 10000
+
+type idResponse struct {
+	ID idgenerator.SnowflakeID `json:"id"`
+}
+
+type idsResponse struct {
+	IDs []idgenerator.SnowflakeID `json:"ids"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler returns an http.Handler that serves IDs generated by g:
+//
+//   - GET /id returns a single ID as {"id": "123456789"}.
+//   - GET /ids?n=100 returns n IDs in increasing order as {"ids": ["123456789", ...]}.
+//
+// Errors are returned as {"error": "..."} with 400 for an invalid n,
+// 503 on sequence exhaustion, and 500 on clock skew or any other error.
+func Handler(g *idgenerator.Generator) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /id", func(w http.ResponseWriter, r *http.Request) {
+		id, err := g.NextCtx(r.Context())
+		if err != nil {
+			writeError(w, statusCode(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, idResponse{ID: id})
+	})
+	mux.HandleFunc("GET /ids", func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.Atoi(r.URL.Query().Get("n"))
+		if err != nil || n <= 0 || n > MaxBatchSize {
+			writeError(w, http.StatusBadRequest, errors.New("n must be an integer between 1 and "+strconv.Itoa(MaxBatchSize)))
+			return
+		}
+		ids, err := g.NextNCtx(r.Context(), n)
+		if err != nil {
+			writeError(w, statusCode(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, idsResponse{IDs: ids})
+	})
+	return mux
+}
+
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, idgenerator.ErrSequenceExhausted):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,105 @@
+package httphandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		prepare  func(c *idgenerator.SimulatedClock, g *idgenerator.Generator)
+		wantCode int
+		wantBody string
+	}{
+		{
+			"GET /id",
+			"/id",
+			nil,
+			http.StatusOK,
+			`{"id":"11234023837724672"}`,
+		},
+		{
+			"GET /ids?n=3",
+			"/ids?n=3",
+			nil,
+			http.StatusOK,
+			`{"ids":["11234023837724672","11234023837724673","11234023837724674"]}`,
+		},
+		{
+			"Error GET /ids?n=0",
+			"/ids?n=0",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"n must be an integer between 1 and 10000"}`,
+		},
+		{
+			"Error GET /ids?n=10001",
+			"/ids?n=10001",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"n must be an integer between 1 and 10000"}`,
+		},
+		{
+			"Error GET /ids without n",
+			"/ids",
+			nil,
+			http.StatusBadRequest,
+			`{"error":"n must be an integer between 1 and 10000"}`,
+		},
+		{
+			"Error sequence exhausted",
+			"/id",
+			func(c *idgenerator.SimulatedClock, g *idgenerator.Generator) {
+				g.NextN(4096)
+			},
+			http.StatusServiceUnavailable,
+			`{"error":"sequence number exhausted"}`,
+		},
+		{
+			"Error clock moved backward",
+			"/id",
+			func(c *idgenerator.SimulatedClock, g *idgenerator.Generator) {
+				g.Next()
+				c.Advance(-time.Millisecond)
+			},
+			http.StatusInternalServerError,
+			`{"error":"clock moved backward"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+			g, err := idgenerator.NewGenerator(
+				idgenerator.WithClock(c),
+				idgenerator.WithDatacenterID(31),
+				idgenerator.WithMachineID(15),
+				idgenerator.WithSequenceExhaustionPolicy(idgenerator.ReturnError),
+			)
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			if tt.prepare != nil {
+				tt.prepare(c, g)
+			}
+
+			rec := httptest.NewRecorder()
+			Handler(g).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("Handler() code = %v, want %v", rec.Code, tt.wantCode)
+			}
+			if got := strings.TrimSpace(rec.Body.String()); got != tt.wantBody {
+				t.Errorf("Handler() body = %v, want %v", got, tt.wantBody)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Handler() Content-Type = %v, want %v", got, "application/json")
+			}
+		})
+	}
+}
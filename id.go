@@ -0,0 +1,98 @@
+package idgenerator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SnowflakeID represents a Snowflake ID.
+type SnowflakeID int64
+
+// SnowflakeIDFromInt64 converts an int64 value to a SnowflakeID.
+func SnowflakeIDFromInt64(v int64) SnowflakeID {
+	return SnowflakeID(v)
+}
+
+// Int64 returns the ID as int64.
+func (id SnowflakeID) Int64() int64 {
+	return int64(id)
+}
+
+// String returns the decimal representation of the ID.
+func (id SnowflakeID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// MarshalJSON implements json.Marshaler.
+// The ID is encoded as a quoted string, since a JavaScript number loses precision over 2^53.
+func (id SnowflakeID) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(id.String())), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Both a quoted string and a number are accepted.
+func (id *SnowflakeID) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidID, s)
+	}
+	*id = SnowflakeID(v)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The ID is encoded as 8 bytes in big-endian order, so that comparing the bytes gives the same order as comparing the IDs.
+func (id SnowflakeID) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint64(make([]byte, 0, 8), uint64(id)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (id *SnowflakeID) UnmarshalBinary(b []byte) error {
+	if len(b) != 8 {
+		return fmt.Errorf("%w: %d bytes, want 8 bytes", ErrInvalidID, len(b))
+	}
+	*id = SnowflakeID(binary.BigEndian.Uint64(b))
+	return nil
+}
+
+// timestamp returns the timestamp bits of the ID in LayoutTwitterSnowflake.
+func (id SnowflakeID) timestamp() int64 {
+	return int64(id) >> timestampBitShift & maxTimestamp
+}
+
+// Before reports whether id was generated in an earlier millisecond than other.
+// Unlike id < other, the datacenter ID, the machine ID, and the sequence number are ignored.
+func (id SnowflakeID) Before(other SnowflakeID) bool {
+	return id.timestamp() < other.timestamp()
+}
+
+// After reports whether id was generated in a later millisecond than other.
+// Unlike id > other, the datacenter ID, the machine ID, and the sequence number are ignored.
+func (id SnowflakeID) After(other SnowflakeID) bool {
+	return id.timestamp() > other.timestamp()
+}
+
+// Equal reports whether id was generated in the same millisecond as other.
+// Unlike id == other, the datacenter ID, the machine ID, and the sequence number are ignored.
+func (id SnowflakeID) Equal(other SnowflakeID) bool {
+	return id.timestamp() == other.timestamp()
+}
+
+// TimeDiff returns the difference of the times when a and b were generated, which is positive if a is after b.
+// baseTime must be the base time the IDs were generated with. If it is zero, the default base time is used.
+func TimeDiff(a, b SnowflakeID, baseTime time.Time) time.Duration {
+	return ExtractTime(int64(a), baseTime).Sub(ExtractTime(int64(b), baseTime))
+}
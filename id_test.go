@@ -0,0 +1,176 @@
+package idgenerator
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSnowflakeID_String(t *testing.T) {
+	tests := []struct {
+		name string
+		id   SnowflakeID
+		want string
+	}{
+		{"Zero", 0, "0"},
+		{"Snowflake ID", 11234023837724673, "11234023837724673"},
+		{"Maximum value", 9223372036854775807, "9223372036854775807"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.id.String(); got != tt.want {
+				t.Errorf("SnowflakeID.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnowflakeID_Int64(t *testing.T) {
+	id := SnowflakeIDFromInt64(11234023837724673)
+	if got := id.Int64(); got != 11234023837724673 {
+		t.Errorf("SnowflakeID.Int64() = %v, want %v", got, 11234023837724673)
+	}
+}
+
+func TestSnowflakeID_MarshalJSON(t *testing.T) {
+	v := struct {
+		ID SnowflakeID `json:"id"`
+	}{ID: 9223372036854775807}
+	got, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"id":"9223372036854775807"}`; string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestSnowflakeID_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    SnowflakeID
+		wantErr bool
+	}{
+		{"Quoted string", `{"id":"9223372036854775807"}`, 9223372036854775807, false},
+		{"Number", `{"id":11234023837724673}`, 11234023837724673, false},
+		{"Null", `{"id":null}`, 0, false},
+		{"Error not a number", `{"id":"abc"}`, 0, true},
+		{"Error overflow", `{"id":"9223372036854775808"}`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v struct {
+				ID SnowflakeID `json:"id"`
+			}
+			err := json.Unmarshal([]byte(tt.input), &v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("json.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if v.ID != tt.want {
+				t.Errorf("json.Unmarshal() = %v, want %v", v.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnowflakeID_MarshalBinary(t *testing.T) {
+	got, err := SnowflakeID(11234023837724673).MarshalBinary()
+	if err != nil {
+		t.Fatalf("SnowflakeID.MarshalBinary() error = %v", err)
+	}
+	if want := []byte{0x00, 0x27, 0xe9, 0x49, 0x00, 0x3e, 0xf0, 0x01}; !bytes.Equal(got, want) {
+		t.Errorf("SnowflakeID.MarshalBinary() = %x, want %x", got, want)
+	}
+}
+
+func TestSnowflakeID_UnmarshalBinary(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		want    SnowflakeID
+		wantErr bool
+	}{
+		{"Snowflake ID", []byte{0x00, 0x27, 0xe9, 0x49, 0x00, 0x3e, 0xf0, 0x01}, 11234023837724673, false},
+		{"Maximum value", []byte{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, math.MaxInt64, false},
+		{"Error too short", []byte{0x00, 0x27}, 0, true},
+		{"Error too long", make([]byte, 9), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got SnowflakeID
+			err := got.UnmarshalBinary(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SnowflakeID.UnmarshalBinary() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SnowflakeID.UnmarshalBinary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnowflakeID_MarshalBinary_Order(t *testing.T) {
+	ids := []SnowflakeID{math.MaxInt64, 0, 11234023837724674, 255, 256, 11234023837724673, 1}
+	encoded := make([][]byte, len(ids))
+	for i, id := range ids {
+		b, err := id.MarshalBinary()
+		if err != nil {
+			t.Fatalf("SnowflakeID.MarshalBinary() error = %v", err)
+		}
+		encoded[i] = b
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	sort.Slice(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 })
+	for i, b := range encoded {
+		var got SnowflakeID
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatalf("SnowflakeID.UnmarshalBinary() error = %v", err)
+		}
+		if got != ids[i] {
+			t.Errorf("sorted bytes[%d] = %v, want %v", i, got, ids[i])
+		}
+	}
+}
+
+func TestSnowflakeID_Compare(t *testing.T) {
+	type args struct {
+		id, other SnowflakeID
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantBefore bool
+		wantAfter  bool
+		wantEqual  bool
+		wantDiff   time.Duration
+	}{
+		// 11234023837724673 is generated at 2024-02-01 00:00:00.000 with datacenter ID 31, machine ID 15, and sequence number 1.
+		// 11234023841918976 is generated 1 millisecond later with all the other fields 0.
+		{"Before", args{11234023837724673, 11234023841918976}, true, false, false, -time.Millisecond},
+		{"After", args{11234023841918976, 11234023837724673}, false, true, false, time.Millisecond},
+		{"Equal with different sequence numbers", args{11234023837724673, 11234023837724672}, false, false, true, 0},
+		{"Equal with different datacenter IDs", args{11234023837724673, 11234023837724673 &^ (31 << 17)}, false, false, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.args.id.Before(tt.args.other); got != tt.wantBefore {
+				t.Errorf("Before() = %v, want %v", got, tt.wantBefore)
+			}
+			if got := tt.args.id.After(tt.args.other); got != tt.wantAfter {
+				t.Errorf("After() = %v, want %v", got, tt.wantAfter)
+			}
+			if got := tt.args.id.Equal(tt.args.other); got != tt.wantEqual {
+				t.Errorf("Equal() = %v, want %v", got, tt.wantEqual)
+			}
+			if got := TimeDiff(tt.args.id, tt.args.other, time.Time{}); got != tt.wantDiff {
+				t.Errorf("TimeDiff() = %v, want %v", got, tt.wantDiff)
+			}
+		})
+	}
+}
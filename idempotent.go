@@ -0,0 +1,150 @@
+package idgenerator
+
+import (
+	"sync"
+	"time"
+)
+
+// IdempotencyStore stores the IDs generated by IdempotentGenerator by their idempotency keys.
+//
+// MemoryIdempotencyStore is for a single node. For multiple nodes, implement it with a shared store such as Redis,
+// e.g., Get with GET key, and Set with SET key id NX EX ttl, so that the first ID is kept.
+type IdempotencyStore interface {
+	// Get returns the ID stored for key, and whether it is stored.
+	Get(key string) (SnowflakeID, bool)
+	// Set stores id for key. The store should keep the ID already stored for key, if any.
+	Set(key string, id SnowflakeID)
+}
+
+// IdempotentGenerator returns the same ID for the same idempotency key, e.g., for the retries of a request of an API client.
+//
+// Concurrent calls with the same key in a process wait for the first one, and return the same ID.
+// Across processes, they return the same ID if the IdempotencyStore keeps the first ID stored for a key,
+// since Next returns the ID read back from the store after storing a new one.
+// An IdempotentGenerator is safe for concurrent use by multiple goroutines.
+type IdempotentGenerator struct {
+	g     IDGenerator
+	store IdempotencyStore
+
+	// inflight is the calls of Next generating the IDs of their keys.
+	inflight map[string]*idempotentCall
+	mutex    sync.Mutex
+}
+
+type idempotentCall struct {
+	done chan struct{}
+	id   SnowflakeID
+	err  error
+}
+
+// NewIdempotentGenerator returns a new IdempotentGenerator generating IDs with g, and storing them in store.
+func NewIdempotentGenerator(g IDGenerator, store IdempotencyStore) *IdempotentGenerator {
+	return &IdempotentGenerator{g: g, store: store, inflight: map[string]*idempotentCall{}}
+}
+
+// Next returns the ID stored for key, or generates and stores a new ID if there is none.
+func (ig *IdempotentGenerator) Next(key string) (SnowflakeID, error) {
+	if id, ok := ig.store.Get(key); ok {
+		return id, nil
+	}
+
+	ig.mutex.Lock()
+	if c, ok := ig.inflight[key]; ok {
+		ig.mutex.Unlock()
+		<-c.done
+		return c.id, c.err
+	}
+	c := &idempotentCall{done: make(chan struct{})}
+	ig.inflight[key] = c
+	ig.mutex.Unlock()
+
+	c.id, c.err = ig.generate(key)
+	close(c.done)
+	ig.mutex.Lock()
+	delete(ig.inflight, key)
+	ig.mutex.Unlock()
+	return c.id, c.err
+}
+
+func (ig *IdempotentGenerator) generate(key string) (SnowflakeID, error) {
+	// Another call may have stored the ID after the first Get.
+	if id, ok := ig.store.Get(key); ok {
+		return id, nil
+	}
+	id, err := ig.g.Next()
+	if err != nil {
+		return 0, err
+	}
+	ig.store.Set(key, id)
+	if stored, ok := ig.store.Get(key); ok {
+		return stored, nil
+	}
+	return id, nil
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore in memory backed by sync.Map, which forgets the IDs after the TTL.
+// Expired IDs are evicted when their keys are accessed, or by EvictExpired.
+// A MemoryIdempotencyStore is safe for concurrent use by multiple goroutines.
+type MemoryIdempotencyStore struct {
+	ttl   time.Duration
+	clock ClockSource
+	ids   sync.Map
+}
+
+var _ IdempotencyStore = (*MemoryIdempotencyStore)(nil)
+
+type idempotencyEntry struct {
+	id SnowflakeID
+	// expiresAt is the time the entry expires, or zero if it never expires.
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore returns a new MemoryIdempotencyStore keeping the IDs for ttl.
+// If ttl is not positive, the IDs are kept forever.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{ttl: ttl, clock: systemClock{}}
+}
+
+// Get returns the ID stored for key, and whether it is stored and not expired.
+func (s *MemoryIdempotencyStore) Get(key string) (SnowflakeID, bool) {
+	v, ok := s.ids.Load(key)
+	if !ok {
+		return 0, false
+	}
+	e := v.(*idempotencyEntry)
+	if e.expired(s.clock.Now()) {
+		s.ids.CompareAndDelete(key, e)
+		return 0, false
+	}
+	return e.id, true
+}
+
+// Set stores id for key, unless an ID is already stored for key and not expired.
+func (s *MemoryIdempotencyStore) Set(key string, id SnowflakeID) {
+	now := s.clock.Now()
+	e := &idempotencyEntry{id: id}
+	if s.ttl > 0 {
+		e.expiresAt = now.Add(s.ttl)
+	}
+	for {
+		v, loaded := s.ids.LoadOrStore(key, e)
+		if !loaded || !v.(*idempotencyEntry).expired(now) || s.ids.CompareAndSwap(key, v, e) {
+			return
+		}
+	}
+}
+
+// EvictExpired deletes the expired IDs, e.g., periodically to bound the memory for keys never accessed again.
+func (s *MemoryIdempotencyStore) EvictExpired() {
+	now := s.clock.Now()
+	s.ids.Range(func(key, v any) bool {
+		if v.(*idempotencyEntry).expired(now) {
+			s.ids.CompareAndDelete(key, v)
+		}
+		return true
+	})
+}
+
+func (e *idempotencyEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
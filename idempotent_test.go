@@ -0,0 +1,98 @@
+package idgenerator
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIdempotentGenerator_Next(t *testing.T) {
+	ig := NewIdempotentGenerator(SequentialMockGenerator(1), NewMemoryIdempotencyStore(0))
+	tests := []struct {
+		name string
+		key  string
+		want SnowflakeID
+	}{
+		{"First call", "a", 1},
+		{"Retry", "a", 1},
+		{"Another key", "b", 2},
+		{"Retry of the first key", "a", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ig.Next(tt.key)
+			if err != nil {
+				t.Fatalf("Next() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdempotentGenerator_Next_Concurrent(t *testing.T) {
+	ig := NewIdempotentGenerator(SequentialMockGenerator(1), NewMemoryIdempotencyStore(time.Minute))
+	const goroutines = 16
+	ids := make([]SnowflakeID, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id, err := ig.Next("key")
+			if err != nil {
+				t.Errorf("Next() error = %v", err)
+			}
+			ids[i] = id
+		}(i)
+	}
+	wg.Wait()
+	for i, id := range ids {
+		if id != ids[0] {
+			t.Errorf("Next() in goroutine %d = %v, want %v", i, id, ids[0])
+		}
+	}
+}
+
+func TestIdempotentGenerator_Next_Error(t *testing.T) {
+	ig := NewIdempotentGenerator(NewMockGenerator(), NewMemoryIdempotencyStore(0))
+	if _, err := ig.Next("key"); !errors.Is(err, ErrMockExhausted) {
+		t.Errorf("Next() error = %v, want %v", err, ErrMockExhausted)
+	}
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	clock := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	s := NewMemoryIdempotencyStore(time.Minute)
+	s.clock = clock
+
+	s.Set("a", 1)
+	s.Set("a", 2)
+	if id, ok := s.Get("a"); id != 1 || !ok {
+		t.Errorf("Get() = %v, %v, want %v, %v", id, ok, 1, true)
+	}
+
+	clock.Advance(time.Minute)
+	if id, ok := s.Get("a"); id != 0 || ok {
+		t.Errorf("Get() after the TTL = %v, %v, want %v, %v", id, ok, 0, false)
+	}
+	s.Set("a", 3)
+	if id, ok := s.Get("a"); id != 3 || !ok {
+		t.Errorf("Get() = %v, %v, want %v, %v", id, ok, 3, true)
+	}
+
+	s.Set("b", 4)
+	clock.Advance(time.Minute)
+	s.Set("c", 5)
+	s.EvictExpired()
+	var keys []any
+	s.ids.Range(func(key, _ any) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if len(keys) != 1 || keys[0] != "c" {
+		t.Errorf("EvictExpired() left %v, want [c]", keys)
+	}
+}
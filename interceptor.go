@@ -0,0 +1,40 @@
+package idgenerator
+
+import "context"
+
+// Interceptor is called around each call of a Generator method that generates IDs, e.g., to create a tracing span.
+// name is the name of the method, such as "Next" and "NextN".
+// An Interceptor must call generate to generate IDs, and usually returns its results as they are.
+// See the otel sub-package for the OpenTelemetry implementation.
+//
+// An Interceptor is called without the Generator's lock held.
+type Interceptor func(ctx context.Context, g *Generator, name string, generate func(context.Context) ([]SnowflakeID, error)) ([]SnowflakeID, error)
+
+// WithInterceptor adds an Interceptor to a Generator.
+// When it is specified multiple times, the first Interceptor is the outermost.
+func WithInterceptor(i Interceptor) Option {
+	return func(s *snowflake) error {
+		if i == nil {
+			return ErrInvalidInterceptor
+		}
+		s.interceptors = append(s.interceptors, i)
+		return nil
+	}
+}
+
+// chainInterceptors returns an Interceptor calling interceptors in order, or nil if there are none.
+func chainInterceptors(interceptors []Interceptor) Interceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+	chained := interceptors[len(interceptors)-1]
+	for i := len(interceptors) - 2; i >= 0; i-- {
+		outer, inner := interceptors[i], chained
+		chained = func(ctx context.Context, g *Generator, name string, generate func(context.Context) ([]SnowflakeID, error)) ([]SnowflakeID, error) {
+			return outer(ctx, g, name, func(ctx context.Context) ([]SnowflakeID, error) {
+				return inner(ctx, g, name, generate)
+			})
+		}
+	}
+	return chained
+}
@@ -0,0 +1,54 @@
+package idgenerator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithInterceptor(t *testing.T) {
+	var calls []string
+	record := func(label string) Interceptor {
+		return func(ctx context.Context, g *Generator, name string, generate func(context.Context) ([]SnowflakeID, error)) ([]SnowflakeID, error) {
+			calls = append(calls, label+" start "+name)
+			ids, err := generate(ctx)
+			calls = append(calls, label+" end "+name)
+			return ids, err
+		}
+	}
+
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(
+		WithClock(c),
+		WithSequenceExhaustionPolicy(ReturnError),
+		WithInterceptor(record("outer")),
+		WithInterceptor(record("inner")),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if _, err := g.NextN(maxSequenceNumber); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if _, err := g.Next(); err != ErrSequenceExhausted {
+		t.Fatalf("Next() error = %v, want %v", err, ErrSequenceExhausted)
+	}
+
+	want := []string{
+		"outer start Next", "inner start Next", "inner end Next", "outer end Next",
+		"outer start NextN", "inner start NextN", "inner end NextN", "outer end NextN",
+		"outer start Next", "inner start Next", "inner end Next", "outer end Next",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	if _, err := NewGenerator(WithInterceptor(nil)); err != ErrInvalidInterceptor {
+		t.Errorf("NewGenerator() error = %v, want %v", err, ErrInvalidInterceptor)
+	}
+}
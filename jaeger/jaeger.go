@@ -0,0 +1,46 @@
+// Package jaeger generates trace IDs and span IDs of Jaeger from Snowflake IDs.
+//
+// A 128-bit trace ID is two consecutive Snowflake IDs as the high and the low words, and a 64-bit span ID is a Snowflake ID,
+// so the IDs generated by the same Generator are unique and increase with time, which makes trace timelines sortable.
+package jaeger
+
+import (
+	"fmt"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// Generator generates trace IDs and span IDs.
+// A Generator is safe for concurrent use by multiple goroutines if the IDGenerator is.
+type Generator struct {
+	g idgenerator.IDGenerator
+}
+
+// NewGenerator returns a new Generator generating IDs with g.
+// Share g with the other users of the node, such as *idgenerator.Generator, to keep the IDs unique and ordered.
+func NewGenerator(g idgenerator.IDGenerator) *Generator {
+	return &Generator{g: g}
+}
+
+// JaegerTraceID returns a new 128-bit trace ID as the high and the low words, which are two consecutive Snowflake IDs.
+func (j *Generator) JaegerTraceID() (high, low uint64, err error) {
+	ids, err := j.g.NextN(2)
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint64(ids[0]), uint64(ids[1]), nil
+}
+
+// JaegerSpanID returns a new 64-bit span ID, which is a Snowflake ID.
+func (j *Generator) JaegerSpanID() (uint64, error) {
+	id, err := j.g.Next()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(id), nil
+}
+
+// JaegerTraceIDString returns the trace ID in 32-character lowercase hex, which Jaeger shows and accepts in queries.
+func JaegerTraceIDString(high, low uint64) string {
+	return fmt.Sprintf("%016x%016x", high, low)
+}
@@ -0,0 +1,56 @@
+package jaeger
+
+import (
+	"errors"
+	"testing"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func TestGenerator(t *testing.T) {
+	j := NewGenerator(idgenerator.SequentialMockGenerator(11234023837724673))
+	high, low, err := j.JaegerTraceID()
+	if err != nil {
+		t.Fatalf("JaegerTraceID() error = %v", err)
+	}
+	if high != 11234023837724673 || low != 11234023837724674 {
+		t.Errorf("JaegerTraceID() = %v, %v, want %v, %v", high, low, 11234023837724673, 11234023837724674)
+	}
+	spanID, err := j.JaegerSpanID()
+	if err != nil {
+		t.Fatalf("JaegerSpanID() error = %v", err)
+	}
+	if spanID != 11234023837724675 {
+		t.Errorf("JaegerSpanID() = %v, want %v", spanID, 11234023837724675)
+	}
+}
+
+func TestGenerator_Error(t *testing.T) {
+	j := NewGenerator(idgenerator.NewMockGenerator())
+	if _, _, err := j.JaegerTraceID(); !errors.Is(err, idgenerator.ErrMockExhausted) {
+		t.Errorf("JaegerTraceID() error = %v, want %v", err, idgenerator.ErrMockExhausted)
+	}
+	if _, err := j.JaegerSpanID(); !errors.Is(err, idgenerator.ErrMockExhausted) {
+		t.Errorf("JaegerSpanID() error = %v, want %v", err, idgenerator.ErrMockExhausted)
+	}
+}
+
+func TestJaegerTraceIDString(t *testing.T) {
+	tests := []struct {
+		name string
+		high uint64
+		low  uint64
+		want string
+	}{
+		{"Snowflake IDs", 11234023837724673, 11234023837724674, "0027e949003ef0010027e949003ef002"},
+		{"Zero high word", 0, 1, "00000000000000000000000000000001"},
+		{"Maximum value", 1<<64 - 1, 1<<64 - 1, "ffffffffffffffffffffffffffffffff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JaegerTraceIDString(tt.high, tt.low); got != tt.want {
+				t.Errorf("JaegerTraceIDString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package idgenerator
+
+// GenerateKafkaKey returns the ID as an 8-byte big-endian slice to be used as a Kafka message key.
+//
+// Comparing the keys as bytes gives the same order as comparing the IDs, that is, the order of generation time.
+// Kafka hashes the key to choose a partition, so the messages are spread over the partitions,
+// and the messages of a producer in each partition are in the order of their keys.
+//
+// Kafka looks up offsets by message timestamp, not by key. To scan the messages from from to to,
+// produce each message with the timestamp of its ID (ExtractTime), look up the offsets for from in each partition,
+// and keep reading while the key is at most the key of MaxIDForTime(to); the keys before MinIDForTime(from) can be skipped.
+//
+// Since IDs are unique, log compaction keeps every message whose key is not produced again,
+// and only removes the older messages of an ID that is updated with the same key.
+func GenerateKafkaKey(id SnowflakeID) []byte {
+	key, _ := id.MarshalBinary()
+	return key
+}
+
+// ParseKafkaKey decodes a Kafka message key generated by GenerateKafkaKey.
+func ParseKafkaKey(key []byte) (SnowflakeID, error) {
+	var id SnowflakeID
+	if err := id.UnmarshalBinary(key); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
@@ -0,0 +1,55 @@
+package idgenerator
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenerateKafkaKey(t *testing.T) {
+	got := GenerateKafkaKey(11234023837724673)
+	if want := []byte{0x00, 0x27, 0xe9, 0x49, 0x00, 0x3e, 0xf0, 0x01}; !bytes.Equal(got, want) {
+		t.Errorf("GenerateKafkaKey() = %x, want %x", got, want)
+	}
+
+	// The keys are ordered by generation time.
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	minID, err := MinIDForTime(from, time.Time{})
+	if err != nil {
+		t.Fatalf("MinIDForTime() error = %v", err)
+	}
+	maxID, err := MaxIDForTime(from.Add(time.Second), time.Time{})
+	if err != nil {
+		t.Fatalf("MaxIDForTime() error = %v", err)
+	}
+	key := GenerateKafkaKey(11234023837724673)
+	if bytes.Compare(GenerateKafkaKey(SnowflakeID(minID)), key) > 0 || bytes.Compare(key, GenerateKafkaKey(SnowflakeID(maxID))) > 0 {
+		t.Errorf("GenerateKafkaKey() = %x, want between %x and %x", key, GenerateKafkaKey(SnowflakeID(minID)), GenerateKafkaKey(SnowflakeID(maxID)))
+	}
+}
+
+func TestParseKafkaKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     []byte
+		want    SnowflakeID
+		wantErr error
+	}{
+		{"Valid", []byte{0x00, 0x27, 0xe9, 0x49, 0x00, 0x3e, 0xf0, 0x01}, 11234023837724673, nil},
+		{"Error too short", []byte{0x00, 0x27, 0xe9}, 0, ErrInvalidID},
+		{"Error nil", nil, 0, ErrInvalidID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseKafkaKey(tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseKafkaKey() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseKafkaKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Command idgenerator is the AWS Lambda function serving Snowflake IDs. See sam.yaml to deploy it.
+package main
+
+import (
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+	"github.com/kawabatas/go-id-generator/lambda"
+)
+
+func main() {
+	awslambda.Start(lambda.Handler)
+}
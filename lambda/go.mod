@@ -0,0 +1,9 @@
+module github.com/kawabatas/go-id-generator/lambda
+
+go 1.26
+
+require github.com/kawabatas/go-id-generator v0.0.0-00010101000000-000000000000
+
+require github.com/aws/aws-lambda-go v1.55.1
+
+replace github.com/kawabatas/go-id-generator => ../
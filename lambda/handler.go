@@ -0,0 +1,100 @@
+// Package lambda serves Snowflake IDs from AWS Lambda behind Amazon API Gateway.
+//
+// Start Handler with lambda.Start of github.com/aws/aws-lambda-go/lambda, as cmd/idgenerator does,
+// and deploy it with sam.yaml. IDs are encoded as JSON strings, since a JavaScript number loses precision over 2^53.
+//
+// Every execution environment of the function generates IDs with the datacenter ID and the machine ID in its environment variables,
+// so IDs are unique only if a single environment runs at a time, e.g., with the reserved concurrency of 1 in sam.yaml.
+// To scale out, deploy a function per machine ID.
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+const (
+	// DatacenterIDEnv is the environment variable of the datacenter ID.
+	DatacenterIDEnv = "DATACENTER_ID"
+	// MachineIDEnv is the environment variable of the machine ID.
+	MachineIDEnv = "MACHINE_ID"
+	// BaseTimeEnv is the optional environment variable of the base time in RFC 3339, such as "2024-01-01T00:00:00Z".
+	BaseTimeEnv = "BASE_TIME"
+)
+
+var (
+	generatorOnce sync.Once
+	generator     *idgenerator.Generator
+	generatorErr  error
+)
+
+// init initializes the Generator in the init phase of a cold start, and it is reused by warm invocations.
+func init() {
+	getGenerator()
+}
+
+func getGenerator() (*idgenerator.Generator, error) {
+	generatorOnce.Do(func() {
+		generator, generatorErr = idgenerator.NewGenerator(
+			idgenerator.WithDatacenterIDFromEnv(DatacenterIDEnv),
+			idgenerator.WithMachineIDFromEnv(MachineIDEnv),
+			idgenerator.WithBaseTimeFromEnv(BaseTimeEnv),
+		)
+	})
+	return generator, generatorErr
+}
+
+type idResponse struct {
+	ID        idgenerator.SnowflakeID `json:"id"`
+	Timestamp string                  `json:"timestamp"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler returns a new ID as {"id": "123456789", "timestamp": "2024-02-01T00:00:00.123Z"},
+// where the timestamp is the time the ID was generated in RFC 3339.
+//
+// Errors are returned as {"error": "..."} with 503 on sequence exhaustion, and 500 on invalid environment variables,
+// clock skew, or any other error. The returned error is always nil, so that API Gateway returns the response as it is.
+func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	g, err := getGenerator()
+	if err != nil {
+		return errorJSON(http.StatusInternalServerError, err), nil
+	}
+	rec, err := g.NextRecordedCtx(ctx)
+	if err != nil {
+		return errorJSON(statusCode(err), err), nil
+	}
+	return responseJSON(http.StatusOK, idResponse{ID: rec.ID, Timestamp: rec.Time.UTC().Format(time.RFC3339Nano)}), nil
+}
+
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, idgenerator.ErrSequenceExhausted):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func errorJSON(code int, err error) events.APIGatewayProxyResponse {
+	return responseJSON(code, errorResponse{Error: err.Error()})
+}
+
+func responseJSON(code int, v any) events.APIGatewayProxyResponse {
+	b, _ := json.Marshal(v)
+	return events.APIGatewayProxyResponse{
+		StatusCode: code,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(b),
+	}
+}
@@ -0,0 +1,87 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// resetGenerator makes the next invocation initialize the Generator again with the current environment variables.
+func resetGenerator(t *testing.T) {
+	t.Helper()
+	generatorOnce = sync.Once{}
+	t.Cleanup(func() { generatorOnce = sync.Once{} })
+}
+
+func TestHandler(t *testing.T) {
+	t.Setenv(DatacenterIDEnv, "31")
+	t.Setenv(MachineIDEnv, "15")
+	t.Setenv(BaseTimeEnv, "2020-01-01T00:00:00Z")
+	resetGenerator(t)
+
+	var ids []idgenerator.SnowflakeID
+	for i := 0; i < 2; i++ {
+		resp, err := Handler(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Path: "/id"})
+		if err != nil {
+			t.Fatalf("Handler() error = %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Handler() status = %v, want %v: %s", resp.StatusCode, http.StatusOK, resp.Body)
+		}
+		if got := resp.Headers["Content-Type"]; got != "application/json" {
+			t.Errorf("Handler() Content-Type = %v, want %v", got, "application/json")
+		}
+		var body struct {
+			ID        string `json:"id"`
+			Timestamp string `json:"timestamp"`
+		}
+		if err := json.Unmarshal([]byte(resp.Body), &body); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		var id idgenerator.SnowflakeID
+		if err := id.UnmarshalJSON([]byte(body.ID)); err != nil {
+			t.Fatalf("UnmarshalJSON() error = %v", err)
+		}
+		if got := idgenerator.ExtractWorkerID(int64(id)); got != 1007 {
+			t.Errorf("ExtractWorkerID() = %v, want %v", got, 1007)
+		}
+		ts, err := time.Parse(time.RFC3339, body.Timestamp)
+		if err != nil {
+			t.Fatalf("time.Parse() error = %v", err)
+		}
+		base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		if got := idgenerator.ExtractTime(int64(id), base); !got.Equal(ts.Truncate(time.Millisecond)) {
+			t.Errorf("ExtractTime() = %v, want %v", got, ts)
+		}
+		ids = append(ids, id)
+	}
+	if ids[0] >= ids[1] {
+		t.Errorf("Handler() = %v, %v, want increasing IDs from the reused Generator", ids[0], ids[1])
+	}
+}
+
+func TestHandler_InvalidEnv(t *testing.T) {
+	t.Setenv(DatacenterIDEnv, "32")
+	t.Setenv(MachineIDEnv, "15")
+	resetGenerator(t)
+
+	resp, err := Handler(context.Background(), events.APIGatewayProxyRequest{})
+	if err != nil {
+		t.Fatalf("Handler() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Handler() status = %v, want %v", resp.StatusCode, http.StatusInternalServerError)
+	}
+	var body struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &body); err != nil || body.Error == "" {
+		t.Errorf("Handler() body = %s, want an error", resp.Body)
+	}
+}
@@ -0,0 +1,282 @@
+package idgenerator
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Layout is a bit layout of Snowflake IDs.
+//
+// The fields are packed from the most significant bit in the order of
+// the timestamp, the datacenter ID, the machine ID, and the sequence number.
+type Layout struct {
+	TimestampBits  int
+	DatacenterBits int
+	MachineBits    int
+	SequenceBits   int
+	// TimeUnit is the resolution of the timestamp. If it is zero, a millisecond is used.
+	TimeUnit time.Duration
+
+	// nodeInLowBits places the datacenter ID and the machine ID below the sequence number, as in Sonyflake.
+	nodeInLowBits bool
+	// tagged sets bit 63, and reserves the top 2 bits of the sequence number for tag, which is a layout version
+	// from 1 to 3 for WithLayoutVersion, or reservedBitTag for WithReservedBit(true). See layoutTagBits.
+	tagged bool
+	tag    uint8
+}
+
+var (
+	// LayoutTwitterSnowflake is the default layout:
+	// 41-bit timestamp in milliseconds, 5-bit datacenter ID, 5-bit machine ID, and 12-bit sequence number.
+	LayoutTwitterSnowflake = Layout{
+		TimestampBits:  timestampBitRange,
+		DatacenterBits: datacenterBitRange,
+		MachineBits:    machineBitRange,
+		SequenceBits:   sequenceNumBitRange,
+		TimeUnit:       time.Millisecond,
+	}
+	// LayoutSonyflake is the layout of Sonyflake:
+	// 39-bit timestamp in 10 milliseconds, 8-bit sequence number, and 16-bit machine ID.
+	// It lasts about 174 years, and generates up to 256 IDs per 10 milliseconds per machine.
+	LayoutSonyflake = Layout{
+		TimestampBits: 39,
+		MachineBits:   16,
+		SequenceBits:  8,
+		TimeUnit:      10 * time.Millisecond,
+		nodeInLowBits: true,
+	}
+
+	// LayoutDiscord is the layout of Discord:
+	// 42-bit timestamp in milliseconds, 5-bit internal worker ID as the datacenter ID,
+	// 5-bit internal process ID as the machine ID, and 12-bit sequence number.
+	// Since IDs are int64, the timestamp is limited to 41 bits, which lasts until 2084 from EpochDiscord.
+	LayoutDiscord = Layout{
+		TimestampBits:  42,
+		DatacenterBits: 5,
+		MachineBits:    5,
+		SequenceBits:   12,
+		TimeUnit:       time.Millisecond,
+	}
+
+	// LayoutInstagram is the layout of Instagram:
+	// 41-bit timestamp in milliseconds, 13-bit shard ID as the machine ID, and 10-bit sequence number.
+	// Since IDs are int64, the timestamp is limited to 40 bits, which lasts about 34 years.
+	LayoutInstagram = Layout{
+		TimestampBits: 41,
+		MachineBits:   13,
+		SequenceBits:  10,
+		TimeUnit:      time.Millisecond,
+	}
+
+	// LayoutMicrosecond is a layout for a few IDs with a fine time resolution:
+	// 51-bit timestamp in microseconds, 5-bit machine ID, and 8-bit sequence number.
+	// It generates up to 256 IDs per microsecond per machine on up to 32 machines.
+	// Since IDs are int64, the timestamp is limited to 50 bits, which lasts about 35 years.
+	LayoutMicrosecond = Layout{
+		TimestampBits: 51,
+		MachineBits:   5,
+		SequenceBits:  8,
+		TimeUnit:      time.Microsecond,
+	}
+
+	// LayoutSecondResolution is a layout for very long-lived systems:
+	// 41-bit timestamp in seconds, 7-bit machine ID, and 16-bit sequence number.
+	// The trade-off is the throughput: it lasts for millennia, but generates at most 65,536 IDs per second per machine,
+	// and a Generator waits up to a second when they are exhausted.
+	// Since IDs are int64, the timestamp is limited to 40 bits, which lasts about 34,000 years.
+	LayoutSecondResolution = Layout{
+		TimestampBits: 41,
+		MachineBits:   7,
+		SequenceBits:  16,
+		TimeUnit:      time.Second,
+	}
+
+	// EpochSonyflake is the default base time of Sonyflake.
+	EpochSonyflake = time.Date(2014, 9, 1, 0, 0, 0, 0, time.UTC)
+	// EpochDiscord is the base time of Discord IDs.
+	EpochDiscord = time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// WithLayout specifies the bit layout of Snowflake ID. The default is LayoutTwitterSnowflake.
+//
+// The datacenter ID, the machine ID, and the sequence number are validated against the layout
+// when the ID or the Generator is created, so WithLayout can be specified in any order with them.
+func WithLayout(l Layout) Option {
+	return func(s *snowflake) error {
+		if err := l.validate(); err != nil {
+			return err
+		}
+		s.layout = l
+		return nil
+	}
+}
+
+// WithSonyflakeLayout specifies LayoutSonyflake as the bit layout of Snowflake ID.
+// Use it with WithBaseTime(EpochSonyflake) to generate the same IDs as Sonyflake.
+func WithSonyflakeLayout() Option {
+	return WithLayout(LayoutSonyflake)
+}
+
+// WithBitLayout specifies a custom bit layout of Snowflake ID with a timestamp in milliseconds.
+// The widths must be 63 bits in total, leaving the sign bit unused.
+//
+// For example, WithBitLayout(41, 0, 10, 12) supports 1024 machines without datacenters,
+// and WithBitLayout(41, 0, 0, 22) generates about 4 million IDs per millisecond on a single machine.
+func WithBitLayout(timestampBits, datacenterBits, machineBits, sequenceBits int) Option {
+	return func(s *snowflake) error {
+		if total := timestampBits + datacenterBits + machineBits + sequenceBits; total != 63 {
+			return fmt.Errorf("%w: %d bits in total, want 63", ErrInvalidLayout, total)
+		}
+		return WithLayout(Layout{
+			TimestampBits:  timestampBits,
+			DatacenterBits: datacenterBits,
+			MachineBits:    machineBits,
+			SequenceBits:   sequenceBits,
+			TimeUnit:       time.Millisecond,
+		})(s)
+	}
+}
+
+// WithShardID specifies the datacenter ID and the machine ID of Snowflake ID as a single shard ID,
+// which fills both of their bits in the layout, such as the 13-bit shard ID of LayoutInstagram.
+// In LayoutTwitterSnowflake, it is the same as WithWorkerID.
+func WithShardID(v int) Option {
+	return func(s *snowflake) error {
+		s.shardID = v
+		s.hasShardID = true
+		return nil
+	}
+}
+
+// getLayout returns the layout of the IDs, which is tagged with reservedBitTag for WithReservedBit(true) if it can be.
+func (s *snowflake) getLayout() Layout {
+	l := LayoutTwitterSnowflake
+	if s.layout != (Layout{}) {
+		l = s.layout
+	}
+	if s.reservedBit && !l.tagged && l.taggable() {
+		return l.withTag(reservedBitTag)
+	}
+	return l
+}
+
+func (l Layout) validate() error {
+	if l.TimestampBits <= 0 || l.DatacenterBits < 0 || l.MachineBits < 0 || l.SequenceBits < 0 {
+		return fmt.Errorf("%w: timestamp bits must be positive, and other bits must not be negative", ErrInvalidLayout)
+	}
+	if total := l.TimestampBits + l.DatacenterBits + l.MachineBits + l.SequenceBits; total > 64 {
+		return fmt.Errorf("%w: %d bits in total", ErrInvalidLayout, total)
+	}
+	if l.TimeUnit < 0 {
+		return fmt.Errorf("%w: negative time unit %v", ErrInvalidLayout, l.TimeUnit)
+	}
+	return nil
+}
+
+func (l Layout) timeUnit() time.Duration {
+	if l.TimeUnit != 0 {
+		return l.TimeUnit
+	}
+	return time.Millisecond
+}
+
+func (l Layout) timestampShift() int {
+	return l.DatacenterBits + l.MachineBits + l.SequenceBits
+}
+
+func (l Layout) datacenterShift() int {
+	if l.nodeInLowBits {
+		return l.MachineBits
+	}
+	return l.MachineBits + l.SequenceBits
+}
+
+func (l Layout) machineShift() int {
+	if l.nodeInLowBits {
+		return 0
+	}
+	return l.SequenceBits
+}
+
+func (l Layout) sequenceShift() int {
+	if l.nodeInLowBits {
+		return l.DatacenterBits + l.MachineBits
+	}
+	return 0
+}
+
+// maxTimestamp returns the largest timestamp that does not set the sign bit.
+func (l Layout) maxTimestamp() int64 {
+	return int64(1)<<min(l.TimestampBits, 63-l.timestampShift()) - 1
+}
+
+func (l Layout) maxDatacenterID() int {
+	return 1<<l.DatacenterBits - 1
+}
+
+func (l Layout) maxMachineID() int {
+	return 1<<l.MachineBits - 1
+}
+
+func (l Layout) maxShardID() int {
+	return 1<<(l.DatacenterBits+l.MachineBits) - 1
+}
+
+func (l Layout) maxSequenceNumber() int {
+	if l.tagged {
+		return 1<<(l.SequenceBits-layoutTagBits) - 1
+	}
+	return 1<<l.SequenceBits - 1
+}
+
+func (l Layout) compose(ts int64, datacenterID, machineID, sequenceNumber int) int64 {
+	id := ts<<l.timestampShift() | int64(datacenterID)<<l.datacenterShift() | int64(machineID)<<l.machineShift() | int64(sequenceNumber)<<l.sequenceShift()
+	if l.tagged {
+		id |= int64(reservedBit) | int64(l.tag)<<layoutTagShift
+	}
+	return id
+}
+
+func (l Layout) decompose(id int64, baseTime time.Time) SnowflakeComponents {
+	ts := id >> l.timestampShift() & l.maxTimestamp()
+	return SnowflakeComponents{
+		Timestamp:      addTimeUnits(baseTime, ts, l.timeUnit()).UTC(),
+		DatacenterID:   l.ExtractDatacenterID(id),
+		MachineID:      l.ExtractMachineID(id),
+		SequenceNumber: l.ExtractSequenceNumber(id),
+	}
+}
+
+// addTimeUnits returns baseTime plus n units. Unlike baseTime.Add(time.Duration(n) * unit),
+// it does not overflow when the timestamp of a layout lasts longer than time.Duration, about 292 years.
+// unit must be at most 9 seconds.
+func addTimeUnits(baseTime time.Time, n int64, unit time.Duration) time.Time {
+	secs := n / int64(time.Second) * int64(unit)
+	rem := time.Duration(n%int64(time.Second)) * unit
+	return time.Unix(baseTime.Unix()+secs, int64(baseTime.Nanosecond())).Add(rem)
+}
+
+// elapsedUnits returns the number of units from baseTime to at. Unlike int64(at.Sub(baseTime) / unit),
+// it does not saturate when at is more than about 292 years away from baseTime.
+// unit must be a divisor or a multiple of a second.
+func elapsedUnits(at, baseTime time.Time, unit time.Duration) int64 {
+	if d := at.Sub(baseTime); d > math.MinInt64 && d < math.MaxInt64 {
+		return int64(d / unit)
+	}
+	secs := at.Unix() - baseTime.Unix()
+	nanos := int64(at.Nanosecond() - baseTime.Nanosecond())
+	if nanos < 0 {
+		secs, nanos = secs-1, nanos+int64(time.Second)
+	}
+	if unit >= time.Second {
+		return secs / int64(unit/time.Second)
+	}
+	perSec := int64(time.Second / unit)
+	if secs > math.MaxInt64/perSec-1 {
+		return math.MaxInt64
+	} else if secs < math.MinInt64/perSec+1 {
+		return math.MinInt64
+	}
+	return secs*perSec + nanos/int64(unit)
+}
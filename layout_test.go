@@ -0,0 +1,396 @@
+package idgenerator
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWithLayout(t *testing.T) {
+	type args struct {
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int64
+		wantErr error
+	}{
+		{
+			"LayoutTwitterSnowflake",
+			args{[]Option{
+				WithLayout(LayoutTwitterSnowflake),
+				WithTimestamp(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+				WithDatacenterID(31),
+				WithMachineID(15),
+				WithSequenceNumber(1),
+			}},
+			11234023837724673,
+			nil,
+		},
+		{
+			"WithSonyflakeLayout after WithMachineID",
+			args{[]Option{
+				WithMachineID(0x1234),
+				WithSonyflakeLayout(),
+				WithBaseTime(EpochSonyflake),
+				WithTimestamp(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+				WithSequenceNumber(1),
+			}},
+			498645703065670196,
+			nil,
+		},
+		{
+			"Error invalid machine ID for LayoutSonyflake",
+			args{[]Option{
+				WithSonyflakeLayout(),
+				WithMachineID(0x10000),
+			}},
+			0,
+			ErrInvalidMachineID,
+		},
+		{
+			"Error invalid datacenter ID for LayoutSonyflake",
+			args{[]Option{
+				WithSonyflakeLayout(),
+				WithDatacenterID(1),
+			}},
+			0,
+			ErrInvalidDatacenterID,
+		},
+		{
+			"Error invalid sequence number for LayoutSonyflake",
+			args{[]Option{
+				WithSonyflakeLayout(),
+				WithSequenceNumber(256),
+			}},
+			0,
+			ErrInvalidSequenceNumber,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSnowflakeID(tt.args.opts...)
+			if err != tt.wantErr {
+				t.Errorf("NewSnowflakeID() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewSnowflakeID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithLayout_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+	}{
+		{"Zero timestamp bits", Layout{SequenceBits: 12}},
+		{"Negative bits", Layout{TimestampBits: 41, MachineBits: -1}},
+		{"Over 64 bits", Layout{TimestampBits: 41, MachineBits: 12, SequenceBits: 12}},
+		{"Negative time unit", Layout{TimestampBits: 41, TimeUnit: -time.Millisecond}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGenerator(WithLayout(tt.layout)); err == nil {
+				t.Errorf("NewGenerator() error = %v, wantErr %v", err, true)
+			}
+		})
+	}
+}
+
+func TestNewGeneratorWithLayout(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGeneratorWithLayout(LayoutSonyflake,
+		WithClock(c),
+		WithBaseTime(EpochSonyflake),
+		WithMachineID(0x1234),
+		WithSequenceExhaustionPolicy(ReturnError),
+	)
+	if err != nil {
+		t.Fatalf("NewGeneratorWithLayout() error = %v", err)
+	}
+	if got := g.Layout(); got != LayoutSonyflake {
+		t.Errorf("Layout() = %v, want %v", got, LayoutSonyflake)
+	}
+
+	ids, err := g.NextN(256)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if ids[0] != 498645703065604660 || ids[1] != 498645703065670196 {
+		t.Errorf("NextN() = [%v %v ...], want [%v %v ...]", ids[0], ids[1], 498645703065604660, 498645703065670196)
+	}
+	// The sequence number is 8 bits in LayoutSonyflake.
+	if _, err := g.Next(); err != ErrSequenceExhausted {
+		t.Errorf("Next() error = %v, want %v", err, ErrSequenceExhausted)
+	}
+
+	// The timestamp is in 10 milliseconds in LayoutSonyflake.
+	c.Advance(10 * time.Millisecond)
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	got, err := ParseSnowflakeID(int64(id), EpochSonyflake, LayoutSonyflake)
+	if err != nil {
+		t.Fatalf("ParseSnowflakeID() error = %v", err)
+	}
+	want := SnowflakeComponents{Timestamp: c.Now(), MachineID: 0x1234}
+	if got != want {
+		t.Errorf("ParseSnowflakeID() = %v, want %v", got, want)
+	}
+}
+
+func TestLayoutMicrosecond(t *testing.T) {
+	at := time.Date(2024, 2, 1, 0, 0, 0, 123456*int(time.Microsecond), time.UTC)
+	id, err := NewSnowflakeID(WithLayout(LayoutMicrosecond), WithTimestamp(at), WithMachineID(15), WithSequenceNumber(1))
+	if err != nil {
+		t.Fatalf("NewSnowflakeID() error = %v", err)
+	}
+	if want := int64(21941453811355393); id != want {
+		t.Errorf("NewSnowflakeID() = %v, want %v", id, want)
+	}
+	if got := ExtractTimeMicro(id, time.Time{}); !got.Equal(at) {
+		t.Errorf("ExtractTimeMicro() = %v, want %v", got, at)
+	}
+
+	// The timestamp is in microseconds in LayoutMicrosecond.
+	c := NewSimulatedClock(at)
+	g, err := NewGeneratorWithLayout(LayoutMicrosecond, WithClock(c), WithMachineID(15))
+	if err != nil {
+		t.Fatalf("NewGeneratorWithLayout() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		id, err := g.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		got, err := ParseSnowflakeID(int64(id), time.Time{}, LayoutMicrosecond)
+		if err != nil {
+			t.Fatalf("ParseSnowflakeID() error = %v", err)
+		}
+		want := SnowflakeComponents{Timestamp: c.Now(), MachineID: 15}
+		if got != want {
+			t.Errorf("ParseSnowflakeID() = %v, want %v", got, want)
+		}
+		c.Advance(time.Microsecond)
+	}
+}
+
+func TestLayoutSecondResolution(t *testing.T) {
+	at := time.Date(3024, 2, 1, 0, 0, 0, 0, time.UTC)
+	id, err := NewSnowflakeID(WithLayout(LayoutSecondResolution), WithTimestamp(at), WithMachineID(127), WithSequenceNumber(65535))
+	if err != nil {
+		t.Fatalf("NewSnowflakeID() error = %v", err)
+	}
+	got, err := ParseSnowflakeID(id, time.Time{}, LayoutSecondResolution)
+	if err != nil {
+		t.Fatalf("ParseSnowflakeID() error = %v", err)
+	}
+	want := SnowflakeComponents{Timestamp: at, MachineID: 127, SequenceNumber: 65535}
+	if got != want {
+		t.Errorf("ParseSnowflakeID() = %v, want %v", got, want)
+	}
+
+	// The sequence number is 16 bits per second in LayoutSecondResolution.
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGeneratorWithLayout(LayoutSecondResolution, WithClock(c), WithSequenceExhaustionPolicy(ReturnError))
+	if err != nil {
+		t.Fatalf("NewGeneratorWithLayout() error = %v", err)
+	}
+	if _, err := g.NextN(65536); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	c.Advance(999 * time.Millisecond)
+	if _, err := g.Next(); err != ErrSequenceExhausted {
+		t.Errorf("Next() error = %v, want %v", err, ErrSequenceExhausted)
+	}
+	c.Advance(time.Millisecond)
+	if _, err := g.Next(); err != nil {
+		t.Errorf("Next() error = %v", err)
+	}
+}
+
+func TestElapsedUnits(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 0, 0, 0, 500, time.UTC)
+	type args struct {
+		at   time.Time
+		unit time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want int64
+	}{
+		{"Millisecond", args{baseTime.Add(1500 * time.Microsecond), time.Millisecond}, 1},
+		{"Second", args{baseTime.Add(90 * time.Second), time.Second}, 90},
+		{"Second beyond time.Duration", args{time.Date(3024, 1, 1, 0, 0, 0, 499, time.UTC), time.Second}, 31556908800 - 1},
+		{"Millisecond beyond time.Duration", args{time.Date(3024, 1, 1, 0, 0, 1, 500, time.UTC), time.Millisecond}, 31556908801000},
+		{"Nanosecond overflow", args{time.Date(300024, 1, 1, 0, 0, 0, 0, time.UTC), time.Nanosecond}, math.MaxInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := elapsedUnits(tt.args.at, baseTime, tt.args.unit); got != tt.want {
+				t.Errorf("elapsedUnits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithBitLayout(t *testing.T) {
+	type args struct {
+		timestampBits, datacenterBits, machineBits, sequenceBits int
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantMachineID   int
+		wantSequenceNum int
+		wantErr         bool
+	}{
+		{"41/0/10/12", args{41, 0, 10, 12}, 1023, 4095, false},
+		{"41/0/0/22", args{41, 0, 0, 22}, 0, 1<<22 - 1, false},
+		{"46/0/10/7", args{46, 0, 10, 7}, 1023, 127, false},
+		{"Error 64 bits", args{41, 5, 5, 13}, 0, 0, true},
+		{"Error 62 bits", args{41, 5, 5, 11}, 0, 0, true},
+		{"Error zero timestamp bits", args{0, 21, 21, 21}, 0, 0, true},
+		{"Error negative bits", args{41, -1, 11, 12}, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+			id, err := NewSnowflakeID(
+				WithBitLayout(tt.args.timestampBits, tt.args.datacenterBits, tt.args.machineBits, tt.args.sequenceBits),
+				WithTimestamp(at),
+				WithMachineID(tt.wantMachineID),
+				WithSequenceNumber(tt.wantSequenceNum),
+			)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSnowflakeID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			layout := Layout{
+				TimestampBits:  tt.args.timestampBits,
+				DatacenterBits: tt.args.datacenterBits,
+				MachineBits:    tt.args.machineBits,
+				SequenceBits:   tt.args.sequenceBits,
+				TimeUnit:       time.Millisecond,
+			}
+			if got := layout.ExtractTime(id, time.Time{}); !got.Equal(at) {
+				t.Errorf("ExtractTime() = %v, want %v", got, at)
+			}
+			if got := layout.ExtractDatacenterID(id); got != 0 {
+				t.Errorf("ExtractDatacenterID() = %v, want %v", got, 0)
+			}
+			if got := layout.ExtractMachineID(id); got != tt.wantMachineID {
+				t.Errorf("ExtractMachineID() = %v, want %v", got, tt.wantMachineID)
+			}
+			if got := layout.ExtractSequenceNumber(id); got != tt.wantSequenceNum {
+				t.Errorf("ExtractSequenceNumber() = %v, want %v", got, tt.wantSequenceNum)
+			}
+		})
+	}
+}
+
+func TestWithShardID(t *testing.T) {
+	type args struct {
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int64
+		wantErr error
+	}{
+		{
+			// The example in the Instagram engineering blog post "Sharding & IDs at Instagram",
+			// with 1387263000 milliseconds since the epoch, shard ID 1341, and sequence number 5001 % 1024.
+			"LayoutInstagram",
+			args{[]Option{
+				WithLayout(LayoutInstagram),
+				WithBaseTime(time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC)),
+				WithTimestamp(time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC).Add(1387263000 * time.Millisecond)),
+				WithShardID(1341),
+				WithSequenceNumber(5001 % 1024),
+			}},
+			11637205501278089,
+			nil,
+		},
+		{
+			"LayoutTwitterSnowflake",
+			args{[]Option{
+				WithTimestamp(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+				WithShardID(1007),
+				WithSequenceNumber(1),
+			}},
+			11234023837724673,
+			nil,
+		},
+		{
+			"Error invalid shard ID for LayoutInstagram",
+			args{[]Option{
+				WithShardID(8192),
+				WithLayout(LayoutInstagram),
+			}},
+			0,
+			ErrInvalidShardID,
+		},
+		{
+			"Error negative shard ID",
+			args{[]Option{
+				WithShardID(-1),
+			}},
+			0,
+			ErrInvalidShardID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSnowflakeID(tt.args.opts...)
+			if err != tt.wantErr {
+				t.Errorf("NewSnowflakeID() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewSnowflakeID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithWorkerID_Layout(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             []Option
+		wantDatacenterID int
+		wantMachineID    int
+		wantErr          error
+	}{
+		{"LayoutTwitterSnowflake", []Option{WithWorkerID(1007)}, 31, 15, nil},
+		{"Before WithBitLayout", []Option{WithWorkerID(1000), WithBitLayout(41, 0, 10, 12)}, 0, 1000, nil},
+		{"After WithBitLayout", []Option{WithBitLayout(41, 2, 8, 12), WithWorkerID(1000)}, 3, 232, nil},
+		{"Error beyond WithBitLayout", []Option{WithBitLayout(41, 0, 8, 14), WithWorkerID(256)}, 0, 0, ErrInvalidWorkerID},
+		{"Error negative", []Option{WithWorkerID(-1)}, 0, 0, ErrInvalidWorkerID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewGenerator() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if g.DatacenterID() != tt.wantDatacenterID || g.MachineID() != tt.wantMachineID {
+				t.Errorf("NewGenerator() node = %v/%v, want %v/%v", g.DatacenterID(), g.MachineID(), tt.wantDatacenterID, tt.wantMachineID)
+			}
+		})
+	}
+}
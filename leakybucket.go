@@ -0,0 +1,109 @@
+package idgenerator
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LeakyBucketGenerator paces the IDs of a Generator at a fixed interval of 1s / idsPerSecond,
+// e.g., to smooth the load of producers of a message queue.
+//
+// Unlike WithRateLimit, which lets NextN return a batch of IDs at once, every ID waits for the interval
+// from the previous one, so NextN spreads its IDs over time, and so do the timestamps in them.
+// The interval is measured in real time, not with the clock specified by WithClock.
+// A LeakyBucketGenerator is safe for concurrent use by multiple goroutines.
+type LeakyBucketGenerator struct {
+	g        *Generator
+	interval time.Duration
+
+	// last is the time the last ID is emitted, or is reserved to be emitted.
+	last  time.Time
+	mutex sync.Mutex
+}
+
+var _ IDGenerator = (*LeakyBucketGenerator)(nil)
+
+// NewLeakyBucketGenerator returns a new LeakyBucketGenerator emitting the IDs of g at idsPerSecond.
+// As with WithRateLimit, it returns ErrInvalidRateLimit if idsPerSecond is not positive and finite,
+// or the interval is shorter than a nanosecond or longer than time.Duration can hold.
+func NewLeakyBucketGenerator(g *Generator, idsPerSecond float64) (*LeakyBucketGenerator, error) {
+	interval, err := rateInterval(idsPerSecond)
+	if err != nil {
+		return nil, err
+	}
+	return &LeakyBucketGenerator{g: g, interval: interval}, nil
+}
+
+// Next returns a new ID after the interval from the previous one.
+// It is the same as NextCtx with context.Background().
+func (l *LeakyBucketGenerator) Next() (SnowflakeID, error) {
+	return l.NextCtx(context.Background())
+}
+
+// NextCtx returns a new ID after the interval from the previous one.
+// If ctx is done while waiting, NextCtx returns ctx.Err().
+func (l *LeakyBucketGenerator) NextCtx(ctx context.Context) (SnowflakeID, error) {
+	if err := l.wait(ctx); err != nil {
+		return 0, err
+	}
+	return l.g.NextCtx(ctx)
+}
+
+// NextN returns n new IDs, each generated after the interval from the previous one.
+// It is the same as NextNCtx with context.Background().
+func (l *LeakyBucketGenerator) NextN(n int) ([]SnowflakeID, error) {
+	return l.NextNCtx(context.Background(), n)
+}
+
+// NextNCtx returns n new IDs, each generated after the interval from the previous one.
+// If ctx is done while waiting, NextNCtx returns ctx.Err() without the IDs generated so far.
+func (l *LeakyBucketGenerator) NextNCtx(ctx context.Context, n int) ([]SnowflakeID, error) {
+	if n <= 0 {
+		return nil, ErrInvalidCount
+	}
+	ids := make([]SnowflakeID, n)
+	for i := range ids {
+		id, err := l.NextCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// wait blocks until the interval has passed since the last emission.
+// If ctx is done while waiting, the reserved time is returned as far as nobody has reserved after it.
+func (l *LeakyBucketGenerator) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mutex.Lock()
+	prev := l.last
+	at := prev.Add(l.interval)
+	if now := time.Now(); at.Before(now) {
+		at = now
+	}
+	l.last = at
+	l.mutex.Unlock()
+
+	wait := time.Until(at)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		if l.last.Equal(at) {
+			l.last = prev
+		}
+		l.mutex.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,102 @@
+package idgenerator
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewLeakyBucketGenerator(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	tests := []struct {
+		name         string
+		idsPerSecond float64
+		wantErr      error
+	}{
+		{"idsPerSecond:0.5", 0.5, nil},
+		{"idsPerSecond:1000", 1000, nil},
+		{"Error idsPerSecond:0", 0, ErrInvalidRateLimit},
+		{"Error idsPerSecond:-1", -1, ErrInvalidRateLimit},
+		{"Error idsPerSecond:+Inf", math.Inf(1), ErrInvalidRateLimit},
+		{"Error idsPerSecond:NaN", math.NaN(), ErrInvalidRateLimit},
+		{"Error interval shorter than a nanosecond", 2e9, ErrInvalidRateLimit},
+		{"Error interval overflowing time.Duration", 1e-10, ErrInvalidRateLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewLeakyBucketGenerator(g, tt.idsPerSecond); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewLeakyBucketGenerator() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLeakyBucketGenerator_NextN(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	const interval = 20 * time.Millisecond
+	l, err := NewLeakyBucketGenerator(g, float64(time.Second/interval))
+	if err != nil {
+		t.Fatalf("NewLeakyBucketGenerator() error = %v", err)
+	}
+
+	start := time.Now()
+	ids, err := l.NextN(3)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	id, err := l.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	ids = append(ids, id)
+	// The first ID is emitted at once, and the others after the interval each.
+	if elapsed := time.Since(start); elapsed < 3*interval {
+		t.Errorf("NextN() and Next() took %v, want at least %v", elapsed, 3*interval)
+	}
+	for i := 1; i < len(ids); i++ {
+		// The timestamps are truncated to milliseconds.
+		if gap := ExtractTime(int64(ids[i]), time.Time{}).Sub(ExtractTime(int64(ids[i-1]), time.Time{})); gap < interval-time.Millisecond {
+			t.Errorf("IDs #%d and #%d are %v apart, want at least %v", i-1, i, gap, interval)
+		}
+	}
+
+	if _, err := l.NextN(0); err != ErrInvalidCount {
+		t.Errorf("NextN() error = %v, want %v", err, ErrInvalidCount)
+	}
+}
+
+func TestLeakyBucketGenerator_NextCtx_Cancel(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	l, err := NewLeakyBucketGenerator(g, 1)
+	if err != nil {
+		t.Fatalf("NewLeakyBucketGenerator() error = %v", err)
+	}
+	if _, err := l.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	last := l.last
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := l.NextCtx(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("NextCtx() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("NextCtx() took %v, want to return on cancellation", elapsed)
+	}
+	if !l.last.Equal(last) {
+		t.Errorf("NextCtx() left the reservation at %v, want %v", l.last, last)
+	}
+}
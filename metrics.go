@@ -0,0 +1,44 @@
+package idgenerator
+
+// Metrics records events of a Generator, e.g., to export them to a monitoring system.
+// See the metrics sub-package for the Prometheus implementation.
+//
+// The methods are called while the Generator holds its lock,
+// so they must return quickly and must not generate IDs with the same Generator.
+type Metrics interface {
+	// ObserveID is called when an ID is generated, with the ID as returned, e.g., after WithObfuscation.
+	// Generator.ExtractSequenceNumber reads its sequence number.
+	ObserveID(g *Generator, id SnowflakeID)
+	// ObserveSequenceOverflow is called when the sequence number of a millisecond is exhausted.
+	ObserveSequenceOverflow(g *Generator)
+	// ObserveSequenceFallback is called when an ID is generated with a random sequence number
+	// after the sequence number is exhausted. See WithCryptoRandomFallback.
+	ObserveSequenceFallback(g *Generator)
+	// ObserveClockSkew is called when the clock moved backward, whether or not it is within the tolerance.
+	ObserveClockSkew(g *Generator)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveID(*Generator, SnowflakeID)  {}
+func (noopMetrics) ObserveSequenceOverflow(*Generator) {}
+func (noopMetrics) ObserveSequenceFallback(*Generator) {}
+func (noopMetrics) ObserveClockSkew(*Generator)        {}
+
+// WithMetrics specifies the Metrics that records events of a Generator.
+func WithMetrics(m Metrics) Option {
+	return func(s *snowflake) error {
+		if m == nil {
+			return ErrInvalidMetrics
+		}
+		s.metrics = m
+		return nil
+	}
+}
+
+func (s *snowflake) getMetrics() Metrics {
+	if s.metrics != nil {
+		return s.metrics
+	}
+	return noopMetrics{}
+}
@@ -0,0 +1,149 @@
+// Package metrics exports metrics of an idgenerator.Generator to Prometheus.
+//
+// It is a separate module, so that the idgenerator package does not depend on the Prometheus client.
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var labelNames = []string{"datacenter_id", "machine_id"}
+
+type prometheusMetrics struct {
+	idsGenerated      *prometheus.CounterVec
+	sequenceOverflows *prometheus.CounterVec
+	sequenceFallbacks *prometheus.CounterVec
+	clockSkewEvents   *prometheus.CounterVec
+	currentSequence   *prometheus.GaugeVec
+
+	// nodes caches the labels and the metrics of each ID per datacenter ID and machine ID.
+	nodes sync.Map // map[node]*nodeMetrics
+}
+
+type node struct {
+	datacenterID, machineID int
+}
+
+// nodeMetrics is the labels of a datacenter ID and a machine ID, and the metrics observed on every ID with them.
+// The other metrics are labeled on each event, so that they are not exported until the event happens.
+type nodeMetrics struct {
+	labels          prometheus.Labels
+	idsGenerated    prometheus.Counter
+	currentSequence prometheus.Gauge
+}
+
+// WithPrometheusMetrics registers the Generator metrics to registry under namespace:
+//
+//   - ids_generated_total (counter)
+//   - sequence_overflows_total (counter)
+//   - sequence_fallback_total (counter)
+//   - clock_skew_events_total (counter)
+//   - current_sequence (gauge)
+//
+// All metrics are labeled with datacenter_id and machine_id.
+// Multiple Generators can share the same registry and namespace.
+func WithPrometheusMetrics(registry prometheus.Registerer, namespace string) idgenerator.Option {
+	return idgenerator.LazyOption(func() (idgenerator.Option, error) {
+		m := &prometheusMetrics{
+			idsGenerated: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "ids_generated_total",
+				Help:      "Total number of generated IDs.",
+			}, labelNames),
+			sequenceOverflows: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "sequence_overflows_total",
+				Help:      "Total number of times the sequence number of a millisecond was exhausted.",
+			}, labelNames),
+			sequenceFallbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "sequence_fallback_total",
+				Help:      "Total number of IDs generated with a random sequence number after the sequence number was exhausted.",
+			}, labelNames),
+			clockSkewEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "clock_skew_events_total",
+				Help:      "Total number of times the clock moved backward.",
+			}, labelNames),
+			currentSequence: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "current_sequence",
+				Help:      "Sequence number of the last generated ID.",
+			}, labelNames),
+		}
+		var err error
+		if m.idsGenerated, err = register(registry, m.idsGenerated); err != nil {
+			return nil, err
+		}
+		if m.sequenceOverflows, err = register(registry, m.sequenceOverflows); err != nil {
+			return nil, err
+		}
+		if m.sequenceFallbacks, err = register(registry, m.sequenceFallbacks); err != nil {
+			return nil, err
+		}
+		if m.clockSkewEvents, err = register(registry, m.clockSkewEvents); err != nil {
+			return nil, err
+		}
+		if m.currentSequence, err = register(registry, m.currentSequence); err != nil {
+			return nil, err
+		}
+		return idgenerator.WithMetrics(m), nil
+	})
+}
+
+// register registers c to registry, or returns the already registered collector.
+func register[T prometheus.Collector](registry prometheus.Registerer, c T) (T, error) {
+	err := registry.Register(c)
+	if err == nil {
+		return c, nil
+	}
+	var are prometheus.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		if existing, ok := are.ExistingCollector.(T); ok {
+			return existing, nil
+		}
+	}
+	return c, err
+}
+
+// node returns the metrics of the datacenter ID and the machine ID of g.
+// The metrics are observed while g holds its lock, so the labels and the labeled metrics are built only once.
+func (m *prometheusMetrics) node(g *idgenerator.Generator) *nodeMetrics {
+	k := node{g.DatacenterID(), g.MachineID()}
+	if n, ok := m.nodes.Load(k); ok {
+		return n.(*nodeMetrics)
+	}
+	l := prometheus.Labels{
+		"datacenter_id": strconv.Itoa(k.datacenterID),
+		"machine_id":    strconv.Itoa(k.machineID),
+	}
+	n, _ := m.nodes.LoadOrStore(k, &nodeMetrics{
+		labels:          l,
+		idsGenerated:    m.idsGenerated.With(l),
+		currentSequence: m.currentSequence.With(l),
+	})
+	return n.(*nodeMetrics)
+}
+
+func (m *prometheusMetrics) ObserveID(g *idgenerator.Generator, id idgenerator.SnowflakeID) {
+	n := m.node(g)
+	n.idsGenerated.Inc()
+	n.currentSequence.Set(float64(g.ExtractSequenceNumber(id)))
+}
+
+func (m *prometheusMetrics) ObserveSequenceOverflow(g *idgenerator.Generator) {
+	m.sequenceOverflows.With(m.node(g).labels).Inc()
+}
+
+func (m *prometheusMetrics) ObserveSequenceFallback(g *idgenerator.Generator) {
+	m.sequenceFallbacks.With(m.node(g).labels).Inc()
+}
+
+func (m *prometheusMetrics) ObserveClockSkew(g *idgenerator.Generator) {
+	m.clockSkewEvents.With(m.node(g).labels).Inc()
+}
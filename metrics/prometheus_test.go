@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWithPrometheusMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := idgenerator.NewGenerator(
+		idgenerator.WithClock(c),
+		idgenerator.WithDatacenterID(31),
+		idgenerator.WithMachineID(15),
+		idgenerator.WithSequenceExhaustionPolicy(idgenerator.ReturnError),
+		idgenerator.WithClockSkewTolerance(time.Millisecond),
+		WithPrometheusMetrics(registry, "test"),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	// A second Generator shares the registered metrics.
+	g2, err := idgenerator.NewGenerator(
+		idgenerator.WithClock(c),
+		idgenerator.WithDatacenterID(1),
+		idgenerator.WithMachineID(2),
+		WithPrometheusMetrics(registry, "test"),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	if _, err := g.NextN(4096); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if _, err := g.Next(); err != idgenerator.ErrSequenceExhausted {
+		t.Fatalf("Next() error = %v, want %v", err, idgenerator.ErrSequenceExhausted)
+	}
+	c.Advance(time.Millisecond)
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	c.Advance(-time.Millisecond)
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if _, err := g2.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+
+	want := `
+# HELP test_clock_skew_events_total Total number of times the clock moved backward.
+# TYPE test_clock_skew_events_total counter
+test_clock_skew_events_total{datacenter_id="31",machine_id="15"} 1
+# HELP test_current_sequence Sequence number of the last generated ID.
+# TYPE test_current_sequence gauge
+test_current_sequence{datacenter_id="1",machine_id="2"} 0
+test_current_sequence{datacenter_id="31",machine_id="15"} 1
+# HELP test_ids_generated_total Total number of generated IDs.
+# TYPE test_ids_generated_total counter
+test_ids_generated_total{datacenter_id="1",machine_id="2"} 1
+test_ids_generated_total{datacenter_id="31",machine_id="15"} 4098
+# HELP test_sequence_overflows_total Total number of times the sequence number of a millisecond was exhausted.
+# TYPE test_sequence_overflows_total counter
+test_sequence_overflows_total{datacenter_id="31",machine_id="15"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithPrometheusMetrics_SequenceFallback(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := idgenerator.NewGenerator(
+		idgenerator.WithClock(c),
+		idgenerator.WithDatacenterID(31),
+		idgenerator.WithMachineID(15),
+		idgenerator.WithCryptoRandomFallback(),
+		WithPrometheusMetrics(registry, "test"),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if _, err := g.NextN(4096 + 2); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+
+	want := `
+# HELP test_sequence_fallback_total Total number of IDs generated with a random sequence number after the sequence number was exhausted.
+# TYPE test_sequence_fallback_total counter
+test_sequence_fallback_total{datacenter_id="31",machine_id="15"} 2
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(want), "test_sequence_fallback_total"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithPrometheusMetrics_LayoutVersion(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := idgenerator.NewGenerator(
+		idgenerator.WithClock(c),
+		idgenerator.WithLayoutVersion(0),
+		idgenerator.WithDatacenterID(31),
+		idgenerator.WithMachineID(15),
+		WithPrometheusMetrics(registry, "test"),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if _, err := g.NextN(3); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+
+	// The sequence number excludes the version bits.
+	want := `
+# HELP test_current_sequence Sequence number of the last generated ID.
+# TYPE test_current_sequence gauge
+test_current_sequence{datacenter_id="31",machine_id="15"} 2
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(want), "test_current_sequence"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithPrometheusMetrics_Obfuscation(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := idgenerator.NewGenerator(
+		idgenerator.WithClock(c),
+		idgenerator.WithDatacenterID(31),
+		idgenerator.WithMachineID(15),
+		idgenerator.WithObfuscation(42),
+		WithPrometheusMetrics(registry, "test"),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if _, err := g.NextN(3); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+
+	// The sequence number is read from the obfuscated ID.
+	want := `
+# HELP test_current_sequence Sequence number of the last generated ID.
+# TYPE test_current_sequence gauge
+test_current_sequence{datacenter_id="31",machine_id="15"} 2
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(want), "test_current_sequence"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithPrometheusMetrics_Error(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	// A collector with the same name but different labels conflicts.
+	registry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Namespace: "test", Name: "ids_generated_total", Help: "conflict"}))
+	if _, err := idgenerator.NewGenerator(WithPrometheusMetrics(registry, "test")); err == nil {
+		t.Error("NewGenerator() error = nil, want error")
+	}
+}
@@ -0,0 +1,69 @@
+package idgenerator
+
+import (
+	"testing"
+	"time"
+)
+
+type countMetrics struct {
+	ids               int
+	sequenceOverflows int
+	sequenceFallbacks int
+	clockSkews        int
+	lastSequence      int
+}
+
+func (m *countMetrics) ObserveID(_ *Generator, id SnowflakeID) {
+	m.ids++
+	m.lastSequence = ExtractSequenceNumber(int64(id))
+}
+
+func (m *countMetrics) ObserveSequenceOverflow(*Generator) {
+	m.sequenceOverflows++
+}
+
+func (m *countMetrics) ObserveSequenceFallback(*Generator) {
+	m.sequenceFallbacks++
+}
+
+func (m *countMetrics) ObserveClockSkew(*Generator) {
+	m.clockSkews++
+}
+
+func TestWithMetrics(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	m := &countMetrics{}
+	g, err := NewGenerator(
+		WithClock(c),
+		WithMetrics(m),
+		WithSequenceExhaustionPolicy(ReturnError),
+		WithClockSkewTolerance(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	if _, err := g.NextN(maxSequenceNumber + 1); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if _, err := g.Next(); err != ErrSequenceExhausted {
+		t.Fatalf("Next() error = %v, want %v", err, ErrSequenceExhausted)
+	}
+	c.Advance(time.Millisecond)
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	c.Advance(-time.Millisecond)
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+
+	want := countMetrics{ids: maxSequenceNumber + 3, sequenceOverflows: 1, clockSkews: 1, lastSequence: 1}
+	if *m != want {
+		t.Errorf("metrics = %+v, want %+v", *m, want)
+	}
+
+	if _, err := NewGenerator(WithMetrics(nil)); err != ErrInvalidMetrics {
+		t.Errorf("NewGenerator() error = %v, want %v", err, ErrInvalidMetrics)
+	}
+}
@@ -0,0 +1,13 @@
+package middleware
+
+import (
+	"net/http"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// ChiMiddleware returns HTTPMiddleware as chi middleware.
+// Chi uses net/http middleware as it is, so it is the same as HTTPMiddleware.
+func ChiMiddleware(g *idgenerator.Generator, headerName string) func(http.Handler) http.Handler {
+	return HTTPMiddleware(g, headerName)
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestChiMiddleware(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(ChiMiddleware(newGenerator(t), "X-Request-ID"))
+	r.Get("/", echoRequestID)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	checkResponse(t, rec, "X-Request-ID")
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"github.com/labstack/echo/v4"
+)
+
+// EchoMiddleware returns HTTPMiddleware as Echo middleware.
+// The ID is stored in the context of c.Request().
+func EchoMiddleware(g *idgenerator.Generator, headerName string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx, ok := withRequestID(c.Response(), c.Request(), g, headerName)
+			if !ok {
+				return nil
+			}
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
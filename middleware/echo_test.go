@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestEchoMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		exhausted  bool
+		wantStatus int
+	}{
+		{"OK", false, http.StatusOK},
+		{"Error", true, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGenerator(t)
+			if tt.exhausted {
+				g = exhaustedGenerator(t)
+			}
+			e := echo.New()
+			e.Use(EchoMiddleware(g, "X-Request-ID"))
+			e.GET("/", func(c echo.Context) error {
+				echoRequestID(c.Response(), c.Request())
+				return nil
+			})
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK {
+				checkResponse(t, rec, "X-Request-ID")
+			}
+		})
+	}
+}
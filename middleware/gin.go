@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// GinMiddleware returns HTTPMiddleware as Gin middleware.
+// The ID is stored in the context of c.Request.
+func GinMiddleware(g *idgenerator.Generator, headerName string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, ok := withRequestID(c.Writer, c.Request, g, headerName)
+		if !ok {
+			c.Abort()
+			return
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
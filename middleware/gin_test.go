@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		exhausted  bool
+		wantStatus int
+	}{
+		{"OK", false, http.StatusOK},
+		{"Error", true, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGenerator(t)
+			if tt.exhausted {
+				g = exhaustedGenerator(t)
+			}
+			r := gin.New()
+			r.Use(GinMiddleware(g, "X-Request-ID"))
+			r.GET("/", func(c *gin.Context) { echoRequestID(c.Writer, c.Request) })
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK {
+				checkResponse(t, rec, "X-Request-ID")
+			}
+		})
+	}
+}
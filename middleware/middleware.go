@@ -0,0 +1,52 @@
+// Package middleware assigns a Snowflake ID to each HTTP request, e.g., to correlate the logs of a request.
+//
+// HTTPMiddleware works with net/http and routers that accept func(http.Handler) http.Handler.
+// GinMiddleware, EchoMiddleware, and ChiMiddleware adapt it to the frameworks.
+//
+// It is a separate module, so that the idgenerator package does not depend on the frameworks.
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+type requestIDKey struct{}
+
+// HTTPMiddleware returns a middleware that generates an ID with g for each request,
+// and stores it in the request context, which RequestIDFromContext retrieves.
+// If headerName is not empty, e.g., "X-Request-ID", the ID is also set to the response header.
+// If g fails to generate an ID, the middleware responds with 500 Internal Server Error.
+func HTTPMiddleware(g *idgenerator.Generator, headerName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, ok := withRequestID(w, r, g, headerName)
+			if !ok {
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// withRequestID generates an ID for r, sets it to the response header, and returns the context with it.
+// It responds with an error and returns false if g fails to generate an ID.
+func withRequestID(w http.ResponseWriter, r *http.Request, g *idgenerator.Generator, headerName string) (context.Context, bool) {
+	id, err := g.NextCtx(r.Context())
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return nil, false
+	}
+	if headerName != "" {
+		w.Header().Set(headerName, id.String())
+	}
+	return context.WithValue(r.Context(), requestIDKey{}, id), true
+}
+
+// RequestIDFromContext returns the ID of the request stored by the middleware.
+func RequestIDFromContext(ctx context.Context) (idgenerator.SnowflakeID, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(idgenerator.SnowflakeID)
+	return id, ok
+}
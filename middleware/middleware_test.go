@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+const wantID = idgenerator.SnowflakeID(11234023837724672)
+
+func newGenerator(t *testing.T, opts ...idgenerator.Option) *idgenerator.Generator {
+	t.Helper()
+	c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := idgenerator.NewGenerator(append([]idgenerator.Option{idgenerator.WithClock(c), idgenerator.WithDatacenterID(31), idgenerator.WithMachineID(15)}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	return g
+}
+
+// exhaustedGenerator returns a Generator that fails to generate the next ID.
+func exhaustedGenerator(t *testing.T) *idgenerator.Generator {
+	t.Helper()
+	g := newGenerator(t, idgenerator.WithSequenceExhaustionPolicy(idgenerator.ReturnError))
+	if _, err := g.NextN(4096); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	return g
+}
+
+// echoRequestID writes the request ID in the context, or 404 if there is none.
+func echoRequestID(w http.ResponseWriter, r *http.Request) {
+	id, ok := RequestIDFromContext(r.Context())
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Write([]byte(id.String()))
+}
+
+// checkResponse checks that the response has the request ID in the body and in the header.
+func checkResponse(t *testing.T, rec *httptest.ResponseRecorder, headerName string) {
+	t.Helper()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %v, want %v", rec.Code, http.StatusOK)
+	}
+	if got := rec.Body.String(); got != wantID.String() {
+		t.Errorf("request ID = %v, want %v", got, wantID)
+	}
+	if headerName == "" {
+		return
+	}
+	if got := rec.Header().Get(headerName); got != wantID.String() {
+		t.Errorf("%s = %v, want %v", headerName, got, wantID)
+	}
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	for _, headerName := range []string{"X-Request-ID", ""} {
+		t.Run(headerName, func(t *testing.T) {
+			h := HTTPMiddleware(newGenerator(t), headerName)(http.HandlerFunc(echoRequestID))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			checkResponse(t, rec, headerName)
+			if headerName == "" && len(rec.Header().Values("X-Request-ID")) != 0 {
+				t.Errorf("X-Request-ID is set without the header name")
+			}
+		})
+	}
+}
+
+func TestHTTPMiddleware_Error(t *testing.T) {
+	h := HTTPMiddleware(exhaustedGenerator(t), "X-Request-ID")(http.HandlerFunc(echoRequestID))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %v, want %v", rec.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	if _, ok := RequestIDFromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()); ok {
+		t.Error("RequestIDFromContext() ok = true without the middleware, want false")
+	}
+}
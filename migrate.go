@@ -0,0 +1,45 @@
+package idgenerator
+
+import (
+	"fmt"
+	"time"
+)
+
+// MigrateID converts an ID in LayoutTwitterSnowflake generated with oldBase into the ID generated at the same time with newBase,
+// keeping the datacenter ID, the machine ID, and the sequence number. If a base time is zero, the default base time is used.
+//
+// It returns ErrInvalidID if the ID is invalid, and ErrInvalidTimestamp or ErrOverLifeTime
+// if the time of the ID is not after newBase or is beyond the lifetime from newBase.
+func MigrateID(id SnowflakeID, oldBase, newBase time.Time) (SnowflakeID, error) {
+	c, err := ParseSnowflakeID(int64(id), oldBase, LayoutTwitterSnowflake)
+	if err != nil {
+		return 0, err
+	}
+	if newBase.IsZero() {
+		newBase = defaultBaseTime
+	}
+	ts, err := elapsedTimestamp(c.Timestamp, newBase, LayoutTwitterSnowflake)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %d at %v is out of range from the new base time %v", err, id, c.Timestamp, newBase)
+	}
+	return SnowflakeID(LayoutTwitterSnowflake.compose(ts, c.DatacenterID, c.MachineID, c.SequenceNumber)), nil
+}
+
+// MigrateIDs converts ids as MigrateID does. The returned IDs are in the same order as ids.
+// If any ID fails, errs has the error of each ID at the same index, or nil for the migrated ones,
+// and the failed IDs are zero. Otherwise, errs is nil.
+func MigrateIDs(ids []SnowflakeID, oldBase, newBase time.Time) (migrated []SnowflakeID, errs []error) {
+	migrated = make([]SnowflakeID, len(ids))
+	for i, id := range ids {
+		m, err := MigrateID(id, oldBase, newBase)
+		if err != nil {
+			if errs == nil {
+				errs = make([]error, len(ids))
+			}
+			errs[i] = err
+			continue
+		}
+		migrated[i] = m
+	}
+	return migrated, errs
+}
@@ -0,0 +1,95 @@
+package idgenerator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMigrateID(t *testing.T) {
+	type args struct {
+		id      SnowflakeID
+		oldBase time.Time
+		newBase time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    SnowflakeID
+		wantErr error
+	}{
+		{
+			"Earlier base time",
+			args{11234023837724673, time.Time{}, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+			143505594781724673,
+			nil,
+		},
+		{
+			"Back to the default base time",
+			args{143505594781724673, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+			11234023837724673,
+			nil,
+		},
+		{
+			"Same base time",
+			args{11234023837724673, time.Time{}, defaultBaseTime},
+			11234023837724673,
+			nil,
+		},
+		{
+			"Error negative ID",
+			args{-1, time.Time{}, time.Time{}},
+			0,
+			ErrInvalidID,
+		},
+		{
+			"Error new base time after the ID",
+			args{11234023837724673, time.Time{}, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+			0,
+			ErrInvalidTimestamp,
+		},
+		{
+			"Error over the lifetime",
+			args{11234023837724673, time.Time{}, time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)},
+			0,
+			ErrOverLifeTime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MigrateID(tt.args.id, tt.args.oldBase, tt.args.newBase)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MigrateID() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MigrateID() = %v, want %v", got, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := ExtractTime(int64(got), tt.args.newBase), ExtractTime(int64(tt.args.id), tt.args.oldBase); !got.Equal(want) {
+				t.Errorf("ExtractTime() of the migrated ID = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMigrateIDs(t *testing.T) {
+	newBase := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	got, errs := MigrateIDs([]SnowflakeID{11234023837724673, 11234023837724673}, time.Time{}, newBase)
+	if errs != nil {
+		t.Fatalf("MigrateIDs() errs = %v, want nil", errs)
+	}
+	if want := []SnowflakeID{143505594781724673, 143505594781724673}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MigrateIDs() = %v, want %v", got, want)
+	}
+
+	got, errs = MigrateIDs([]SnowflakeID{-1, 11234023837724673}, time.Time{}, newBase)
+	if len(errs) != 2 || !errors.Is(errs[0], ErrInvalidID) || errs[1] != nil {
+		t.Errorf("MigrateIDs() errs = %v, want [%v <nil>]", errs, ErrInvalidID)
+	}
+	if want := []SnowflakeID{0, 143505594781724673}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MigrateIDs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package idgenerator
+
+import "sync"
+
+// MockGenerator is an IDGenerator for tests, which returns pre-configured IDs without a clock.
+// A MockGenerator is safe for concurrent use by multiple goroutines.
+type MockGenerator struct {
+	ids        []SnowflakeID
+	sequential bool
+
+	mutex sync.Mutex
+}
+
+var _ IDGenerator = (*MockGenerator)(nil)
+
+// NewMockGenerator returns a MockGenerator that returns ids in order.
+// When all ids have been returned, it returns ErrMockExhausted.
+func NewMockGenerator(ids ...SnowflakeID) *MockGenerator {
+	return &MockGenerator{ids: append([]SnowflakeID(nil), ids...)}
+}
+
+// SequentialMockGenerator returns a MockGenerator that returns start, start+1, start+2, and so on.
+func SequentialMockGenerator(start SnowflakeID) *MockGenerator {
+	return &MockGenerator{ids: []SnowflakeID{start}, sequential: true}
+}
+
+// Next returns the next pre-configured ID.
+func (m *MockGenerator) Next() (SnowflakeID, error) {
+	ids, err := m.NextN(1)
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// NextN returns the next n pre-configured IDs.
+// If fewer than n IDs remain, it returns ErrMockExhausted without consuming any IDs.
+func (m *MockGenerator) NextN(n int) ([]SnowflakeID, error) {
+	if n <= 0 {
+		return nil, ErrInvalidCount
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.sequential {
+		ids := make([]SnowflakeID, n)
+		for i := range ids {
+			ids[i] = m.ids[0] + SnowflakeID(i)
+		}
+		m.ids[0] += SnowflakeID(n)
+		return ids, nil
+	}
+
+	if len(m.ids) < n {
+		return nil, ErrMockExhausted
+	}
+	ids := append([]SnowflakeID(nil), m.ids[:n]...)
+	m.ids = m.ids[n:]
+	return ids, nil
+}
@@ -0,0 +1,66 @@
+package idgenerator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMockGenerator_Next(t *testing.T) {
+	m := NewMockGenerator(3, 1, 2)
+	for _, want := range []SnowflakeID{3, 1, 2} {
+		got, err := m.Next()
+		if err != nil {
+			t.Fatalf("MockGenerator.Next() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("MockGenerator.Next() = %v, want %v", got, want)
+		}
+	}
+	if _, err := m.Next(); err != ErrMockExhausted {
+		t.Errorf("MockGenerator.Next() error = %v, want %v", err, ErrMockExhausted)
+	}
+}
+
+func TestMockGenerator_NextN(t *testing.T) {
+	type args struct {
+		n int
+	}
+	tests := []struct {
+		name    string
+		m       *MockGenerator
+		args    args
+		want    []SnowflakeID
+		wantErr bool
+	}{
+		{"Pre-configured IDs", NewMockGenerator(3, 1, 2), args{2}, []SnowflakeID{3, 1}, false},
+		{"Sequential IDs", SequentialMockGenerator(100), args{3}, []SnowflakeID{100, 101, 102}, false},
+		{"Error exhausted", NewMockGenerator(3, 1, 2), args{4}, nil, true},
+		{"Error invalid count", SequentialMockGenerator(100), args{0}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.NextN(tt.args.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MockGenerator.NextN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MockGenerator.NextN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSequentialMockGenerator(t *testing.T) {
+	var g IDGenerator = SequentialMockGenerator(100)
+	if _, err := g.NextN(2); err != nil {
+		t.Fatalf("MockGenerator.NextN() error = %v", err)
+	}
+	got, err := g.Next()
+	if err != nil {
+		t.Fatalf("MockGenerator.Next() error = %v", err)
+	}
+	if got != 102 {
+		t.Errorf("MockGenerator.Next() = %v, want %v", got, 102)
+	}
+}
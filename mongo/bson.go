@@ -0,0 +1,121 @@
+// Package mongo stores Snowflake IDs as BSON Int64 values with the MongoDB Go driver.
+//
+// Use ID instead of idgenerator.SnowflakeID in the fields of documents,
+// or Register the codec of idgenerator.SnowflakeID to the registry of the client:
+//
+//	registry := bson.NewRegistry()
+//	idgeneratormongo.Register(registry)
+//	client, err := mongo.Connect(options.Client().ApplyURI(uri).SetRegistry(registry))
+//
+// Both decode BSON String values as well, for documents written by clients that store IDs as decimal strings.
+//
+// It is a separate module, so that the idgenerator package does not depend on the MongoDB Go driver.
+package mongo
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/x/bsonx/bsoncore"
+)
+
+// ID is a Snowflake ID stored as a BSON Int64 value.
+type ID idgenerator.SnowflakeID
+
+var (
+	_ bson.ValueMarshaler   = ID(0)
+	_ bson.ValueUnmarshaler = (*ID)(nil)
+)
+
+// SnowflakeID returns the ID as idgenerator.SnowflakeID.
+func (id ID) SnowflakeID() idgenerator.SnowflakeID {
+	return idgenerator.SnowflakeID(id)
+}
+
+// MarshalBSONValue encodes the ID as a BSON Int64 value.
+func (id ID) MarshalBSONValue() (byte, []byte, error) {
+	return byte(bson.TypeInt64), bsoncore.AppendInt64(nil, int64(id)), nil
+}
+
+// UnmarshalBSONValue decodes a BSON Int64 value, or a BSON String value of a decimal ID.
+func (id *ID) UnmarshalBSONValue(typ byte, data []byte) error {
+	v, err := decodeValue(bson.Type(typ), data)
+	if err != nil {
+		return err
+	}
+	*id = ID(v)
+	return nil
+}
+
+func decodeValue(typ bson.Type, data []byte) (int64, error) {
+	switch typ {
+	case bson.TypeInt64:
+		v, _, ok := bsoncore.ReadInt64(data)
+		if !ok {
+			return 0, fmt.Errorf("%w: insufficient bytes for BSON Int64", idgenerator.ErrInvalidID)
+		}
+		return v, nil
+	case bson.TypeString:
+		s, _, ok := bsoncore.ReadString(data)
+		if !ok {
+			return 0, fmt.Errorf("%w: insufficient bytes for BSON String", idgenerator.ErrInvalidID)
+		}
+		return parseString(s)
+	default:
+		return 0, fmt.Errorf("%w: cannot decode BSON %v", idgenerator.ErrInvalidID, typ)
+	}
+}
+
+func parseString(s string) (int64, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", idgenerator.ErrInvalidID, err)
+	}
+	return v, nil
+}
+
+var snowflakeIDType = reflect.TypeOf(idgenerator.SnowflakeID(0))
+
+// Register registers the encoder and the decoder of idgenerator.SnowflakeID to r,
+// which behave the same as the methods of ID.
+func Register(r *bson.Registry) {
+	r.RegisterTypeEncoder(snowflakeIDType, bson.ValueEncoderFunc(encodeSnowflakeID))
+	r.RegisterTypeDecoder(snowflakeIDType, bson.ValueDecoderFunc(decodeSnowflakeID))
+}
+
+func encodeSnowflakeID(_ bson.EncodeContext, vw bson.ValueWriter, val reflect.Value) error {
+	if val.Type() != snowflakeIDType {
+		return bson.ValueEncoderError{Name: "SnowflakeIDEncodeValue", Types: []reflect.Type{snowflakeIDType}, Received: val}
+	}
+	return vw.WriteInt64(val.Int())
+}
+
+func decodeSnowflakeID(_ bson.DecodeContext, vr bson.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != snowflakeIDType {
+		return bson.ValueDecoderError{Name: "SnowflakeIDDecodeValue", Types: []reflect.Type{snowflakeIDType}, Received: val}
+	}
+	var v int64
+	switch vr.Type() {
+	case bson.TypeInt64:
+		i, err := vr.ReadInt64()
+		if err != nil {
+			return err
+		}
+		v = i
+	case bson.TypeString:
+		s, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+		if v, err = parseString(s); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: cannot decode BSON %v", idgenerator.ErrInvalidID, vr.Type())
+	}
+	val.SetInt(v)
+	return nil
+}
@@ -0,0 +1,106 @@
+package mongo
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type document struct {
+	ID   ID     `bson:"_id"`
+	Name string `bson:"name"`
+}
+
+func TestID_RoundTrip(t *testing.T) {
+	want := document{ID: 11234023837724673, Name: "alice"}
+	b, err := bson.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if typ := bson.Raw(b).Lookup("_id").Type; typ != bson.TypeInt64 {
+		t.Errorf("Marshal() _id type = %v, want %v", typ, bson.TypeInt64)
+	}
+
+	var got document
+	if err := bson.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Unmarshal() = %v, want %v", got, want)
+	}
+	if got.ID.SnowflakeID() != 11234023837724673 {
+		t.Errorf("SnowflakeID() = %v, want %v", got.ID.SnowflakeID(), 11234023837724673)
+	}
+}
+
+func TestID_UnmarshalBSONValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     bson.D
+		want    ID
+		wantErr error
+	}{
+		{"Int64", bson.D{{Key: "_id", Value: int64(11234023837724673)}}, 11234023837724673, nil},
+		{"String", bson.D{{Key: "_id", Value: "11234023837724673"}}, 11234023837724673, nil},
+		{"Error invalid string", bson.D{{Key: "_id", Value: "abc"}}, 0, idgenerator.ErrInvalidID},
+		{"Error double", bson.D{{Key: "_id", Value: 1.5}}, 0, idgenerator.ErrInvalidID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := bson.Marshal(tt.doc)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var got document
+			err = bson.Unmarshal(b, &got)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Unmarshal() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.ID != tt.want {
+				t.Errorf("Unmarshal() = %v, want %v", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	type snowflakeDocument struct {
+		ID idgenerator.SnowflakeID `bson:"_id"`
+	}
+	r := bson.NewRegistry()
+	Register(r)
+
+	var buf bytes.Buffer
+	enc := bson.NewEncoder(bson.NewDocumentWriter(&buf))
+	enc.SetRegistry(r)
+	if err := enc.Encode(snowflakeDocument{ID: 11234023837724673}); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if typ := bson.Raw(buf.Bytes()).Lookup("_id").Type; typ != bson.TypeInt64 {
+		t.Errorf("Encode() _id type = %v, want %v", typ, bson.TypeInt64)
+	}
+
+	for _, b := range [][]byte{buf.Bytes(), mustMarshal(t, bson.D{{Key: "_id", Value: "11234023837724673"}})} {
+		dec := bson.NewDecoder(bson.NewDocumentReader(bytes.NewReader(b)))
+		dec.SetRegistry(r)
+		var got snowflakeDocument
+		if err := dec.Decode(&got); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if got.ID != 11234023837724673 {
+			t.Errorf("Decode() = %v, want %v", got.ID, 11234023837724673)
+		}
+	}
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := bson.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return b
+}
@@ -0,0 +1,9 @@
+module github.com/kawabatas/go-id-generator/mongo
+
+go 1.25.0
+
+require github.com/kawabatas/go-id-generator v0.0.0-00010101000000-000000000000
+
+require go.mongodb.org/mongo-driver/v2 v2.9.1
+
+replace github.com/kawabatas/go-id-generator => ../
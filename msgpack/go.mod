@@ -0,0 +1,12 @@
+module github.com/kawabatas/go-id-generator/msgpack
+
+go 1.22.0
+
+require (
+	github.com/kawabatas/go-id-generator v0.0.0-00010101000000-000000000000
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+
+replace github.com/kawabatas/go-id-generator => ../
@@ -0,0 +1,59 @@
+// Package msgpack encodes Snowflake IDs as MessagePack int64 values with vmihailenco/msgpack.
+//
+// Use ID instead of idgenerator.SnowflakeID in the fields of messages, or call Register once
+// to encode idgenerator.SnowflakeID in the same way.
+//
+// It is a separate module, so that the idgenerator package does not depend on the MessagePack library.
+package msgpack
+
+import (
+	"reflect"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ID is a Snowflake ID encoded as a MessagePack int64 value.
+type ID idgenerator.SnowflakeID
+
+var (
+	_ msgpack.CustomEncoder = ID(0)
+	_ msgpack.CustomDecoder = (*ID)(nil)
+)
+
+// SnowflakeID returns the ID as idgenerator.SnowflakeID.
+func (id ID) SnowflakeID() idgenerator.SnowflakeID {
+	return idgenerator.SnowflakeID(id)
+}
+
+// EncodeMsgpack encodes the ID as a MessagePack int64 value.
+func (id ID) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeInt64(int64(id))
+}
+
+// DecodeMsgpack decodes a MessagePack integer value.
+func (id *ID) DecodeMsgpack(dec *msgpack.Decoder) error {
+	v, err := dec.DecodeInt64()
+	if err != nil {
+		return err
+	}
+	*id = ID(v)
+	return nil
+}
+
+// Register makes vmihailenco/msgpack encode and decode idgenerator.SnowflakeID in the same way as ID.
+func Register() {
+	msgpack.Register(idgenerator.SnowflakeID(0),
+		func(enc *msgpack.Encoder, v reflect.Value) error {
+			return enc.EncodeInt64(v.Int())
+		},
+		func(dec *msgpack.Decoder, v reflect.Value) error {
+			i, err := dec.DecodeInt64()
+			if err != nil {
+				return err
+			}
+			v.SetInt(i)
+			return nil
+		},
+	)
+}
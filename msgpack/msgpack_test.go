@@ -0,0 +1,97 @@
+package msgpack
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestID_RoundTrip(t *testing.T) {
+	type message struct {
+		ID   ID     `msgpack:"id"`
+		Name string `msgpack:"name"`
+	}
+	want := message{ID: 11234023837724673, Name: "alice"}
+	b, err := msgpack.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got message
+	if err := msgpack.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Unmarshal() = %v, want %v", got, want)
+	}
+	if got.ID.SnowflakeID() != 11234023837724673 {
+		t.Errorf("SnowflakeID() = %v, want %v", got.ID.SnowflakeID(), 11234023837724673)
+	}
+}
+
+func TestID_EncodeMsgpack(t *testing.T) {
+	id := ID(11234023837724673)
+	b, err := msgpack.Marshal(id)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	// 0xd3 is the int64 format of MessagePack.
+	if want := []byte{0xd3, 0x00, 0x27, 0xe9, 0x49, 0x00, 0x3e, 0xf0, 0x01}; !bytes.Equal(b, want) {
+		t.Errorf("Marshal() = %x, want %x", b, want)
+	}
+
+	j, err := json.Marshal(id.SnowflakeID())
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if len(b) >= len(j) {
+		t.Errorf("Marshal() = %d bytes, want fewer than %d bytes of JSON", len(b), len(j))
+	}
+}
+
+func TestID_DecodeMsgpack(t *testing.T) {
+	// Integers in any format are decoded, e.g., ones encoded compactly by other clients.
+	for _, v := range []any{uint64(11234023837724673), int64(11234023837724673)} {
+		b, err := msgpack.Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		var got ID
+		if err := msgpack.Unmarshal(b, &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if got != 11234023837724673 {
+			t.Errorf("Unmarshal() = %v, want %v", got, 11234023837724673)
+		}
+	}
+
+	b, err := msgpack.Marshal("abc")
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got ID
+	if err := msgpack.Unmarshal(b, &got); err == nil {
+		t.Error("Unmarshal() error = nil, want error")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register()
+	id := idgenerator.SnowflakeID(11234023837724673)
+	b, err := msgpack.Marshal(id)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if b[0] != 0xd3 {
+		t.Errorf("Marshal() format = %x, want %x", b[0], 0xd3)
+	}
+	var got idgenerator.SnowflakeID
+	if err := msgpack.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got != id {
+		t.Errorf("Unmarshal() = %v, want %v", got, id)
+	}
+}
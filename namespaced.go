@@ -0,0 +1,104 @@
+package idgenerator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// namespaceSeparator separates the prefix and the base-62 ID of a namespaced ID.
+const namespaceSeparator = "_"
+
+// NamespacedGenerator generates string IDs prefixed with the entity type, such as "usr_00pS1Hwq1mz".
+// The prefix makes the type of an ID obvious in logs and API responses.
+type NamespacedGenerator struct {
+	prefix string
+	g      *Generator
+}
+
+// NewNamespacedGenerator returns a new NamespacedGenerator generating IDs with g, prefixed with prefix.
+// The prefix must consist of lowercase ASCII letters and digits.
+func NewNamespacedGenerator(prefix string, g *Generator) (*NamespacedGenerator, error) {
+	if err := validatePrefix(prefix); err != nil {
+		return nil, err
+	}
+	return &NamespacedGenerator{prefix: prefix, g: g}, nil
+}
+
+// Prefix returns the prefix of the generated IDs.
+func (ng *NamespacedGenerator) Prefix() string {
+	return ng.prefix
+}
+
+// Next returns a new generated ID in the form of "<prefix>_<base62(id)>".
+func (ng *NamespacedGenerator) Next() (string, error) {
+	id, err := ng.g.Next()
+	if err != nil {
+		return "", err
+	}
+	return ng.prefix + namespaceSeparator + id.Base62(), nil
+}
+
+// ParseNamespacedID decodes a string generated by NamespacedGenerator.
+// It returns ErrPrefixMismatch if the prefix is not expectedPrefix.
+func ParseNamespacedID(s, expectedPrefix string) (SnowflakeID, error) {
+	prefix, body, ok := strings.Cut(s, namespaceSeparator)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q has no prefix", ErrInvalidID, s)
+	}
+	if prefix != expectedPrefix {
+		return 0, fmt.Errorf("%w: %q, want %q", ErrPrefixMismatch, prefix, expectedPrefix)
+	}
+	return ParseBase62(body)
+}
+
+func validatePrefix(prefix string) error {
+	if prefix == "" {
+		return fmt.Errorf("%w: empty", ErrInvalidPrefix)
+	}
+	for i := 0; i < len(prefix); i++ {
+		if c := prefix[i]; !('a' <= c && c <= 'z' || '0' <= c && c <= '9') {
+			return fmt.Errorf("%w: %q contains invalid character %q", ErrInvalidPrefix, prefix, c)
+		}
+	}
+	return nil
+}
+
+// Registry holds NamespacedGenerators by prefix, so that multiple entity types share a process.
+// The zero value is an empty Registry ready to use. A Registry is safe for concurrent use by multiple goroutines.
+type Registry struct {
+	generators map[string]*NamespacedGenerator
+	mutex      sync.RWMutex
+}
+
+// Register registers g to generate IDs prefixed with prefix.
+// It returns ErrInvalidPrefix if prefix is invalid or already registered.
+func (r *Registry) Register(prefix string, g *Generator) error {
+	ng, err := NewNamespacedGenerator(prefix, g)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.generators[prefix]; ok {
+		return fmt.Errorf("%w: %q is already registered", ErrInvalidPrefix, prefix)
+	}
+	if r.generators == nil {
+		r.generators = make(map[string]*NamespacedGenerator)
+	}
+	r.generators[prefix] = ng
+	return nil
+}
+
+// Generate returns a new generated ID prefixed with prefix, using the Generator registered for prefix.
+// It returns ErrPrefixNotRegistered if no Generator is registered for prefix.
+func (r *Registry) Generate(prefix string) (string, error) {
+	r.mutex.RLock()
+	ng, ok := r.generators[prefix]
+	r.mutex.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrPrefixNotRegistered, prefix)
+	}
+	return ng.Next()
+}
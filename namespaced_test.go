@@ -0,0 +1,114 @@
+package idgenerator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newNamespacedTestGenerator(t *testing.T) *Generator {
+	t.Helper()
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15), WithSequenceNumber(1))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	return g
+}
+
+func TestNewNamespacedGenerator(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		wantErr error
+	}{
+		{"Valid", "usr", nil},
+		{"Valid with digits", "v2ord", nil},
+		{"Error empty", "", ErrInvalidPrefix},
+		{"Error separator", "usr_x", ErrInvalidPrefix},
+		{"Error uppercase", "USR", ErrInvalidPrefix},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNamespacedGenerator(tt.prefix, &Generator{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewNamespacedGenerator() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNamespacedGenerator_Next(t *testing.T) {
+	ng, err := NewNamespacedGenerator("usr", newNamespacedTestGenerator(t))
+	if err != nil {
+		t.Fatalf("NewNamespacedGenerator() error = %v", err)
+	}
+	got, err := ng.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if want := "usr_00pS1Hwq1mz"; got != want {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestParseNamespacedID(t *testing.T) {
+	type args struct {
+		s              string
+		expectedPrefix string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    SnowflakeID
+		wantErr error
+	}{
+		{"Valid", args{"usr_00pS1Hwq1mz", "usr"}, 11234023837724673, nil},
+		{"Error prefix mismatch", args{"ord_00pS1Hwq1mz", "usr"}, 0, ErrPrefixMismatch},
+		{"Error no prefix", args{"00pS1Hwq1mz", "usr"}, 0, ErrInvalidID},
+		{"Error invalid body", args{"usr_00pS1Hwq1m", "usr"}, 0, ErrInvalidID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNamespacedID(tt.args.s, tt.args.expectedPrefix)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseNamespacedID() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseNamespacedID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	var r Registry
+	if err := r.Register("usr", newNamespacedTestGenerator(t)); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := r.Register("ord", newNamespacedTestGenerator(t)); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := r.Register("usr", newNamespacedTestGenerator(t)); !errors.Is(err, ErrInvalidPrefix) {
+		t.Errorf("Register() error = %v, want %v", err, ErrInvalidPrefix)
+	}
+
+	for _, prefix := range []string{"usr", "ord"} {
+		s, err := r.Generate(prefix)
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		id, err := ParseNamespacedID(s, prefix)
+		if err != nil {
+			t.Fatalf("ParseNamespacedID() error = %v", err)
+		}
+		if want := SnowflakeID(11234023837724673); id != want {
+			t.Errorf("ParseNamespacedID(Generate()) = %v, want %v", id, want)
+		}
+	}
+
+	if _, err := r.Generate("inv"); !errors.Is(err, ErrPrefixNotRegistered) {
+		t.Errorf("Generate() error = %v, want %v", err, ErrPrefixNotRegistered)
+	}
+}
@@ -0,0 +1,180 @@
+package idgenerator
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"strings"
+)
+
+// podUIDEnv is the environment variable conventionally set to the Pod UID with the Kubernetes downward API.
+const podUIDEnv = "MY_POD_UID"
+
+// interfaceAddrs and hostname are replaced in tests.
+var (
+	interfaceAddrs = net.InterfaceAddrs
+	hostname       = os.Hostname
+)
+
+// WithMachineIDFromIP specifies the machine ID of Snowflake ID from the primary IPv4 address of the host.
+// The last two octets of the address are reduced to the machine ID range by modulo.
+// It returns ErrNoIPAddress if no non-loopback IPv4 address is found.
+//
+// Hosts whose last two octets are congruent modulo 32 get the same machine ID,
+// so it collides easily when many hosts share the same subnet.
+func WithMachineIDFromIP() Option {
+	return func(s *snowflake) error {
+		v, err := nodeIDFromIP(maxMachineID)
+		if err != nil {
+			return err
+		}
+		s.machineID = v
+		return nil
+	}
+}
+
+// WithWorkerIDFromIP specifies the 10-bit worker ID of Snowflake ID from the primary IPv4 address of the host.
+// The last two octets of the address are reduced to the worker ID range of the layout by modulo, as with WithWorkerID.
+// It returns ErrNoIPAddress if no non-loopback IPv4 address is found.
+//
+// Hosts whose last two octets are congruent modulo 1024 get the same worker ID,
+// e.g., 10.0.0.1 and 10.0.4.1 collide, so it works well only within a subnet up to /22.
+func WithWorkerIDFromIP() Option {
+	return func(s *snowflake) error {
+		ip, err := primaryIPv4()
+		if err != nil {
+			return err
+		}
+		s.setWorkerID(int(ip[2])<<8|int(ip[3]), true)
+		return nil
+	}
+}
+
+func nodeIDFromIP(maxID int) (int, error) {
+	ip, err := primaryIPv4()
+	if err != nil {
+		return 0, err
+	}
+	return (int(ip[2])<<8 | int(ip[3])) % (maxID + 1), nil
+}
+
+// primaryIPv4 returns the first non-loopback and non-link-local IPv4 address of the host.
+func primaryIPv4() (net.IP, error) {
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip := ipNet.IP.To4()
+		if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+			continue
+		}
+		return ip, nil
+	}
+	return nil, ErrNoIPAddress
+}
+
+// WithMachineIDFromHostname specifies the machine ID of Snowflake ID from the host name.
+// The FNV-32a hash of the host name is reduced to the machine ID range by modulo,
+// so the same host name always produces the same machine ID.
+//
+// Different host names may produce the same machine ID. The probability that at least two hosts collide is
+// about 3% for 2 hosts, 28% for 5 hosts, and 79% for 10 hosts.
+func WithMachineIDFromHostname() Option {
+	return func(s *snowflake) error {
+		v, err := nodeIDFromHostname(maxMachineID)
+		if err != nil {
+			return err
+		}
+		s.machineID = v
+		return nil
+	}
+}
+
+// WithWorkerIDFromHostname specifies the 10-bit worker ID of Snowflake ID from the host name.
+// The FNV-32a hash of the host name is reduced to the worker ID range of the layout by modulo, as with WithWorkerID,
+// so the same host name always produces the same worker ID.
+//
+// Different host names may produce the same worker ID. The probability that at least two hosts collide is
+// about 4% for 10 hosts, 35% for 30 hosts, 70% for 50 hosts, and 99% for 100 hosts.
+func WithWorkerIDFromHostname() Option {
+	return func(s *snowflake) error {
+		name, err := hostname()
+		if err != nil {
+			return err
+		}
+		s.setWorkerID(int(fnv32a(name)), true)
+		return nil
+	}
+}
+
+func nodeIDFromHostname(maxID int) (int, error) {
+	name, err := hostname()
+	if err != nil {
+		return 0, err
+	}
+	return hashNodeID(name, maxID), nil
+}
+
+// hashNodeID reduces the FNV-32a hash of s to [0, maxID].
+func hashNodeID(s string, maxID int) int {
+	return int(fnv32a(s) % uint32(maxID+1))
+}
+
+// fnv32a returns the FNV-32a hash of s.
+func fnv32a(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
+}
+
+// WithMachineIDFromPodUID specifies the datacenter ID and the machine ID of Snowflake ID from the Pod UID in Kubernetes,
+// which is read from the MY_POD_UID environment variable set with the downward API:
+//
+//	env:
+//	- name: MY_POD_UID
+//	  valueFrom:
+//	    fieldRef:
+//	      fieldPath: metadata.uid
+//
+// The lower 10 bits of the last 4 bytes of the UID are split into the datacenter ID (the upper 5 bits)
+// and the machine ID (the lower 5 bits), or as many bits as the datacenter and machine bits of the layout,
+// as with WithWorkerID. A restarted Pod gets a new UID, so it does not reuse
+// the sequence of the previous Pod unless the new UID happens to have the same bits.
+// Pods may still collide, with the same probability as WithWorkerIDFromHostname.
+//
+// If MY_POD_UID is not set, it falls back to WithMachineIDFromHostname.
+// It returns ErrInvalidEnv if MY_POD_UID is not a UUID.
+func WithMachineIDFromPodUID() Option {
+	return func(s *snowflake) error {
+		env, ok := os.LookupEnv(podUIDEnv)
+		if !ok || env == "" {
+			return WithMachineIDFromHostname()(s)
+		}
+		uid, err := parseUUID(env)
+		if err != nil {
+			return fmt.Errorf("%w: %s=%q", ErrInvalidEnv, podUIDEnv, env)
+		}
+		s.setWorkerID(int(binary.BigEndian.Uint32(uid[12:])), true)
+		return nil
+	}
+}
+
+// parseUUID parses a UUID in the form of xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func parseUUID(s string) ([16]byte, error) {
+	var uid [16]byte
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return uid, ErrInvalidEnv
+	}
+	if _, err := hex.Decode(uid[:], []byte(strings.ReplaceAll(s, "-", ""))); err != nil {
+		return uid, err
+	}
+	return uid, nil
+}
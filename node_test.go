@@ -0,0 +1,204 @@
+package idgenerator
+
+import (
+	"errors"
+	"net"
+	"os"
+	"testing"
+)
+
+func stubInterfaceAddrs(t *testing.T, cidrs ...string) {
+	t.Helper()
+	var addrs []net.Addr
+	for _, cidr := range cidrs {
+		ip, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatalf("net.ParseCIDR() error = %v", err)
+		}
+		ipNet.IP = ip
+		addrs = append(addrs, ipNet)
+	}
+	orig := interfaceAddrs
+	interfaceAddrs = func() ([]net.Addr, error) { return addrs, nil }
+	t.Cleanup(func() { interfaceAddrs = orig })
+}
+
+func TestWithMachineIDFromIP(t *testing.T) {
+	tests := []struct {
+		name    string
+		addrs   []string
+		want    int
+		wantErr bool
+	}{
+		{"10.0.1.2", []string{"10.0.1.2/24"}, 2, false},
+		{"192.168.3.63", []string{"192.168.3.63/24"}, 31, false},
+		{"Skip loopback and IPv6", []string{"127.0.0.1/8", "::1/128", "fe80::1/64", "172.16.0.33/16"}, 1, false},
+		{"Skip link-local", []string{"169.254.0.5/16", "10.1.2.3/8"}, 3, false},
+		{"Error no IPv4 address", []string{"127.0.0.1/8", "::1/128"}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubInterfaceAddrs(t, tt.addrs...)
+			s := &snowflake{}
+			err := WithMachineIDFromIP()(s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithMachineIDFromIP() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if s.machineID != tt.want {
+				t.Errorf("WithMachineIDFromIP() machine ID = %v, want %v", s.machineID, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithWorkerIDFromIP(t *testing.T) {
+	tests := []struct {
+		name             string
+		addrs            []string
+		wantDatacenterID int
+		wantMachineID    int
+		wantErr          bool
+	}{
+		{"10.0.1.2", []string{"10.0.1.2/24"}, 8, 2, false},
+		{"10.0.3.255", []string{"10.0.3.255/22"}, 31, 31, false},
+		{"10.0.4.1", []string{"10.0.4.1/22"}, 0, 1, false},
+		{"Error no IPv4 address", []string{"::1/128"}, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubInterfaceAddrs(t, tt.addrs...)
+			s := &snowflake{}
+			err := WithWorkerIDFromIP()(s)
+			if err == nil {
+				err = s.validate()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithWorkerIDFromIP() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if s.datacenterID != tt.wantDatacenterID {
+				t.Errorf("WithWorkerIDFromIP() datacenter ID = %v, want %v", s.datacenterID, tt.wantDatacenterID)
+			}
+			if s.machineID != tt.wantMachineID {
+				t.Errorf("WithWorkerIDFromIP() machine ID = %v, want %v", s.machineID, tt.wantMachineID)
+			}
+		})
+	}
+}
+
+func stubHostname(t *testing.T, name string, err error) {
+	t.Helper()
+	orig := hostname
+	hostname = func() (string, error) { return name, err }
+	t.Cleanup(func() { hostname = orig })
+}
+
+func TestWithMachineIDFromHostname(t *testing.T) {
+	tests := []struct {
+		name     string
+		hostname string
+		err      error
+		want     int
+		wantErr  bool
+	}{
+		{"web-1", "web-1", nil, 31, false},
+		{"web-2", "web-2", nil, 18, false},
+		{"api-server-0", "api-server-0", nil, 4, false},
+		{"localhost", "localhost", nil, 26, false},
+		{"ip-10-0-1-2.ec2.internal", "ip-10-0-1-2.ec2.internal", nil, 5, false},
+		{"Error hostname", "", errors.New("hostname error"), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubHostname(t, tt.hostname, tt.err)
+			s := &snowflake{}
+			err := WithMachineIDFromHostname()(s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithMachineIDFromHostname() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if s.machineID != tt.want {
+				t.Errorf("WithMachineIDFromHostname() machine ID = %v, want %v", s.machineID, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithWorkerIDFromHostname(t *testing.T) {
+	tests := []struct {
+		name         string
+		hostname     string
+		err          error
+		wantWorkerID int
+		wantErr      bool
+	}{
+		{"web-1", "web-1", nil, 799, false},
+		{"web-2", "web-2", nil, 178, false},
+		{"api-server-0", "api-server-0", nil, 612, false},
+		{"localhost", "localhost", nil, 826, false},
+		{"ip-10-0-1-2.ec2.internal", "ip-10-0-1-2.ec2.internal", nil, 165, false},
+		{"Error hostname", "", errors.New("hostname error"), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubHostname(t, tt.hostname, tt.err)
+			s := &snowflake{}
+			err := WithWorkerIDFromHostname()(s)
+			if err == nil {
+				err = s.validate()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithWorkerIDFromHostname() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := s.datacenterID<<machineBitRange | s.machineID; got != tt.wantWorkerID {
+				t.Errorf("WithWorkerIDFromHostname() worker ID = %v, want %v", got, tt.wantWorkerID)
+			}
+		})
+	}
+}
+
+func TestWithMachineIDFromPodUID(t *testing.T) {
+	tests := []struct {
+		name             string
+		env              string
+		set              bool
+		wantDatacenterID int
+		wantMachineID    int
+		wantErr          bool
+	}{
+		{"Pod UID", "e0d6c7a4-9b3e-4f1a-8c2d-0123456789ab", true, 13, 11, false},
+		{"Uppercase Pod UID", "E0D6C7A4-9B3E-4F1A-8C2D-0123456789AB", true, 13, 11, false},
+		{"Fallback to hostname", "", false, 0, 31, false},
+		{"Fallback to hostname for empty", "", true, 0, 31, false},
+		{"Error without hyphens", "e0d6c7a49b3e4f1a8c2d0123456789ab", true, 0, 0, true},
+		{"Error not hex", "e0d6c7a4-9b3e-4f1a-8c2d-0123456789zz", true, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.set {
+				t.Setenv(podUIDEnv, tt.env)
+			} else {
+				t.Setenv(podUIDEnv, "")
+				os.Unsetenv(podUIDEnv)
+			}
+			stubHostname(t, "web-1", nil)
+			s := &snowflake{}
+			err := WithMachineIDFromPodUID()(s)
+			if err == nil {
+				err = s.validate()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithMachineIDFromPodUID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !errors.Is(err, ErrInvalidEnv) {
+				t.Errorf("WithMachineIDFromPodUID() error = %v, want %v", err, ErrInvalidEnv)
+			}
+			if s.datacenterID != tt.wantDatacenterID || s.machineID != tt.wantMachineID {
+				t.Errorf("WithMachineIDFromPodUID() = (%v, %v), want (%v, %v)", s.datacenterID, s.machineID, tt.wantDatacenterID, tt.wantMachineID)
+			}
+		})
+	}
+}
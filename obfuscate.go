@@ -0,0 +1,105 @@
+package idgenerator
+
+// obfuscationRounds is the number of rounds of the Feistel network.
+const obfuscationRounds = 4
+
+// WithObfuscation makes a Generator return IDs scrambled with key, which look random
+// and hide how many IDs have been generated. Deobfuscate with the same key restores the original IDs.
+//
+// The scrambled IDs are still non-negative int64 values, so they can be stored as they are,
+// but they are no longer ordered by time, and NextN no longer returns IDs in increasing order.
+// The key is not a cryptographic secret; use SignedIDGenerator to detect tampering.
+func WithObfuscation(key uint64) Option {
+	return func(s *snowflake) error {
+		s.obfuscationKey = key
+		s.obfuscation = true
+		return nil
+	}
+}
+
+func (s *snowflake) getObfuscator() *feistel {
+	if !s.obfuscation {
+		return nil
+	}
+	return newFeistel(63, s.obfuscationKey)
+}
+
+// obfuscate scrambles id if WithObfuscation is specified. Bit 63 of id is kept as it is.
+func (g *Generator) obfuscate(id SnowflakeID) SnowflakeID {
+	if g.obfuscator == nil {
+		return id
+	}
+	return SnowflakeID(g.obfuscator.encrypt(uint64(ClearReservedBit(id)))) | id&reservedBit
+}
+
+// Obfuscate scrambles id with key. It is what a Generator with WithObfuscation(key) returns for id.
+func Obfuscate(id SnowflakeID, key uint64) SnowflakeID {
+	return SnowflakeID(newFeistel(63, key).encrypt(uint64(id)))
+}
+
+// Deobfuscate restores the ID scrambled with key by Obfuscate or a Generator with WithObfuscation(key).
+func Deobfuscate(id SnowflakeID, key uint64) SnowflakeID {
+	return SnowflakeID(newFeistel(63, key).decrypt(uint64(id)))
+}
+
+// feistel is a balanced Feistel network permuting the integers in [0, 2^bits).
+// When bits is odd, it permutes [0, 2^(bits+1)) and walks the cycle until the value is back in the domain.
+type feistel struct {
+	bits     int
+	halfBits int
+	keys     [obfuscationRounds]uint64
+}
+
+func newFeistel(bits int, key uint64) *feistel {
+	f := &feistel{bits: bits, halfBits: (bits + 1) / 2}
+	for i := range f.keys {
+		// splitmix64 derives the round keys from the key.
+		key += 0x9e3779b97f4a7c15
+		z := key
+		z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+		z = (z ^ z>>27) * 0x94d049bb133111eb
+		f.keys[i] = z ^ z>>31
+	}
+	return f
+}
+
+func (f *feistel) round(v uint64, key uint64) uint64 {
+	h := (v ^ key) * 0x9e3779b97f4a7c15
+	return (h ^ h>>29) & (1<<f.halfBits - 1)
+}
+
+func (f *feistel) permute(v uint64) uint64 {
+	mask := uint64(1)<<f.halfBits - 1
+	l, r := v>>f.halfBits, v&mask
+	for _, k := range f.keys {
+		l, r = r, l^f.round(r, k)
+	}
+	return l<<f.halfBits | r
+}
+
+func (f *feistel) inverse(v uint64) uint64 {
+	mask := uint64(1)<<f.halfBits - 1
+	l, r := v>>f.halfBits, v&mask
+	for i := len(f.keys) - 1; i >= 0; i-- {
+		l, r = r^f.round(l, f.keys[i]), l
+	}
+	return l<<f.halfBits | r
+}
+
+func (f *feistel) encrypt(v uint64) uint64 {
+	for {
+		v = f.permute(v)
+		if v>>f.bits == 0 {
+			return v
+		}
+	}
+}
+
+func (f *feistel) decrypt(v uint64) uint64 {
+	for {
+		v = f.inverse(v)
+		if v>>f.bits == 0 {
+			return v
+		}
+	}
+}
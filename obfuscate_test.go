@@ -0,0 +1,108 @@
+package idgenerator
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestObfuscate(t *testing.T) {
+	tests := []struct {
+		name string
+		id   SnowflakeID
+		key  uint64
+	}{
+		{"Zero", 0, 1},
+		{"Golden", 11234023837724673, 1},
+		{"Max", 1<<63 - 1, 1},
+		{"Zero key", 11234023837724673, 0},
+		{"Other key", 11234023837724673, 0xdeadbeefcafebabe},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Obfuscate(tt.id, tt.key)
+			if got < 0 {
+				t.Errorf("Obfuscate() = %v, want non-negative", got)
+			}
+			if back := Deobfuscate(got, tt.key); back != tt.id {
+				t.Errorf("Deobfuscate(Obfuscate()) = %v, want %v", back, tt.id)
+			}
+		})
+	}
+}
+
+func TestObfuscate_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 100000 {
+		id := SnowflakeID(r.Int63())
+		key := r.Uint64()
+		got := Obfuscate(id, key)
+		if got < 0 {
+			t.Fatalf("Obfuscate(%v, %v) = %v, want non-negative", id, key, got)
+		}
+		if back := Deobfuscate(got, key); back != id {
+			t.Fatalf("Deobfuscate(Obfuscate(%v, %v)) = %v", id, key, back)
+		}
+	}
+}
+
+func TestObfuscate_DifferentKeys(t *testing.T) {
+	var id SnowflakeID = 11234023837724673
+	if Obfuscate(id, 1) == Obfuscate(id, 2) {
+		t.Errorf("Obfuscate() returns the same ID for different keys")
+	}
+	if Obfuscate(id, 1) == id {
+		t.Errorf("Obfuscate() returns the ID as it is")
+	}
+}
+
+// TestFeistel_Bijective checks the same construction as for 63 bits exhaustively over smaller domains,
+// both with an even number of bits and with an odd number of bits that needs cycle walking.
+func TestFeistel_Bijective(t *testing.T) {
+	for _, bits := range []int{8, 15, 16} {
+		f := newFeistel(bits, 0x0123456789abcdef)
+		seen := make([]bool, 1<<bits)
+		for v := uint64(0); v < 1<<bits; v++ {
+			got := f.encrypt(v)
+			if got>>bits != 0 {
+				t.Fatalf("bits %d: encrypt(%v) = %v, out of the domain", bits, v, got)
+			}
+			if seen[got] {
+				t.Fatalf("bits %d: encrypt(%v) = %v, duplicated", bits, v, got)
+			}
+			seen[got] = true
+			if back := f.decrypt(got); back != v {
+				t.Fatalf("bits %d: decrypt(encrypt(%v)) = %v", bits, v, back)
+			}
+		}
+	}
+}
+
+func TestGenerator_WithObfuscation(t *testing.T) {
+	const key = 42
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15), WithObfuscation(key))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if got, want := Deobfuscate(id, key), SnowflakeID(11234023837724672); got != want {
+		t.Errorf("Deobfuscate(Next()) = %v, want %v", got, want)
+	}
+	if id == 11234023837724672 {
+		t.Errorf("Next() = %v, want an obfuscated ID", id)
+	}
+
+	ids, err := g.NextN(3)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	for i, id := range ids {
+		if got, want := Deobfuscate(id, key), SnowflakeID(11234023837724673+i); got != want {
+			t.Errorf("Deobfuscate(NextN()[%d]) = %v, want %v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package idgenerator
+
+import "time"
+
+// WithObserver adds fn to be called synchronously after each ID is generated successfully,
+// with the ID and the time taken to generate it, e.g., to log IDs or record latency.
+// For NextN, fn is called for each ID with the time taken to generate all of them.
+// When it is specified multiple times, the observers are called in order.
+//
+// fn is called without the Generator's lock held, so it may generate IDs with the same Generator.
+func WithObserver(fn func(id SnowflakeID, elapsed time.Duration)) Option {
+	return func(s *snowflake) error {
+		if fn == nil {
+			return ErrInvalidObserver
+		}
+		s.observers = append(s.observers, fn)
+		return nil
+	}
+}
+
+func (g *Generator) observe(elapsed time.Duration, ids ...SnowflakeID) {
+	for _, id := range ids {
+		for _, fn := range g.observers {
+			fn(id, elapsed)
+		}
+	}
+}
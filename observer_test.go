@@ -0,0 +1,70 @@
+package idgenerator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithObserver(t *testing.T) {
+	if _, err := NewGenerator(WithObserver(nil)); err != ErrInvalidObserver {
+		t.Errorf("NewGenerator() error = %v, want %v", err, ErrInvalidObserver)
+	}
+
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	var g *Generator
+	var calls []string
+	var observed []SnowflakeID
+	var nested SnowflakeID
+	g, err := NewGenerator(
+		WithClock(c),
+		WithDatacenterID(31),
+		WithMachineID(15),
+		WithObserver(func(id SnowflakeID, elapsed time.Duration) {
+			calls = append(calls, "first")
+			observed = append(observed, id)
+			if elapsed < 0 {
+				t.Errorf("observer elapsed = %v, want non-negative", elapsed)
+			}
+		}),
+		WithObserver(func(id SnowflakeID, elapsed time.Duration) {
+			calls = append(calls, "second")
+			// Generating an ID in an observer does not deadlock.
+			if nested == 0 {
+				nested = -1
+				nested, _ = g.Next()
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if _, err := g.NextN(2); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+
+	wantCalls := []string{"first", "second", "first", "second", "first", "second", "first", "second"}
+	if len(calls) != len(wantCalls) {
+		t.Fatalf("observer calls = %v, want %v", calls, wantCalls)
+	}
+	for i := range calls {
+		if calls[i] != wantCalls[i] {
+			t.Errorf("observer calls = %v, want %v", calls, wantCalls)
+			break
+		}
+	}
+	// The nested ID is observed before the outer second observer returns.
+	wantObserved := []SnowflakeID{11234023837724672, 11234023837724673, 11234023837724674, 11234023837724675}
+	for i := range wantObserved {
+		if observed[i] != wantObserved[i] {
+			t.Errorf("observed IDs = %v, want %v", observed, wantObserved)
+			break
+		}
+	}
+	if nested != 11234023837724673 {
+		t.Errorf("nested Next() = %v, want %v", nested, 11234023837724673)
+	}
+}
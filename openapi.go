@@ -0,0 +1,40 @@
+package idgenerator
+
+// snowflakeIDDescription is the description of a SnowflakeID in the schemas.
+const snowflakeIDDescription = "Snowflake ID: a 64-bit ID ordered by the time it was generated."
+
+// SnowflakeIDJSONSchema returns the OpenAPI 3.0 schema object of a SnowflakeID in JSON,
+// which is a string of decimal digits as SnowflakeID.MarshalJSON encodes, since a JavaScript number loses precision over 2^53.
+// The IDs generated with WithReservedBit(true) or WithLayoutVersion are negative, and do not match the pattern.
+//
+// It returns a new map on every call, so that the caller may add fields, such as the extensions of SwaggerExtension.
+func SnowflakeIDJSONSchema() map[string]any {
+	return map[string]any{
+		"type":        "string",
+		"pattern":     "^[0-9]{1,19}$",
+		"description": snowflakeIDDescription,
+		"example":     "11234023837724673",
+	}
+}
+
+// SnowflakeIDInt64JSONSchema returns the OpenAPI 3.0 schema object of a SnowflakeID as an integer,
+// e.g., for a query parameter or a field encoded by the caller as a number, which SnowflakeID.UnmarshalJSON also accepts.
+func SnowflakeIDInt64JSONSchema() map[string]any {
+	return map[string]any{
+		"type":        "integer",
+		"format":      "int64",
+		"description": snowflakeIDDescription,
+		"example":     int64(11234023837724673),
+	}
+}
+
+// SwaggerExtension returns the specification extension marking a schema as a SnowflakeID,
+// for tools that detect and specially render such fields. Merge it into the schema object, e.g.:
+//
+//	schema := idgenerator.SnowflakeIDJSONSchema()
+//	maps.Copy(schema, idgenerator.SwaggerExtension())
+func SwaggerExtension() map[string]any {
+	return map[string]any{
+		"x-snowflake-id": true,
+	}
+}
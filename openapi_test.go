@@ -0,0 +1,64 @@
+package idgenerator
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestSnowflakeIDJSONSchema(t *testing.T) {
+	schema := SnowflakeIDJSONSchema()
+	pattern := regexp.MustCompile(schema["pattern"].(string))
+	tests := []struct {
+		name string
+		id   SnowflakeID
+		want bool
+	}{
+		{"Example", 11234023837724673, true},
+		{"Zero", 0, true},
+		{"Maximum value", 1<<63 - 1, true},
+		{"Negative", -1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.id.MarshalJSON()
+			if err != nil {
+				t.Fatalf("MarshalJSON() error = %v", err)
+			}
+			var s string
+			if err := json.Unmarshal(b, &s); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got := pattern.MatchString(s); got != tt.want {
+				t.Errorf("pattern %v matches %q = %v, want %v", pattern, s, got, tt.want)
+			}
+		})
+	}
+
+	maps.Copy(schema, SwaggerExtension())
+	if SnowflakeIDJSONSchema()["x-snowflake-id"] != nil {
+		t.Errorf("SnowflakeIDJSONSchema() shares the map between calls")
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"description":"Snowflake ID: a 64-bit ID ordered by the time it was generated.","example":"11234023837724673","pattern":"^[0-9]{1,19}$","type":"string","x-snowflake-id":true}`
+	if string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+}
+
+func TestSnowflakeIDInt64JSONSchema(t *testing.T) {
+	want := map[string]any{
+		"type":        "integer",
+		"format":      "int64",
+		"description": snowflakeIDDescription,
+		"example":     int64(11234023837724673),
+	}
+	if got := SnowflakeIDInt64JSONSchema(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SnowflakeIDInt64JSONSchema() = %v, want %v", got, want)
+	}
+}
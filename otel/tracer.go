@@ -0,0 +1,45 @@
+// Package otel traces ID generation of an idgenerator.Generator with OpenTelemetry.
+//
+// It is a separate module, so that the idgenerator package does not depend on OpenTelemetry.
+package otel
+
+import (
+	"context"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithOTelTracer wraps each ID generation of the Generator in a span named "idgenerator.Next" (or "idgenerator.NextN").
+//
+// The span has the attributes generator.datacenter_id and generator.machine_id,
+// and id for a single ID or id.count, id.first and id.last for a batch.
+// When the generation fails, the error is recorded on the span.
+func WithOTelTracer(tracer trace.Tracer) idgenerator.Option {
+	return idgenerator.WithInterceptor(func(ctx context.Context, g *idgenerator.Generator, name string, generate func(context.Context) ([]idgenerator.SnowflakeID, error)) ([]idgenerator.SnowflakeID, error) {
+		ctx, span := tracer.Start(ctx, "idgenerator."+name, trace.WithAttributes(
+			attribute.Int("generator.datacenter_id", g.DatacenterID()),
+			attribute.Int("generator.machine_id", g.MachineID()),
+		))
+		defer span.End()
+
+		ids, err := generate(ctx)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return ids, err
+		}
+		if len(ids) == 1 {
+			span.SetAttributes(attribute.String("id", ids[0].String()))
+		} else if len(ids) > 1 {
+			span.SetAttributes(
+				attribute.Int("id.count", len(ids)),
+				attribute.String("id.first", ids[0].String()),
+				attribute.String("id.last", ids[len(ids)-1].String()),
+			)
+		}
+		return ids, nil
+	})
+}
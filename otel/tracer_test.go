@@ -0,0 +1,92 @@
+package otel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithOTelTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	c := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := idgenerator.NewGenerator(
+		idgenerator.WithClock(c),
+		idgenerator.WithDatacenterID(31),
+		idgenerator.WithMachineID(15),
+		idgenerator.WithSequenceExhaustionPolicy(idgenerator.ReturnError),
+		WithOTelTracer(provider.Tracer("test")),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if _, err := g.NextN(4095); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if _, err := g.Next(); err != idgenerator.ErrSequenceExhausted {
+		t.Fatalf("Next() error = %v, want %v", err, idgenerator.ErrSequenceExhausted)
+	}
+
+	generator := []attribute.KeyValue{
+		attribute.Int("generator.datacenter_id", 31),
+		attribute.Int("generator.machine_id", 15),
+	}
+	tests := []struct {
+		name       string
+		attributes []attribute.KeyValue
+		status     codes.Code
+		events     int
+	}{
+		{
+			"idgenerator.Next",
+			append(generator, attribute.String("id", "11234023837724672")),
+			codes.Unset,
+			0,
+		},
+		{
+			"idgenerator.NextN",
+			append(generator,
+				attribute.Int("id.count", 4095),
+				attribute.String("id.first", "11234023837724673"),
+				attribute.String("id.last", "11234023837728767"),
+			),
+			codes.Unset,
+			0,
+		},
+		{
+			"idgenerator.Next",
+			generator,
+			codes.Error,
+			1,
+		},
+	}
+	spans := recorder.Ended()
+	if len(spans) != len(tests) {
+		t.Fatalf("spans = %d, want %d", len(spans), len(tests))
+	}
+	for i, tt := range tests {
+		span := spans[i]
+		if span.Name() != tt.name {
+			t.Errorf("span[%d] name = %v, want %v", i, span.Name(), tt.name)
+		}
+		if !reflect.DeepEqual(span.Attributes(), tt.attributes) {
+			t.Errorf("span[%d] attributes = %v, want %v", i, span.Attributes(), tt.attributes)
+		}
+		if span.Status().Code != tt.status {
+			t.Errorf("span[%d] status = %v, want %v", i, span.Status().Code, tt.status)
+		}
+		if len(span.Events()) != tt.events {
+			t.Errorf("span[%d] events = %v, want %v", i, len(span.Events()), tt.events)
+		}
+	}
+}
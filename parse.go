@@ -0,0 +1,128 @@
+package idgenerator
+
+import (
+	"fmt"
+	"time"
+)
+
+// SnowflakeComponents represents the fields of a Snowflake ID.
+type SnowflakeComponents struct {
+	Timestamp      time.Time
+	DatacenterID   int
+	MachineID      int
+	SequenceNumber int
+}
+
+// ParseSnowflakeID decomposes a Snowflake ID into its fields.
+// baseTime must be the base time the ID was generated with. If it is zero, the default base time is used.
+// layout must be the bit layout the ID was generated with. If it is zero, the layout is detected by DetectLayout,
+// and the sequence number of an ID generated with WithLayoutVersion excludes the version bits.
+// It returns ErrInvalidID if the ID has bits beyond the layout, or is negative and not generated with WithLayoutVersion,
+// so it rejects the IDs generated with WithReservedBit(true).
+// It returns ErrInvalidLayoutVersion if layout is zero and the version of the ID is not registered.
+func ParseSnowflakeID(id int64, baseTime time.Time, layout Layout) (SnowflakeComponents, error) {
+	if layout == (Layout{}) {
+		l, err := detectLayout(id)
+		if err != nil {
+			return SnowflakeComponents{}, err
+		}
+		if l.tagged && l.tag != reservedBitTag {
+			id = int64(ClearReservedBit(SnowflakeID(id)))
+		}
+		layout = l
+	} else if err := layout.validate(); err != nil {
+		return SnowflakeComponents{}, err
+	}
+	if id < 0 {
+		return SnowflakeComponents{}, fmt.Errorf("%w: %d is negative", ErrInvalidID, id)
+	}
+	if baseTime.IsZero() {
+		baseTime = defaultBaseTime
+	}
+	if id>>layout.timestampShift() > layout.maxTimestamp() {
+		return SnowflakeComponents{}, fmt.Errorf("%w: %d has bits beyond the layout", ErrInvalidID, id)
+	}
+	return layout.decompose(id, baseTime), nil
+}
+
+// ParseDiscordID decomposes a Discord ID into its fields.
+// The DatacenterID and MachineID fields are the internal worker ID and the internal process ID.
+func ParseDiscordID(id int64) (SnowflakeComponents, error) {
+	return ParseSnowflakeID(id, EpochDiscord, LayoutDiscord)
+}
+
+// ExtractTime returns the time when the ID was generated, read from bits 22-62 or in the layout detected by DetectLayout.
+// baseTime must be the base time the ID was generated with. If it is zero, the default base time is used.
+func ExtractTime(id int64, baseTime time.Time) time.Time {
+	return detectLayoutOrDefault(id).ExtractTime(id, baseTime)
+}
+
+// ExtractTimeMicro returns the time when the ID in LayoutMicrosecond was generated, in microseconds.
+// baseTime must be the base time the ID was generated with. If it is zero, the default base time is used.
+func ExtractTimeMicro(id int64, baseTime time.Time) time.Time {
+	return LayoutMicrosecond.ExtractTime(id, baseTime)
+}
+
+// ExtractDatacenterID returns the datacenter ID of the ID, read from bits 17-21 or in the layout detected by DetectLayout.
+func ExtractDatacenterID(id int64) int {
+	return detectLayoutOrDefault(id).ExtractDatacenterID(id)
+}
+
+// ExtractMachineID returns the machine ID of the ID, read from bits 12-16 or in the layout detected by DetectLayout.
+func ExtractMachineID(id int64) int {
+	return detectLayoutOrDefault(id).ExtractMachineID(id)
+}
+
+// ExtractSequenceNumber returns the sequence number of the ID, read from bits 0-11 or in the layout detected by DetectLayout,
+// which excludes the tag bits of WithLayoutVersion and WithReservedBit(true).
+func ExtractSequenceNumber(id int64) int {
+	return detectLayoutOrDefault(id).ExtractSequenceNumber(id)
+}
+
+// ExtractWorkerID returns the 10-bit worker ID of the ID, read from bits 12-21 or in the layout detected by DetectLayout.
+// It is the combination of the datacenter ID and the machine ID set by WithWorkerID.
+func ExtractWorkerID(id int64) int {
+	return ExtractShardID(id, detectLayoutOrDefault(id))
+}
+
+// ExtractShardID returns the shard ID of the ID in layout, which is the combination of the datacenter ID and the machine ID.
+// See WithShardID.
+func ExtractShardID(id int64, layout Layout) int {
+	return layout.ExtractDatacenterID(id)<<layout.MachineBits | layout.ExtractMachineID(id)
+}
+
+// ExtractTime returns the time when the ID in the layout was generated.
+// baseTime must be the base time the ID was generated with. If it is zero, the default base time is used.
+func (l Layout) ExtractTime(id int64, baseTime time.Time) time.Time {
+	if baseTime.IsZero() {
+		baseTime = defaultBaseTime
+	}
+	return l.decompose(id, baseTime).Timestamp
+}
+
+// ExtractDatacenterID returns the datacenter ID of the ID in the layout.
+func (l Layout) ExtractDatacenterID(id int64) int {
+	return int(id>>l.datacenterShift()) & l.maxDatacenterID()
+}
+
+// ExtractMachineID returns the machine ID of the ID in the layout.
+func (l Layout) ExtractMachineID(id int64) int {
+	return int(id>>l.machineShift()) & l.maxMachineID()
+}
+
+// ExtractSequenceNumber returns the sequence number of the ID in the layout.
+func (l Layout) ExtractSequenceNumber(id int64) int {
+	return int(id>>l.sequenceShift()) & l.maxSequenceNumber()
+}
+
+// IDAge returns how long ago the ID was generated.
+// baseTime must be the base time the ID was generated with. If it is zero, the default base time is used.
+func IDAge(id SnowflakeID, baseTime time.Time) time.Duration {
+	return time.Since(ExtractTime(int64(id), baseTime))
+}
+
+// IsExpired reports whether the ID was generated more than ttl ago, e.g., to expire a token or a session by its ID.
+// baseTime must be the base time the ID was generated with. If it is zero, the default base time is used.
+func IsExpired(id SnowflakeID, baseTime time.Time, ttl time.Duration) bool {
+	return IDAge(id, baseTime) > ttl
+}
@@ -0,0 +1,311 @@
+package idgenerator
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSnowflakeID(t *testing.T) {
+	type args struct {
+		id       int64
+		baseTime time.Time
+		layout   Layout
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    SnowflakeComponents
+		wantErr bool
+	}{
+		{
+			"Default base time",
+			args{11234023837724673, time.Time{}, Layout{}},
+			SnowflakeComponents{
+				Timestamp:      time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				DatacenterID:   31,
+				MachineID:      15,
+				SequenceNumber: 1,
+			},
+			false,
+		},
+		{
+			"Custom base time",
+			args{11234023837724673, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Layout{}},
+			SnowflakeComponents{
+				Timestamp:      time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+				DatacenterID:   31,
+				MachineID:      15,
+				SequenceNumber: 1,
+			},
+			false,
+		},
+		{
+			"LayoutTwitterSnowflake",
+			args{11234023837724673, time.Time{}, LayoutTwitterSnowflake},
+			SnowflakeComponents{
+				Timestamp:      time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				DatacenterID:   31,
+				MachineID:      15,
+				SequenceNumber: 1,
+			},
+			false,
+		},
+		{
+			"LayoutSonyflake",
+			args{498645703065670196, EpochSonyflake, LayoutSonyflake},
+			SnowflakeComponents{
+				Timestamp:      time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				DatacenterID:   0,
+				MachineID:      0x1234,
+				SequenceNumber: 1,
+			},
+			false,
+		},
+		{
+			"Error negative ID",
+			args{-1, time.Time{}, Layout{}},
+			SnowflakeComponents{},
+			true,
+		},
+		{
+			"Error invalid layout",
+			args{1, time.Time{}, Layout{TimestampBits: 41, SequenceBits: 24}},
+			SnowflakeComponents{},
+			true,
+		},
+		{
+			"Error bits beyond the layout",
+			args{1 << 40, time.Time{}, Layout{TimestampBits: 16, MachineBits: 8, SequenceBits: 8}},
+			SnowflakeComponents{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSnowflakeID(tt.args.id, tt.args.baseTime, tt.args.layout)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSnowflakeID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSnowflakeID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSnowflakeID_RoundTrip(t *testing.T) {
+	at := time.Date(2024, 5, 6, 7, 8, 9, 10*int(time.Millisecond), time.UTC)
+	id, err := NewSnowflakeID(
+		WithTimestamp(at),
+		WithDatacenterID(3),
+		WithMachineID(7),
+		WithSequenceNumber(4095),
+	)
+	if err != nil {
+		t.Fatalf("NewSnowflakeID() error = %v", err)
+	}
+	got, err := ParseSnowflakeID(id, time.Time{}, Layout{})
+	if err != nil {
+		t.Fatalf("ParseSnowflakeID() error = %v", err)
+	}
+	want := SnowflakeComponents{Timestamp: at, DatacenterID: 3, MachineID: 7, SequenceNumber: 4095}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSnowflakeID() = %v, want %v", got, want)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	type args struct {
+		id       int64
+		baseTime time.Time
+	}
+	tests := []struct {
+		name               string
+		args               args
+		wantTime           time.Time
+		wantDatacenterID   int
+		wantMachineID      int
+		wantSequenceNumber int
+	}{
+		{
+			"Default base time",
+			args{11234023837724673, time.Time{}},
+			time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			31,
+			15,
+			1,
+		},
+		{
+			"Custom base time",
+			args{11234023837724673, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+			time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+			31,
+			15,
+			1,
+		},
+		{
+			"Zero",
+			args{0, time.Time{}},
+			defaultBaseTime,
+			0,
+			0,
+			0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractTime(tt.args.id, tt.args.baseTime); !got.Equal(tt.wantTime) {
+				t.Errorf("ExtractTime() = %v, want %v", got, tt.wantTime)
+			}
+			if got := ExtractDatacenterID(tt.args.id); got != tt.wantDatacenterID {
+				t.Errorf("ExtractDatacenterID() = %v, want %v", got, tt.wantDatacenterID)
+			}
+			if got := ExtractMachineID(tt.args.id); got != tt.wantMachineID {
+				t.Errorf("ExtractMachineID() = %v, want %v", got, tt.wantMachineID)
+			}
+			if got := ExtractSequenceNumber(tt.args.id); got != tt.wantSequenceNumber {
+				t.Errorf("ExtractSequenceNumber() = %v, want %v", got, tt.wantSequenceNumber)
+			}
+		})
+	}
+}
+
+func TestExtractWorkerID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   int64
+		want int
+	}{
+		{"Datacenter ID:31 Machine ID:15", 11234023837724673, 1007},
+		{"Zero", 0, 0},
+		{"Maximum value", math.MaxInt64, 1023},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractWorkerID(tt.id); got != tt.want {
+				t.Errorf("ExtractWorkerID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDiscordID(t *testing.T) {
+	type args struct {
+		id int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    SnowflakeComponents
+		wantErr bool
+	}{
+		{
+			// The example in the Discord API documentation.
+			"175928847299117063",
+			args{175928847299117063},
+			SnowflakeComponents{
+				Timestamp:      time.Date(2016, 4, 30, 11, 18, 25, 796*int(time.Millisecond), time.UTC),
+				DatacenterID:   1,
+				MachineID:      0,
+				SequenceNumber: 7,
+			},
+			false,
+		},
+		{
+			"Error negative ID",
+			args{-1},
+			SnowflakeComponents{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDiscordID(tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDiscordID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDiscordID() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			id, err := NewSnowflakeID(
+				WithLayout(LayoutDiscord),
+				WithBaseTime(EpochDiscord),
+				WithTimestamp(got.Timestamp),
+				WithDatacenterID(got.DatacenterID),
+				WithMachineID(got.MachineID),
+				WithSequenceNumber(got.SequenceNumber),
+			)
+			if err != nil {
+				t.Fatalf("NewSnowflakeID() error = %v", err)
+			}
+			if id != tt.args.id {
+				t.Errorf("NewSnowflakeID() = %v, want %v", id, tt.args.id)
+			}
+		})
+	}
+}
+
+func TestExtractShardID(t *testing.T) {
+	type args struct {
+		id     int64
+		layout Layout
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{"LayoutInstagram", args{11637205501278089, LayoutInstagram}, 1341},
+		{"LayoutTwitterSnowflake", args{11234023837724673, LayoutTwitterSnowflake}, 1007},
+		{"LayoutSonyflake", args{498645703065670196, LayoutSonyflake}, 0x1234},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractShardID(tt.args.id, tt.args.layout); got != tt.want {
+				t.Errorf("ExtractShardID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIDAge(t *testing.T) {
+	type args struct {
+		age      time.Duration
+		baseTime time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{"Default base time 1 hour", args{time.Hour, time.Time{}}},
+		{"Custom base time 1 minute", args{time.Minute, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{"Just generated", args{0, time.Time{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := NewSnowflakeID(WithTimestamp(time.Now().Add(-tt.args.age)), WithBaseTime(tt.args.baseTime))
+			if err != nil {
+				t.Fatalf("NewSnowflakeID() error = %v", err)
+			}
+			got := IDAge(SnowflakeID(id), tt.args.baseTime)
+			// The timestamp is truncated to milliseconds, and some time passes after generation.
+			if got < tt.args.age || got > tt.args.age+time.Second {
+				t.Errorf("IDAge() = %v, want about %v", got, tt.args.age)
+			}
+			if IsExpired(SnowflakeID(id), tt.args.baseTime, tt.args.age+time.Minute) {
+				t.Errorf("IsExpired() with ttl %v = %v, want %v", tt.args.age+time.Minute, true, false)
+			}
+			if tt.args.age > 0 && !IsExpired(SnowflakeID(id), tt.args.baseTime, tt.args.age/2) {
+				t.Errorf("IsExpired() with ttl %v = %v, want %v", tt.args.age/2, false, true)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Package pgx encodes and decodes Snowflake IDs as PostgreSQL int8 (BIGINT) values with jackc/pgx.
+//
+// Register the codec on each connection, e.g., in pgxpool.Config.AfterConnect:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		idgeneratorpgx.Register(conn.TypeMap())
+//		return nil
+//	}
+//
+// It is a separate module, so that the idgenerator package does not depend on pgx.
+package pgx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// Codec is the int8 codec that encodes SnowflakeID values and scans into *SnowflakeID directly,
+// in both the text and the binary formats. Other values are handled by pgtype.Int8Codec.
+type Codec struct {
+	pgtype.Int8Codec
+}
+
+// Register registers Codec for int8 to m, and makes int8 the default PostgreSQL type of SnowflakeID.
+func Register(m *pgtype.Map) {
+	m.RegisterType(&pgtype.Type{Name: "int8", OID: pgtype.Int8OID, Codec: Codec{}})
+	m.RegisterDefaultPgType(idgenerator.SnowflakeID(0), "int8")
+}
+
+// PlanEncode returns an EncodePlan for a SnowflakeID, or the plan of pgtype.Int8Codec for other values.
+func (c Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if _, ok := value.(idgenerator.SnowflakeID); ok {
+		switch format {
+		case pgtype.BinaryFormatCode:
+			return encodePlanBinary{}
+		case pgtype.TextFormatCode:
+			return encodePlanText{}
+		}
+	}
+	return c.Int8Codec.PlanEncode(m, oid, format, value)
+}
+
+// PlanScan returns a ScanPlan for a *SnowflakeID, or the plan of pgtype.Int8Codec for other targets.
+func (c Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if _, ok := target.(*idgenerator.SnowflakeID); ok {
+		switch format {
+		case pgtype.BinaryFormatCode:
+			return scanPlanBinary{}
+		case pgtype.TextFormatCode:
+			return scanPlanText{}
+		}
+	}
+	return c.Int8Codec.PlanScan(m, oid, format, target)
+}
+
+type encodePlanBinary struct{}
+
+func (encodePlanBinary) Encode(value any, buf []byte) ([]byte, error) {
+	return binary.BigEndian.AppendUint64(buf, uint64(value.(idgenerator.SnowflakeID))), nil
+}
+
+type encodePlanText struct{}
+
+func (encodePlanText) Encode(value any, buf []byte) ([]byte, error) {
+	return strconv.AppendInt(buf, value.(idgenerator.SnowflakeID).Int64(), 10), nil
+}
+
+type scanPlanBinary struct{}
+
+func (scanPlanBinary) Scan(src []byte, target any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", target)
+	}
+	if len(src) != 8 {
+		return fmt.Errorf("invalid length for int8: %v", len(src))
+	}
+	*target.(*idgenerator.SnowflakeID) = idgenerator.SnowflakeID(binary.BigEndian.Uint64(src))
+	return nil
+}
+
+type scanPlanText struct{}
+
+func (scanPlanText) Scan(src []byte, target any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", target)
+	}
+	v, err := strconv.ParseInt(string(src), 10, 64)
+	if err != nil {
+		return err
+	}
+	*target.(*idgenerator.SnowflakeID) = idgenerator.SnowflakeID(v)
+	return nil
+}
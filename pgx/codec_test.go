@@ -0,0 +1,112 @@
+package pgx
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func TestCodec(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	tests := []struct {
+		name   string
+		format int16
+		want   string
+	}{
+		{"Binary", pgtype.BinaryFormatCode, "\x00\x27\xe9\x49\x00\x3e\xf0\x01"},
+		{"Text", pgtype.TextFormatCode, "11234023837724673"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := idgenerator.SnowflakeID(11234023837724673)
+			buf, err := m.Encode(pgtype.Int8OID, tt.format, id, nil)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if string(buf) != tt.want {
+				t.Errorf("Encode() = %q, want %q", buf, tt.want)
+			}
+
+			var got idgenerator.SnowflakeID
+			if err := m.Scan(pgtype.Int8OID, tt.format, buf, &got); err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if got != id {
+				t.Errorf("Scan() = %v, want %v", got, id)
+			}
+
+			if err := m.Scan(pgtype.Int8OID, tt.format, nil, &got); err == nil {
+				t.Error("Scan() NULL error = nil, want error")
+			}
+
+			// Other values are handled by pgtype.Int8Codec.
+			var n int64
+			if err := m.Scan(pgtype.Int8OID, tt.format, buf, &n); err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if n != 11234023837724673 {
+				t.Errorf("Scan() = %v, want %v", n, 11234023837724673)
+			}
+		})
+	}
+}
+
+func TestCodec_DefaultPgType(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+	typ, ok := m.TypeForValue(idgenerator.SnowflakeID(1))
+	if !ok {
+		t.Fatal("TypeForValue() ok = false, want true")
+	}
+	if typ.Name != "int8" {
+		t.Errorf("TypeForValue() = %v, want %v", typ.Name, "int8")
+	}
+}
+
+// TestCodec_PostgreSQL queries a table by a Snowflake ID primary key.
+// It runs only when PGX_TEST_DATABASE is set to a connection string, e.g., postgres://localhost:5432/test.
+func TestCodec_PostgreSQL(t *testing.T) {
+	connString := os.Getenv("PGX_TEST_DATABASE")
+	if connString == "" {
+		t.Skip("PGX_TEST_DATABASE is not set")
+	}
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, connString)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close(ctx)
+	Register(conn.TypeMap())
+
+	if _, err := conn.Exec(ctx, "CREATE TEMPORARY TABLE users (id BIGINT PRIMARY KEY, name TEXT NOT NULL)"); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	g, err := idgenerator.NewGenerator()
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if _, err := conn.Exec(ctx, "INSERT INTO users (id, name) VALUES ($1, $2)", id, "alice"); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+
+	for _, mode := range []pgx.QueryExecMode{pgx.QueryExecModeCacheStatement, pgx.QueryExecModeSimpleProtocol} {
+		var got idgenerator.SnowflakeID
+		var name string
+		if err := conn.QueryRow(ctx, "SELECT id, name FROM users WHERE id = $1", mode, id).Scan(&got, &name); err != nil {
+			t.Fatalf("QueryRow() error = %v", err)
+		}
+		if got != id || name != "alice" {
+			t.Errorf("QueryRow() = %v, %v, want %v, %v", got, name, id, "alice")
+		}
+	}
+}
@@ -0,0 +1,16 @@
+module github.com/kawabatas/go-id-generator/pgx
+
+go 1.25.0
+
+require (
+	github.com/jackc/pgx/v5 v5.11.0
+	github.com/kawabatas/go-id-generator v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	golang.org/x/text v0.29.0 // indirect
+)
+
+replace github.com/kawabatas/go-id-generator => ../
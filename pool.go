@@ -0,0 +1,104 @@
+package idgenerator
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+)
+
+// GeneratorPool generates unique Snowflake IDs with multiple Generators, called shards,
+// to reduce the lock contention of a single Generator under very high concurrency.
+//
+// Each shard has its own machine ID, starting from the machine ID of the options, so that the shards never generate the same ID.
+// Go does not expose goroutine IDs, so each call is routed to a randomly chosen shard.
+// IDs generated by a GeneratorPool are unique, but not in increasing order across shards.
+// A GeneratorPool is safe for concurrent use by multiple goroutines.
+type GeneratorPool struct {
+	shards []*Generator
+}
+
+var _ IDGenerator = (*GeneratorPool)(nil)
+
+// WithShardCount specifies the number of shards of a GeneratorPool.
+// The default is the number of logical CPUs, limited by the number of available machine IDs.
+// It is not supported by NewGenerator.
+func WithShardCount(n int) Option {
+	return func(s *snowflake) error {
+		if n <= 0 {
+			return ErrInvalidShardCount
+		}
+		s.shardCount = n
+		return nil
+	}
+}
+
+// NewGeneratorPool returns a new GeneratorPool.
+//
+// The options are the same as NewGenerator, and apply to every shard.
+// The shard i uses the machine ID of the options plus i, so the machine IDs must be available in the layout:
+// e.g., with the default layout, WithMachineID(8) and WithShardCount(8) use the machine IDs from 8 to 15.
+func NewGeneratorPool(opts ...Option) (*GeneratorPool, error) {
+	s := &snowflake{}
+	for _, f := range opts {
+		if err := f(s); err != nil {
+			return nil, err
+		}
+	}
+	// The first shard resolves the datacenter ID and the machine ID, including random ones.
+	first, err := NewGenerator(append(opts[:len(opts):len(opts)], withPoolShard(-1, -1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	available := first.layout.maxMachineID() - first.machineID + 1
+	n := s.shardCount
+	if n == 0 {
+		n = min(runtime.GOMAXPROCS(0), available)
+	} else if n > available {
+		return nil, fmt.Errorf("%w: %d shards from machine ID %d", ErrInvalidShardCount, n, first.machineID)
+	}
+
+	p := &GeneratorPool{shards: make([]*Generator, n)}
+	p.shards[0] = first
+	for i := 1; i < n; i++ {
+		if p.shards[i], err = NewGenerator(append(opts[:len(opts):len(opts)], withPoolShard(first.datacenterID, first.machineID+i))...); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// withPoolShard fixes the datacenter ID and the machine ID of a shard, unless they are negative,
+// and clears the shard count, which NewGenerator does not support.
+func withPoolShard(datacenterID, machineID int) Option {
+	return func(s *snowflake) error {
+		s.shardCount = 0
+		if datacenterID < 0 {
+			return nil
+		}
+		s.datacenterID = datacenterID
+		s.machineID = machineID
+		s.hasShardID = false
+		s.random = false
+		return nil
+	}
+}
+
+// ShardCount returns the number of shards.
+func (p *GeneratorPool) ShardCount() int {
+	return len(p.shards)
+}
+
+// Next returns a new generated Snowflake ID.
+func (p *GeneratorPool) Next() (SnowflakeID, error) {
+	return p.shard().Next()
+}
+
+// NextN returns n new generated Snowflake IDs in increasing order, all generated by the same shard.
+func (p *GeneratorPool) NextN(n int) ([]SnowflakeID, error) {
+	return p.shard().NextN(n)
+}
+
+func (p *GeneratorPool) shard() *Generator {
+	return p.shards[rand.N(len(p.shards))]
+}
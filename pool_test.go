@@ -0,0 +1,103 @@
+package idgenerator
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewGeneratorPool(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    int
+		wantErr error
+	}{
+		{"Default", nil, min(runtime.GOMAXPROCS(0), maxMachineID+1), nil},
+		{"WithShardCount", []Option{WithShardCount(4)}, 4, nil},
+		{"All machine IDs", []Option{WithMachineID(16), WithShardCount(16)}, 16, nil},
+		{"Error zero shards", []Option{WithShardCount(0)}, 0, ErrInvalidShardCount},
+		{"Error too many shards", []Option{WithMachineID(16), WithShardCount(17)}, 0, ErrInvalidShardCount},
+		{"Error invalid option", []Option{WithMachineID(-1)}, 0, ErrInvalidMachineID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewGeneratorPool(tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewGeneratorPool() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := p.ShardCount(); got != tt.want {
+				t.Errorf("ShardCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewGenerator_WithShardCount(t *testing.T) {
+	if _, err := NewGenerator(WithShardCount(4)); err != ErrUnsupportedOption {
+		t.Errorf("NewGenerator() error = %v, want %v", err, ErrUnsupportedOption)
+	}
+}
+
+func TestGeneratorPool_Shards(t *testing.T) {
+	p, err := NewGeneratorPool(WithDatacenterID(3), WithMachineID(8), WithShardCount(4))
+	if err != nil {
+		t.Fatalf("NewGeneratorPool() error = %v", err)
+	}
+	for i, g := range p.shards {
+		if got := g.DatacenterID(); got != 3 {
+			t.Errorf("shard %d DatacenterID() = %v, want %v", i, got, 3)
+		}
+		if got, want := g.MachineID(), 8+i; got != want {
+			t.Errorf("shard %d MachineID() = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestGeneratorPool_Next(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	p, err := NewGeneratorPool(WithClock(c), WithShardCount(8), WithSequenceExhaustionPolicy(ReturnError))
+	if err != nil {
+		t.Fatalf("NewGeneratorPool() error = %v", err)
+	}
+
+	const goroutines, perGoroutine = 16, 100
+	var mutex sync.Mutex
+	seen := make(map[SnowflakeID]bool)
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perGoroutine {
+				id, err := p.Next()
+				if err != nil {
+					t.Errorf("Next() error = %v", err)
+					return
+				}
+				ids, err := p.NextN(2)
+				if err != nil {
+					t.Errorf("NextN() error = %v", err)
+					return
+				}
+				mutex.Lock()
+				for _, id := range append(ids, id) {
+					if seen[id] {
+						t.Errorf("duplicated ID %v", id)
+					}
+					seen[id] = true
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if got, want := len(seen), goroutines*perGoroutine*3; got != want {
+		t.Errorf("generated %v IDs, want %v", got, want)
+	}
+}
@@ -0,0 +1,44 @@
+// Package proto provides SnowflakeIDProto, a Protocol Buffers message of a nullable Snowflake ID
+// to replace google.protobuf.Int64Value, whose JSON is a quoted string.
+//
+// Generate the Go code from snowflake_id.proto with buf generate.
+package proto
+
+import (
+	"fmt"
+	"strconv"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// FromInt64 returns a new SnowflakeIDProto of the ID v.
+func FromInt64(v int64) *SnowflakeIDProto {
+	return &SnowflakeIDProto{Value: strconv.FormatInt(v, 10)}
+}
+
+// FromSnowflakeID returns a new SnowflakeIDProto of id.
+func FromSnowflakeID(id idgenerator.SnowflakeID) *SnowflakeIDProto {
+	return FromInt64(int64(id))
+}
+
+// ToInt64 returns the ID as int64. It returns 0 if x is nil or the value is not a valid ID.
+// Use SnowflakeID to validate the value.
+func (x *SnowflakeIDProto) ToInt64() int64 {
+	id, err := x.SnowflakeID()
+	if err != nil {
+		return 0
+	}
+	return int64(id)
+}
+
+// SnowflakeID returns the ID. It returns idgenerator.ErrInvalidID if x is nil or the value is not a valid ID.
+func (x *SnowflakeIDProto) SnowflakeID() (idgenerator.SnowflakeID, error) {
+	if x == nil {
+		return 0, idgenerator.ErrInvalidID
+	}
+	v, err := strconv.ParseInt(x.Value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", idgenerator.ErrInvalidID, x.Value)
+	}
+	return idgenerator.SnowflakeID(v), nil
+}
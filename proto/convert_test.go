@@ -0,0 +1,99 @@
+package proto
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	"google.golang.org/protobuf/encoding/protojson"
+	protoio "google.golang.org/protobuf/proto"
+)
+
+func TestFromInt64(t *testing.T) {
+	tests := []struct {
+		name string
+		v    int64
+	}{
+		{"Example", 11234023837724673},
+		{"Over 2^53", 1<<53 + 1},
+		{"Maximum value", 1<<63 - 1},
+		{"Zero", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := FromInt64(tt.v)
+			if got := x.ToInt64(); got != tt.v {
+				t.Errorf("ToInt64() = %v, want %v", got, tt.v)
+			}
+
+			b, err := protoio.Marshal(x)
+			if err != nil {
+				t.Fatalf("proto.Marshal() error = %v", err)
+			}
+			var got SnowflakeIDProto
+			if err := protoio.Unmarshal(b, &got); err != nil {
+				t.Fatalf("proto.Unmarshal() error = %v", err)
+			}
+			if got.ToInt64() != tt.v {
+				t.Errorf("proto.Unmarshal() = %v, want %v", got.ToInt64(), tt.v)
+			}
+		})
+	}
+}
+
+func TestSnowflakeIDProto_JSON(t *testing.T) {
+	x := FromSnowflakeID(11234023837724673)
+	want := `{"value":"11234023837724673"}`
+
+	b, err := protojson.Marshal(x)
+	if err != nil {
+		t.Fatalf("protojson.Marshal() error = %v", err)
+	}
+	// protojson randomly adds spaces to discourage depending on the exact output, so compare after compacting.
+	var got map[string]any
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got["value"] != "11234023837724673" {
+		t.Errorf("protojson.Marshal() = %s, want %s", b, want)
+	}
+
+	b, err = json.Marshal(x)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+
+	var parsed SnowflakeIDProto
+	if err := protojson.Unmarshal([]byte(want), &parsed); err != nil {
+		t.Fatalf("protojson.Unmarshal() error = %v", err)
+	}
+	if id, err := parsed.SnowflakeID(); err != nil || id != 11234023837724673 {
+		t.Errorf("SnowflakeID() = %v, %v, want %v, nil", id, err, 11234023837724673)
+	}
+}
+
+func TestSnowflakeIDProto_SnowflakeID_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		x    *SnowflakeIDProto
+	}{
+		{"Nil", nil},
+		{"Empty", &SnowflakeIDProto{}},
+		{"Not a number", &SnowflakeIDProto{Value: "abc"}},
+		{"Overflow", &SnowflakeIDProto{Value: "9223372036854775808"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.x.SnowflakeID(); !errors.Is(err, idgenerator.ErrInvalidID) {
+				t.Errorf("SnowflakeID() error = %v, want %v", err, idgenerator.ErrInvalidID)
+			}
+			if got := tt.x.ToInt64(); got != 0 {
+				t.Errorf("ToInt64() = %v, want %v", got, 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+module github.com/kawabatas/go-id-generator/proto
+
+go 1.23
+
+require github.com/kawabatas/go-id-generator v0.0.0-00010101000000-000000000000
+
+require google.golang.org/protobuf v1.36.12
+
+replace github.com/kawabatas/go-id-generator => ../
@@ -0,0 +1,126 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: snowflake_id.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SnowflakeIDProto is a nullable Snowflake ID, which replaces google.protobuf.Int64Value.
+// The ID is a string field, so it is a quoted string in JSON with any encoder, including encoding/json,
+// which encodes an int64 as a number that loses precision over 2^53 in JavaScript.
+type SnowflakeIDProto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID in decimal.
+	Value         string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnowflakeIDProto) Reset() {
+	*x = SnowflakeIDProto{}
+	mi := &file_snowflake_id_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnowflakeIDProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnowflakeIDProto) ProtoMessage() {}
+
+func (x *SnowflakeIDProto) ProtoReflect() protoreflect.Message {
+	mi := &file_snowflake_id_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnowflakeIDProto.ProtoReflect.Descriptor instead.
+func (*SnowflakeIDProto) Descriptor() ([]byte, []int) {
+	return file_snowflake_id_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SnowflakeIDProto) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_snowflake_id_proto protoreflect.FileDescriptor
+
+const file_snowflake_id_proto_rawDesc = "" +
+	"\n" +
+	"\x12snowflake_id.proto\x12\x0eidgenerator.v1\"(\n" +
+	"\x10SnowflakeIDProto\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05valueB,Z*github.com/kawabatas/go-id-generator/protob\x06proto3"
+
+var (
+	file_snowflake_id_proto_rawDescOnce sync.Once
+	file_snowflake_id_proto_rawDescData []byte
+)
+
+func file_snowflake_id_proto_rawDescGZIP() []byte {
+	file_snowflake_id_proto_rawDescOnce.Do(func() {
+		file_snowflake_id_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_snowflake_id_proto_rawDesc), len(file_snowflake_id_proto_rawDesc)))
+	})
+	return file_snowflake_id_proto_rawDescData
+}
+
+var file_snowflake_id_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_snowflake_id_proto_goTypes = []any{
+	(*SnowflakeIDProto)(nil), // 0: idgenerator.v1.SnowflakeIDProto
+}
+var file_snowflake_id_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_snowflake_id_proto_init() }
+func file_snowflake_id_proto_init() {
+	if File_snowflake_id_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_snowflake_id_proto_rawDesc), len(file_snowflake_id_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_snowflake_id_proto_goTypes,
+		DependencyIndexes: file_snowflake_id_proto_depIdxs,
+		MessageInfos:      file_snowflake_id_proto_msgTypes,
+	}.Build()
+	File_snowflake_id_proto = out.File
+	file_snowflake_id_proto_goTypes = nil
+	file_snowflake_id_proto_depIdxs = nil
+}
@@ -0,0 +1,118 @@
+package idgenerator
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// minQuotaEviction is the number of counters of a QuotaManager from which it starts to evict the expired ones.
+const minQuotaEviction = 64
+
+// QuotaManager limits the number of IDs each tenant generates in fixed windows, such as every minute.
+// The counter of a tenant is evicted once its window ends, so unbounded tenant IDs do not grow the memory without bound.
+// A QuotaManager is safe for concurrent use by multiple goroutines.
+type QuotaManager struct {
+	clock    ClockSource
+	counters map[string]*WindowCounter
+	// evictAt is the number of counters at which the expired counters are evicted next.
+	evictAt int
+
+	// mutex is held for reading while a counter counts, and for writing while counters are added or evicted.
+	mutex sync.RWMutex
+}
+
+// NewQuotaManager returns a new QuotaManager.
+func NewQuotaManager() *QuotaManager {
+	return &QuotaManager{clock: systemClock{}, counters: map[string]*WindowCounter{}}
+}
+
+// Allow reports whether tenantID may generate an ID within limit IDs in the current window, and counts it if so.
+// The windows are aligned to the Unix epoch, e.g., every minute from hh:mm:00 for a window of a minute.
+// window should be the same on every call for the tenant. If it is not positive, no window can count the IDs,
+// so Allow returns false.
+func (q *QuotaManager) Allow(tenantID string, limit int, window time.Duration) bool {
+	if window <= 0 {
+		return false
+	}
+	now := q.clock.Now()
+	q.mutex.RLock()
+	if c, ok := q.counters[tenantID]; ok {
+		allowed := c.Allow(now, limit, window)
+		q.mutex.RUnlock()
+		return allowed
+	}
+	q.mutex.RUnlock()
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	c, ok := q.counters[tenantID]
+	if !ok {
+		q.evictExpired(now)
+		c = &WindowCounter{}
+		q.counters[tenantID] = c
+	}
+	return c.Allow(now, limit, window)
+}
+
+// evictExpired removes the counters whose window has ended by now. It scans the counters only when they have doubled
+// since the last scan, so that the cost is amortized over the added counters. The caller must hold q.mutex for writing.
+func (q *QuotaManager) evictExpired(now time.Time) {
+	if len(q.counters) < q.evictAt {
+		return
+	}
+	for tenantID, c := range q.counters {
+		if c.expired(now) {
+			delete(q.counters, tenantID)
+		}
+	}
+	q.evictAt = max(2*len(q.counters), minQuotaEviction)
+}
+
+// WindowCounter counts IDs in the current fixed window, and resets the count when the window boundary passes.
+// The zero value is ready to use. A WindowCounter is safe for concurrent use by multiple goroutines.
+type WindowCounter struct {
+	current atomic.Pointer[counterWindow]
+}
+
+// counterWindow is the count of a window. A new counterWindow replaces the old one to reset the count,
+// so that the start and the count of a window never mismatch.
+type counterWindow struct {
+	start, end int64
+	count      atomic.Int64
+}
+
+// Allow reports whether an ID is allowed within limit IDs in the window containing now, and counts it if so.
+// It returns false if window is not positive.
+func (c *WindowCounter) Allow(now time.Time, limit int, window time.Duration) bool {
+	if window <= 0 {
+		return false
+	}
+	start := now.Truncate(window)
+	w := c.window(start.UnixNano(), start.Add(window).UnixNano())
+	if w.count.Add(1) > int64(limit) {
+		w.count.Add(-1)
+		return false
+	}
+	return true
+}
+
+// expired reports whether the current window has ended by now, or there is no window yet.
+func (c *WindowCounter) expired(now time.Time) bool {
+	w := c.current.Load()
+	return w == nil || w.end <= now.UnixNano()
+}
+
+// window returns the window from start to end, or the current one if it starts later, e.g., when the clock moves backward.
+func (c *WindowCounter) window(start, end int64) *counterWindow {
+	for {
+		w := c.current.Load()
+		if w != nil && w.start >= start {
+			return w
+		}
+		next := &counterWindow{start: start, end: end}
+		if c.current.CompareAndSwap(w, next) {
+			return next
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package idgenerator
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestQuotaManager_Allow(t *testing.T) {
+	clock := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 30, 0, time.UTC))
+	q := NewQuotaManager()
+	q.clock = clock
+
+	tests := []struct {
+		name     string
+		tenantID string
+		advance  time.Duration
+		want     bool
+	}{
+		{"First", "a", 0, true},
+		{"Second", "a", 0, true},
+		{"Over the limit", "a", 0, false},
+		{"Another tenant", "b", 0, true},
+		{"Same window", "a", 29 * time.Second, false},
+		{"Next window", "a", time.Second, true},
+		{"Clock moved backward", "a", -time.Second, true},
+		{"Over the limit in the next window", "a", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.Advance(tt.advance)
+			if got := q.Allow(tt.tenantID, 2, time.Minute); got != tt.want {
+				t.Errorf("Allow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuotaManager_Allow_InvalidWindow(t *testing.T) {
+	q := NewQuotaManager()
+	for _, window := range []time.Duration{0, -time.Minute} {
+		if q.Allow("a", 2, window) {
+			t.Errorf("Allow(%v) = true, want false", window)
+		}
+	}
+}
+
+func TestQuotaManager_Allow_Eviction(t *testing.T) {
+	clock := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	q := NewQuotaManager()
+	q.clock = clock
+
+	// The counter of an active tenant is not evicted.
+	if !q.Allow("active", 1, time.Hour) {
+		t.Fatal("Allow() = false, want true")
+	}
+	for i := 0; i < 1000; i++ {
+		clock.Advance(time.Second)
+		q.Allow(strconv.Itoa(i), 1, time.Second)
+	}
+	if q.Allow("active", 1, time.Hour) {
+		t.Error("Allow() over the limit after eviction = true, want false")
+	}
+	if got := len(q.counters); got > minQuotaEviction {
+		t.Errorf("QuotaManager has %v counters, want at most %v", got, minQuotaEviction)
+	}
+}
+
+func TestWindowCounter_Allow_Concurrent(t *testing.T) {
+	var c WindowCounter
+	now := time.Now()
+	const goroutines, calls, limit = 8, 1000, 5000
+	var allowed atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				if c.Allow(now, limit, time.Hour) {
+					allowed.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := allowed.Load(); got != limit {
+		t.Errorf("Allow() allowed %v, want %v", got, limit)
+	}
+}
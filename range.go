@@ -0,0 +1,75 @@
+package idgenerator
+
+import "time"
+
+// MinIDForTime returns the smallest ID that can be generated at t.
+// All bits other than the timestamp are 0.
+//
+// Together with MaxIDForTime, it can be used to query IDs generated in a time range,
+// e.g. WHERE id BETWEEN MinIDForTime(from) AND MaxIDForTime(to).
+// If baseTime is zero, the default base time is used.
+func MinIDForTime(t time.Time, baseTime time.Time) (int64, error) {
+	ts, err := timestampForTime(t, baseTime)
+	if err != nil {
+		return 0, err
+	}
+	return ts << timestampBitShift, nil
+}
+
+// MaxIDForTime returns the largest ID that can be generated at t.
+// All bits other than the timestamp are 1.
+// If baseTime is zero, the default base time is used.
+func MaxIDForTime(t time.Time, baseTime time.Time) (int64, error) {
+	ts, err := timestampForTime(t, baseTime)
+	if err != nil {
+		return 0, err
+	}
+	return ts<<timestampBitShift | (int64(1)<<timestampBitShift - 1), nil
+}
+
+func timestampForTime(t time.Time, baseTime time.Time) (int64, error) {
+	if baseTime.IsZero() {
+		baseTime = defaultBaseTime
+	}
+	diffMilli := t.Sub(baseTime).Milliseconds()
+	if diffMilli < 0 {
+		return 0, ErrInvalidTimestamp
+	} else if diffMilli > maxTimestamp {
+		return 0, ErrOverLifeTime
+	}
+	return diffMilli, nil
+}
+
+// IDRange is a closed interval of IDs, e.g., to scope a query to a time window
+// with WHERE id BETWEEN Start AND End.
+type IDRange struct {
+	Start SnowflakeID
+	End   SnowflakeID
+}
+
+// NewIDRangeForTimeWindow returns the IDRange of all the IDs that can be generated from from to to, both inclusive.
+// If baseTime is zero, the default base time is used.
+func NewIDRangeForTimeWindow(from, to time.Time, baseTime time.Time) (IDRange, error) {
+	if to.Before(from) {
+		return IDRange{}, ErrInvalidTimeRange
+	}
+	start, err := MinIDForTime(from, baseTime)
+	if err != nil {
+		return IDRange{}, err
+	}
+	end, err := MaxIDForTime(to, baseTime)
+	if err != nil {
+		return IDRange{}, err
+	}
+	return IDRange{Start: SnowflakeID(start), End: SnowflakeID(end)}, nil
+}
+
+// Contains reports whether id is in the range.
+func (r IDRange) Contains(id SnowflakeID) bool {
+	return r.Start <= id && id <= r.End
+}
+
+// Overlaps reports whether the range and other have any ID in common.
+func (r IDRange) Overlaps(other IDRange) bool {
+	return r.Start <= other.End && other.Start <= r.End
+}
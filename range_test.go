@@ -0,0 +1,218 @@
+package idgenerator
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMinIDForTime(t *testing.T) {
+	type args struct {
+		t        time.Time
+		baseTime time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int64
+		wantErr bool
+	}{
+		{
+			"2024-02-01",
+			args{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+			11234023833600000,
+			false,
+		},
+		{
+			"Base time",
+			args{defaultBaseTime, time.Time{}},
+			0,
+			false,
+		},
+		{
+			"Error invalid timestamp",
+			args{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+			0,
+			true,
+		},
+		{
+			"Error over the maximum lifetime",
+			args{time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)},
+			0,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MinIDForTime(tt.args.t, tt.args.baseTime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MinIDForTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MinIDForTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxIDForTime(t *testing.T) {
+	type args struct {
+		t        time.Time
+		baseTime time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int64
+		wantErr bool
+	}{
+		{
+			"2024-02-01",
+			args{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+			11234023837794303,
+			false,
+		},
+		{
+			"Maximum lifetime",
+			args{defaultBaseTime.Add(time.Duration(maxTimestamp) * time.Millisecond), time.Time{}},
+			math.MaxInt64,
+			false,
+		},
+		{
+			"Error invalid timestamp",
+			args{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+			0,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MaxIDForTime(tt.args.t, tt.args.baseTime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaxIDForTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MaxIDForTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinMaxIDForTime_Contains(t *testing.T) {
+	at := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	id, err := NewSnowflakeID(WithTimestamp(at), WithDatacenterID(31), WithMachineID(15), WithSequenceNumber(1))
+	if err != nil {
+		t.Fatalf("NewSnowflakeID() error = %v", err)
+	}
+	minID, err := MinIDForTime(at, time.Time{})
+	if err != nil {
+		t.Fatalf("MinIDForTime() error = %v", err)
+	}
+	maxID, err := MaxIDForTime(at, time.Time{})
+	if err != nil {
+		t.Fatalf("MaxIDForTime() error = %v", err)
+	}
+	if id < minID || id > maxID {
+		t.Errorf("ID %v is not between %v and %v", id, minID, maxID)
+	}
+}
+
+func TestNewIDRangeForTimeWindow(t *testing.T) {
+	type args struct {
+		from, to time.Time
+		baseTime time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    IDRange
+		wantErr bool
+	}{
+		{
+			"1 millisecond",
+			args{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+			IDRange{Start: 11234023833600000, End: 11234023837794303},
+			false,
+		},
+		{
+			"2 milliseconds",
+			args{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, int(time.Millisecond), time.UTC), time.Time{}},
+			IDRange{Start: 11234023833600000, End: 11234023841988607},
+			false,
+		},
+		{
+			"Error to before from",
+			args{time.Date(2024, 2, 1, 0, 0, 0, 1, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+			IDRange{},
+			true,
+		},
+		{
+			"Error before base time",
+			args{time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+			IDRange{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewIDRangeForTimeWindow(tt.args.from, tt.args.to, tt.args.baseTime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewIDRangeForTimeWindow() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewIDRangeForTimeWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIDRange_Contains(t *testing.T) {
+	r := IDRange{Start: 10, End: 20}
+	tests := []struct {
+		name string
+		id   SnowflakeID
+		want bool
+	}{
+		{"Before start", 9, false},
+		{"Start", 10, true},
+		{"Middle", 15, true},
+		{"End", 20, true},
+		{"After end", 21, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Contains(tt.id); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIDRange_Overlaps(t *testing.T) {
+	r := IDRange{Start: 10, End: 20}
+	tests := []struct {
+		name  string
+		other IDRange
+		want  bool
+	}{
+		{"Before", IDRange{Start: 0, End: 9}, false},
+		{"Touching start", IDRange{Start: 0, End: 10}, true},
+		{"Inside", IDRange{Start: 12, End: 18}, true},
+		{"Enclosing", IDRange{Start: 0, End: 30}, true},
+		{"Touching end", IDRange{Start: 20, End: 30}, true},
+		{"After", IDRange{Start: 21, End: 30}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Overlaps(tt.other); got != tt.want {
+				t.Errorf("Overlaps() = %v, want %v", got, tt.want)
+			}
+			if got := tt.other.Overlaps(r); got != tt.want {
+				t.Errorf("Overlaps() reversed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package idgenerator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// WithRateLimit caps the throughput of a Generator at idsPerSecond IDs per second.
+//
+// Next and NextN block until the IDs are allowed, before acquiring the Generator's lock,
+// so that the rate limit and the sequence exhaustion policy do not wait for each other.
+// NextCtx and NextNCtx return ctx.Err() if ctx is done while waiting.
+// The rate is measured in real time, not with the clock specified by WithClock.
+// It returns ErrInvalidRateLimit if idsPerSecond is not positive and finite,
+// or the interval of 1s / idsPerSecond is shorter than a nanosecond or longer than time.Duration can hold.
+func WithRateLimit(idsPerSecond float64) Option {
+	return func(s *snowflake) error {
+		interval, err := rateInterval(idsPerSecond)
+		if err != nil {
+			return err
+		}
+		s.rateInterval = interval
+		return nil
+	}
+}
+
+// rateInterval returns the interval between IDs at idsPerSecond, 1s / idsPerSecond.
+func rateInterval(idsPerSecond float64) (time.Duration, error) {
+	if idsPerSecond <= 0 || math.IsInf(idsPerSecond, 0) || math.IsNaN(idsPerSecond) {
+		return 0, ErrInvalidRateLimit
+	}
+	// float64(math.MaxInt64) is 2^63, which already overflows time.Duration.
+	interval := float64(time.Second) / idsPerSecond
+	if interval < 1 || interval >= math.MaxInt64 {
+		return 0, fmt.Errorf("%w: the interval of %v IDs per second is out of the range of time.Duration", ErrInvalidRateLimit, idsPerSecond)
+	}
+	return time.Duration(interval), nil
+}
+
+// rateLimiter is a token bucket holding one token, which is refilled every interval.
+// Instead of a goroutine refilling the bucket, each caller reserves the time its tokens are refilled.
+type rateLimiter struct {
+	interval time.Duration
+
+	// next is the time the next token is refilled.
+	next  time.Time
+	mutex sync.Mutex
+}
+
+// newRateLimiter returns a rateLimiter, or nil if interval is zero. A nil rateLimiter never waits.
+func newRateLimiter(interval time.Duration) *rateLimiter {
+	if interval == 0 {
+		return nil
+	}
+	return &rateLimiter{interval: interval}
+}
+
+// wait blocks until n tokens are available.
+// If ctx is done while waiting, the reserved tokens are returned as far as nobody has reserved after them.
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	if l == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mutex.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(time.Duration(n) * l.interval)
+	reserved := l.next
+	l.mutex.Unlock()
+
+	// The first token is available at once, and the others are refilled one by one.
+	wait := time.Until(at.Add(time.Duration(n-1) * l.interval))
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		if l.next.Equal(reserved) {
+			l.next = at
+		}
+		l.mutex.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,120 @@
+package idgenerator
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	type args struct {
+		idsPerSecond float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{"idsPerSecond:0.5", args{0.5}, false},
+		{"idsPerSecond:1000", args{1000}, false},
+		{"idsPerSecond:1e9", args{1e9}, false},
+		{"Error idsPerSecond:0", args{0}, true},
+		{"Error idsPerSecond:-1", args{-1}, true},
+		{"Error idsPerSecond:+Inf", args{math.Inf(1)}, true},
+		{"Error idsPerSecond:NaN", args{math.NaN()}, true},
+		{"Error interval shorter than a nanosecond", args{2e9}, true},
+		{"Error interval overflowing time.Duration", args{1e-10}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenerator(WithRateLimit(tt.args.idsPerSecond))
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidRateLimit)) {
+				t.Errorf("NewGenerator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerator_Next_WithRateLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func(g *Generator) error
+	}{
+		{
+			"Next",
+			func(g *Generator) error {
+				for i := 0; i < 11; i++ {
+					if _, err := g.Next(); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			"NextN",
+			func(g *Generator) error {
+				_, err := g.NextN(11)
+				return err
+			},
+		},
+		{
+			"Next concurrently",
+			func(g *Generator) error {
+				var wg sync.WaitGroup
+				errs := make(chan error, 11)
+				for i := 0; i < 11; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						if _, err := g.Next(); err != nil {
+							errs <- err
+						}
+					}()
+				}
+				wg.Wait()
+				close(errs)
+				return <-errs
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(WithRateLimit(100), WithSequenceExhaustionPolicy(ReturnError))
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			start := time.Now()
+			if err := tt.generate(g); err != nil {
+				t.Fatalf("generate error = %v", err)
+			}
+			// 11 IDs at 100 IDs per second take 10 intervals of 10ms.
+			if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+				t.Errorf("11 IDs took %v, want at least %v", elapsed, 100*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestGenerator_NextCtx_WithRateLimit(t *testing.T) {
+	g, err := NewGenerator(WithRateLimit(1))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := g.NextCtx(ctx); err != context.DeadlineExceeded {
+		t.Errorf("NextCtx() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("NextCtx() returned after %v, want promptly", elapsed)
+	}
+}
@@ -0,0 +1,47 @@
+package idgenerator
+
+import (
+	"context"
+	"time"
+)
+
+// RecordedID is a Snowflake ID paired with the time when it was generated.
+//
+// The ID has only the resolution of the layout, such as a millisecond, while Time keeps the nanoseconds of the clock.
+// Store both, e.g., in a time-series database, to sort the IDs of the same millisecond by Time
+// while using ID as the durable identifier.
+type RecordedID struct {
+	ID   SnowflakeID
+	Time time.Time
+}
+
+// NextRecorded returns a new generated Snowflake ID with the time read from the clock source for it.
+// It is the same as NextRecordedCtx with context.Background().
+func (g *Generator) NextRecorded() (RecordedID, error) {
+	return g.NextRecordedCtx(context.Background())
+}
+
+// NextRecordedCtx returns a new generated Snowflake ID with the time read from the clock source for it.
+// If the Generator waits for the next millisecond on sequence exhaustion, the time is the one after waiting.
+func (g *Generator) NextRecordedCtx(ctx context.Context) (RecordedID, error) {
+	if g.interceptor == nil {
+		id, at, err := g.lockedNextRecorded(ctx)
+		if err != nil {
+			return RecordedID{}, err
+		}
+		return RecordedID{ID: id, Time: at}, nil
+	}
+	var at time.Time
+	ids, err := g.interceptor(ctx, g, "NextRecorded", func(ctx context.Context) ([]SnowflakeID, error) {
+		id, t, err := g.lockedNextRecorded(ctx)
+		if err != nil {
+			return nil, err
+		}
+		at = t
+		return []SnowflakeID{id}, nil
+	})
+	if err != nil {
+		return RecordedID{}, err
+	}
+	return RecordedID{ID: ids[0], Time: at}, nil
+}
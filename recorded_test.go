@@ -0,0 +1,63 @@
+package idgenerator
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGenerator_NextRecorded(t *testing.T) {
+	at := time.Date(2024, 2, 1, 0, 0, 0, 123456789, time.UTC)
+	c := NewSimulatedClock(at)
+	var names []string
+	g, err := NewGenerator(
+		WithClock(c),
+		WithDatacenterID(31),
+		WithMachineID(15),
+		WithInterceptor(func(ctx context.Context, g *Generator, name string, generate func(context.Context) ([]SnowflakeID, error)) ([]SnowflakeID, error) {
+			names = append(names, name)
+			return generate(ctx)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	got, err := g.NextRecorded()
+	if err != nil {
+		t.Fatalf("NextRecorded() error = %v", err)
+	}
+	if want := (RecordedID{ID: 11234024353624064, Time: at}); got != want {
+		t.Errorf("NextRecorded() = %v, want %v", got, want)
+	}
+
+	c.Advance(time.Nanosecond)
+	next, err := g.NextRecorded()
+	if err != nil {
+		t.Fatalf("NextRecorded() error = %v", err)
+	}
+	// Both IDs are in the same millisecond, and only Time tells which was generated first.
+	if next.ID != got.ID+1 || !next.Time.Equal(at.Add(time.Nanosecond)) {
+		t.Errorf("NextRecorded() = %v, want %v", next, RecordedID{ID: got.ID + 1, Time: at.Add(time.Nanosecond)})
+	}
+	if len(names) != 2 || names[0] != "NextRecorded" {
+		t.Errorf("Interceptor called with %v, want [NextRecorded NextRecorded]", names)
+	}
+}
+
+func TestGenerator_NextRecorded_SequenceExhausted(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	// More IDs than the sequence number of a millisecond allows, so that Generator waits for the next millisecond.
+	for i := 0; i < 2*(maxSequenceNumber+1); i++ {
+		got, err := g.NextRecorded()
+		if err != nil {
+			t.Fatalf("NextRecorded() error = %v", err)
+		}
+		if want := got.Time.UTC().Truncate(time.Millisecond); !ExtractTime(int64(got.ID), time.Time{}).Equal(want) {
+			t.Fatalf("NextRecorded() = %v, want the time in the millisecond of the ID", got)
+		}
+	}
+}
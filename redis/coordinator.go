@@ -0,0 +1,85 @@
+// Package redis shares the sequence numbers of Snowflake IDs among Generators through Redis.
+//
+// Generators with the same datacenter ID and machine ID, e.g., replicas of a service, generate the same IDs
+// in the same millisecond. With a RedisSequenceCoordinator, they take the sequence numbers from a counter in Redis
+// for each timestamp instead, at the cost of a round trip to Redis for each ID:
+//
+//	c := redis.NewRedisCoordinator(client)
+//	g, err := idgenerator.NewGenerator(idgenerator.WithSequenceProvider(c))
+//
+// It is a separate module, so that the idgenerator package does not depend on the Redis client.
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultKeyPrefix = "snowflake:seq:"
+	defaultTTL       = time.Second
+)
+
+// RedisSequenceCoordinator is an idgenerator.SequenceProvider that counts the sequence numbers
+// with INCR on a key of each timestamp, such as "snowflake:seq:2678400000".
+type RedisSequenceCoordinator struct {
+	client    goredis.Cmdable
+	keyPrefix string
+	ttl       time.Duration
+}
+
+var _ idgenerator.SequenceProvider = (*RedisSequenceCoordinator)(nil)
+
+// CoordOption configures a RedisSequenceCoordinator.
+type CoordOption func(*RedisSequenceCoordinator)
+
+// WithKeyPrefix specifies the prefix of the keys. The default is "snowflake:seq:".
+// Generators with different datacenter IDs or machine IDs can use different prefixes to have their own counters.
+func WithKeyPrefix(prefix string) CoordOption {
+	return func(c *RedisSequenceCoordinator) {
+		c.keyPrefix = prefix
+	}
+}
+
+// WithTTL specifies how long a key of a timestamp lives. The default is a second.
+//
+// A timestamp is used only for a millisecond, but a Generator whose clock is behind the others
+// may use it later. The TTL must be longer than the clock difference among the Generators,
+// or the counter starts from 0 again and the sequence numbers collide.
+func WithTTL(d time.Duration) CoordOption {
+	return func(c *RedisSequenceCoordinator) {
+		c.ttl = d
+	}
+}
+
+// NewRedisCoordinator returns a new RedisSequenceCoordinator counting the sequence numbers on client.
+func NewRedisCoordinator(client goredis.Cmdable, opts ...CoordOption) *RedisSequenceCoordinator {
+	c := &RedisSequenceCoordinator{
+		client:    client,
+		keyPrefix: defaultKeyPrefix,
+		ttl:       defaultTTL,
+	}
+	for _, f := range opts {
+		f(c)
+	}
+	return c
+}
+
+// NextSequence increments the counter of timestamp, and returns the sequence number starting from 0.
+// The counter and its TTL are set in a single transaction.
+func (c *RedisSequenceCoordinator) NextSequence(ctx context.Context, timestamp int64) (int, error) {
+	key := c.keyPrefix + strconv.FormatInt(timestamp, 10)
+	var incr *goredis.IntCmd
+	if _, err := c.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.PExpire(ctx, key, c.ttl)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return int(incr.Val() - 1), nil
+}
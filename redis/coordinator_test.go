@@ -0,0 +1,99 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	idgenerator "github.com/kawabatas/go-id-generator"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func newClient(t *testing.T) (*miniredis.Miniredis, *goredis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return mr, client
+}
+
+func TestRedisSequenceCoordinator_NextSequence(t *testing.T) {
+	mr, client := newClient(t)
+	c := NewRedisCoordinator(client, WithKeyPrefix("test:"), WithTTL(10*time.Millisecond))
+	ctx := context.Background()
+
+	for want := 0; want < 3; want++ {
+		got, err := c.NextSequence(ctx, 100)
+		if err != nil {
+			t.Fatalf("NextSequence() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("NextSequence() = %v, want %v", got, want)
+		}
+	}
+	got, err := c.NextSequence(ctx, 101)
+	if err != nil {
+		t.Fatalf("NextSequence() error = %v", err)
+	}
+	if got != 0 {
+		t.Errorf("NextSequence() = %v, want %v", got, 0)
+	}
+
+	if ttl := mr.TTL("test:100"); ttl <= 0 || ttl > 10*time.Millisecond {
+		t.Errorf("TTL = %v, want up to %v", ttl, 10*time.Millisecond)
+	}
+	mr.FastForward(10 * time.Millisecond)
+	if mr.Exists("test:100") {
+		t.Error("key exists after TTL")
+	}
+}
+
+func TestRedisSequenceCoordinator_Error(t *testing.T) {
+	mr, client := newClient(t)
+	c := NewRedisCoordinator(client)
+	mr.Close()
+	if _, err := c.NextSequence(context.Background(), 100); err == nil {
+		t.Error("NextSequence() error = nil, want error")
+	}
+}
+
+func TestWithSequenceProvider(t *testing.T) {
+	_, client := newClient(t)
+	c := NewRedisCoordinator(client)
+	clock := idgenerator.NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	// Generators with the same machine ID share the sequence numbers.
+	const generators, perGenerator = 4, 50
+	var mutex sync.Mutex
+	seen := make(map[idgenerator.SnowflakeID]bool)
+	var wg sync.WaitGroup
+	for range generators {
+		g, err := idgenerator.NewGenerator(idgenerator.WithClock(clock), idgenerator.WithMachineID(1), idgenerator.WithSequenceProvider(c))
+		if err != nil {
+			t.Fatalf("NewGenerator() error = %v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids, err := g.NextN(perGenerator)
+			if err != nil {
+				t.Errorf("NextN() error = %v", err)
+				return
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, id := range ids {
+				if seen[id] {
+					t.Errorf("duplicated ID %v", id)
+				}
+				seen[id] = true
+			}
+		}()
+	}
+	wg.Wait()
+	if got, want := len(seen), generators*perGenerator; got != want {
+		t.Errorf("generated %v IDs, want %v", got, want)
+	}
+}
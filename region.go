@@ -0,0 +1,96 @@
+package idgenerator
+
+import (
+	"fmt"
+	"sync"
+)
+
+// WithRegion embeds the region regionCode from 0 to 31 in the IDs as the 5-bit datacenter ID,
+// e.g., 0 for us-east-1 and 1 for eu-west-1, so that IDs can be routed to their region and
+// conflicts of cross-region replication are visible in the IDs. Specify the machine in the region by WithMachineID.
+//
+// IDs from different regions are still globally unique, but are not sorted by region:
+// they are sorted by time first, so the IDs of all regions interleave, and the clocks of the regions may differ a little.
+// Use RegionRegistry to name the regions. It returns ErrInvalidRegion if regionCode is out of range.
+func WithRegion(regionCode int) Option {
+	return func(s *snowflake) error {
+		if regionCode < 0 || regionCode > maxDatacenterID {
+			return fmt.Errorf("%w: %d is not from 0 to %d", ErrInvalidRegion, regionCode, maxDatacenterID)
+		}
+		s.datacenterID = regionCode
+		return nil
+	}
+}
+
+// ExtractRegion returns the region code of the ID generated with WithRegion, read from bits 17-21.
+func ExtractRegion(id SnowflakeID) int {
+	return ExtractDatacenterID(int64(id))
+}
+
+// RegionRegistry maps region codes to region names, such as "us-east-1".
+// Build the same RegionRegistry in every region, e.g., from a shared configuration.
+// A RegionRegistry is safe for concurrent use by multiple goroutines.
+type RegionRegistry struct {
+	names map[int]string
+	codes map[string]int
+
+	mutex sync.RWMutex
+}
+
+// NewRegionRegistry returns a new empty RegionRegistry.
+func NewRegionRegistry() *RegionRegistry {
+	return &RegionRegistry{names: map[int]string{}, codes: map[string]int{}}
+}
+
+// Register registers name as the name of the region code.
+// It returns ErrInvalidRegion if code is out of range, or either of code and name is already registered with another one.
+func (r *RegionRegistry) Register(code int, name string) error {
+	if code < 0 || code > maxDatacenterID {
+		return fmt.Errorf("%w: %d is not from 0 to %d", ErrInvalidRegion, code, maxDatacenterID)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if registered, ok := r.names[code]; ok && registered != name {
+		return fmt.Errorf("%w: %d is already registered as %q", ErrInvalidRegion, code, registered)
+	}
+	if registered, ok := r.codes[name]; ok && registered != code {
+		return fmt.Errorf("%w: %q is already registered as %d", ErrInvalidRegion, name, registered)
+	}
+	r.names[code] = name
+	r.codes[name] = code
+	return nil
+}
+
+// Name returns the name of the region code, and whether it is registered.
+func (r *RegionRegistry) Name(code int) (string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	name, ok := r.names[code]
+	return name, ok
+}
+
+// Code returns the code of the region name, and whether it is registered.
+func (r *RegionRegistry) Code(name string) (int, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	code, ok := r.codes[name]
+	return code, ok
+}
+
+// Region returns the name of the region of the ID generated with WithRegion, and whether it is registered.
+func (r *RegionRegistry) Region(id SnowflakeID) (string, bool) {
+	return r.Name(ExtractRegion(id))
+}
+
+// WithRegion is the package-level WithRegion with the code of the region name.
+// It returns ErrInvalidRegion if name is not registered.
+func (r *RegionRegistry) WithRegion(name string) Option {
+	return func(s *snowflake) error {
+		code, ok := r.Code(name)
+		if !ok {
+			return fmt.Errorf("%w: %q is not registered", ErrInvalidRegion, name)
+		}
+		return WithRegion(code)(s)
+	}
+}
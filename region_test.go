@@ -0,0 +1,79 @@
+package idgenerator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithRegion(t *testing.T) {
+	tests := []struct {
+		name       string
+		regionCode int
+		wantErr    error
+	}{
+		{"Zero", 0, nil},
+		{"Maximum", 31, nil},
+		{"Error negative", -1, ErrInvalidRegion},
+		{"Error over 31", 32, ErrInvalidRegion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := NewSnowflakeID(WithRegion(tt.regionCode), WithMachineID(15))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewSnowflakeID() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := ExtractRegion(SnowflakeID(id)); got != tt.regionCode {
+				t.Errorf("ExtractRegion() = %v, want %v", got, tt.regionCode)
+			}
+			if got := ExtractMachineID(id); got != 15 {
+				t.Errorf("ExtractMachineID() = %v, want %v", got, 15)
+			}
+		})
+	}
+}
+
+func TestRegionRegistry(t *testing.T) {
+	r := NewRegionRegistry()
+	for code, name := range []string{"us-east-1", "eu-west-1", "ap-southeast-1"} {
+		if err := r.Register(code, name); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+	}
+	errTests := []struct {
+		name       string
+		code       int
+		regionName string
+	}{
+		{"Code registered with another name", 0, "us-west-2"},
+		{"Name registered with another code", 3, "us-east-1"},
+		{"Code out of range", 32, "us-west-2"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.Register(tt.code, tt.regionName); !errors.Is(err, ErrInvalidRegion) {
+				t.Errorf("Register() error = %v, want %v", err, ErrInvalidRegion)
+			}
+		})
+	}
+
+	g, err := NewGenerator(r.WithRegion("eu-west-1"))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if name, ok := r.Region(id); name != "eu-west-1" || !ok {
+		t.Errorf("Region() = %q, %v, want %q, %v", name, ok, "eu-west-1", true)
+	}
+	if _, err := NewGenerator(r.WithRegion("us-west-2")); !errors.Is(err, ErrInvalidRegion) {
+		t.Errorf("NewGenerator() error = %v, want %v", err, ErrInvalidRegion)
+	}
+	if name, ok := r.Name(31); name != "" || ok {
+		t.Errorf("Name() = %q, %v, want %q, %v", name, ok, "", false)
+	}
+}
@@ -0,0 +1,66 @@
+package idgenerator
+
+import "sync/atomic"
+
+const (
+	reservationPending uint32 = iota
+	reservationCommitted
+	reservationAborted
+)
+
+// Reservation is an ID reserved by Generator.Reserve, which is either committed as issued or aborted to be reused.
+// A Reservation is safe for concurrent use by multiple goroutines, and its copies share the state.
+type Reservation struct {
+	rec   RecordedID
+	g     *Generator
+	state *atomic.Uint32
+}
+
+// Reserve claims a new ID, e.g., before a database write in a transaction,
+// so that Abort returns the ID to the Generator on rollback, and there is no gap in the IDs.
+//
+// A reservation neither committed nor aborted keeps the ID, as if it were committed.
+func (g *Generator) Reserve() (Reservation, error) {
+	rec, err := g.NextRecorded()
+	if err != nil {
+		return Reservation{}, err
+	}
+	return Reservation{rec: rec, g: g, state: new(atomic.Uint32)}, nil
+}
+
+// ID returns the reserved ID.
+func (r Reservation) ID() SnowflakeID {
+	return r.rec.ID
+}
+
+// Commit confirms the reserved ID as issued, and returns it.
+// It returns 0 if the reservation is already aborted, since the ID may be issued again.
+func (r Reservation) Commit() SnowflakeID {
+	if r.state.CompareAndSwap(reservationPending, reservationCommitted) || r.state.Load() == reservationCommitted {
+		return r.rec.ID
+	}
+	return 0
+}
+
+// Abort releases the reserved ID, and the next call of Next, NextCtx, NextRecorded, or Reserve returns it again.
+// Since the ID is older than the ones generated after it, Next does not return increasing IDs after an abort.
+// NextN never returns a released ID, so that it keeps returning IDs in increasing order.
+// Abort does nothing if the reservation is already committed or aborted.
+func (r Reservation) Abort() {
+	if !r.state.CompareAndSwap(reservationPending, reservationAborted) {
+		return
+	}
+	r.g.mutex.Lock()
+	defer r.g.mutex.Unlock()
+	r.g.released = append(r.g.released, r.rec)
+}
+
+// reuseReleased returns the oldest released ID, if any. The caller must hold g.mutex.
+func (g *Generator) reuseReleased() (RecordedID, bool) {
+	if len(g.released) == 0 {
+		return RecordedID{}, false
+	}
+	r := g.released[0]
+	g.released = g.released[1:]
+	return r, true
+}
@@ -0,0 +1,62 @@
+package idgenerator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerator_Reserve(t *testing.T) {
+	clock := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(clock), WithWorkerID(1007))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	committed, err := g.Reserve()
+	if err != nil {
+		t.Fatalf("Reserve() error = %v", err)
+	}
+	if got, want := committed.Commit(), committed.ID(); got != want {
+		t.Errorf("Commit() = %v, want %v", got, want)
+	}
+	committed.Abort()
+	if got, want := committed.Commit(), committed.ID(); got != want {
+		t.Errorf("Commit() after Abort() = %v, want %v", got, want)
+	}
+
+	aborted, err := g.Reserve()
+	if err != nil {
+		t.Fatalf("Reserve() error = %v", err)
+	}
+	aborted.Abort()
+	aborted.Abort()
+	if got := aborted.Commit(); got != 0 {
+		t.Errorf("Commit() after Abort() = %v, want %v", got, 0)
+	}
+
+	// NextN does not reuse the aborted ID.
+	ids, err := g.NextN(2)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if ids[0] <= aborted.ID() {
+		t.Errorf("NextN() = %v, want greater than %v", ids, aborted.ID())
+	}
+
+	clock.Advance(time.Millisecond)
+	rec, err := g.NextRecorded()
+	if err != nil {
+		t.Fatalf("NextRecorded() error = %v", err)
+	}
+	if rec.ID != aborted.ID() || !rec.Time.Equal(clock.Now().Add(-time.Millisecond)) {
+		t.Errorf("NextRecorded() = %+v, want the aborted ID %v", rec, aborted.ID())
+	}
+	// The aborted ID is reused only once, even if Abort is called twice.
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if id <= ids[1] {
+		t.Errorf("Next() = %v, want a new ID greater than %v", id, ids[1])
+	}
+}
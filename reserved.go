@@ -0,0 +1,53 @@
+package idgenerator
+
+import "fmt"
+
+// reservedBit is bit 63 of an ID, which is unused in the Snowflake spec.
+const reservedBit = SnowflakeID(-1 << 63)
+
+// WithReservedBit sets bit 63, which is unused and always 0 in the Snowflake spec, to 1 when v is true,
+// e.g., to distinguish imported IDs from generated ones, or synthetic IDs from real ones.
+//
+// An ID with the bit set is negative as int64, but is still valid as uint64 (see NewSnowflakeIDUint64).
+// Such IDs do not sort correctly as int64: they are smaller than every ID without the bit regardless of the time.
+// ParseSnowflakeID returns ErrInvalidID for them, so clear the bit with ClearReservedBit before parsing.
+//
+// The bit is also the flag of WithLayoutVersion, whose version is in the top 2 bits of the sequence number,
+// so WithReservedBit(true) with WithLayoutVersion returns ErrUnsupportedOption. To tell the IDs apart,
+// the top 2 bits of the sequence number are always 0 with the bit set, which is not a layout version,
+// in the layouts whose top 2 bits of the sequence number are bits 10-11, such as LayoutTwitterSnowflake.
+// So the sequence number is 2 bits narrower, e.g., up to 1024 IDs per millisecond in LayoutTwitterSnowflake.
+// It returns ErrInvalidLayout if the layout uses all 64 bits.
+func WithReservedBit(v bool) Option {
+	return func(s *snowflake) error {
+		s.reservedBit = v
+		return nil
+	}
+}
+
+func (s *snowflake) getReservedBit() SnowflakeID {
+	if !s.reservedBit {
+		return 0
+	}
+	return reservedBit
+}
+
+func (s *snowflake) validateReservedBit(layout Layout) error {
+	if !s.reservedBit {
+		return nil
+	}
+	if total := layout.TimestampBits + layout.DatacenterBits + layout.MachineBits + layout.SequenceBits; total > 63 {
+		return fmt.Errorf("%w: no reserved bit in %d bits", ErrInvalidLayout, total)
+	}
+	return nil
+}
+
+// IsReservedBitSet reports whether bit 63 of id is set by WithReservedBit(true).
+func IsReservedBitSet(id SnowflakeID) bool {
+	return id&reservedBit != 0
+}
+
+// ClearReservedBit returns id with bit 63 cleared, which is the ID in the layout without WithReservedBit(true).
+func ClearReservedBit(id SnowflakeID) SnowflakeID {
+	return id &^ reservedBit
+}
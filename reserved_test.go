@@ -0,0 +1,169 @@
+package idgenerator
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWithReservedBit(t *testing.T) {
+	tests := []struct {
+		name string
+		v    bool
+		want int64
+	}{
+		{"Set", true, 11234023837724673 | math.MinInt64},
+		{"Not set", false, 11234023837724673},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSnowflakeID(
+				WithTimestamp(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+				WithDatacenterID(31),
+				WithMachineID(15),
+				WithSequenceNumber(1),
+				WithReservedBit(tt.v),
+			)
+			if err != nil {
+				t.Fatalf("NewSnowflakeID() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NewSnowflakeID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithReservedBit_Uint64(t *testing.T) {
+	got, err := NewSnowflakeIDUint64(
+		WithTimestamp(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+		WithDatacenterID(31),
+		WithMachineID(15),
+		WithSequenceNumber(1),
+		WithReservedBit(true),
+	)
+	if err != nil {
+		t.Fatalf("NewSnowflakeIDUint64() error = %v", err)
+	}
+	if want := uint64(1)<<63 | 11234023837724673; got != want {
+		t.Errorf("NewSnowflakeIDUint64() = %v, want %v", got, want)
+	}
+}
+
+func TestWithReservedBit_Generator(t *testing.T) {
+	g, err := NewGenerator(WithDatacenterID(31), WithMachineID(15), WithReservedBit(true))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if got := g.MaxSequenceNumber(); got != 1023 {
+		t.Errorf("MaxSequenceNumber() = %v, want %v", got, 1023)
+	}
+	// More IDs than the narrower sequence number in a time unit.
+	ids, err := g.NextN(3000)
+	if err != nil {
+		t.Fatalf("Generator.NextN() error = %v", err)
+	}
+	for i, id := range ids {
+		if !IsReservedBitSet(id) {
+			t.Errorf("Generator.NextN() = %v, want the reserved bit set", id)
+		}
+		if i > 0 && id <= ids[i-1] {
+			t.Errorf("Generator.NextN() returned %v after %v, want increasing IDs", id, ids[i-1])
+		}
+		c, err := ParseSnowflakeID(int64(ClearReservedBit(id)), time.Time{}, Layout{})
+		if err != nil {
+			t.Fatalf("ParseSnowflakeID() error = %v", err)
+		}
+		if c.DatacenterID != 31 || c.MachineID != 15 {
+			t.Errorf("ParseSnowflakeID() = %v, want datacenter ID 31 and machine ID 15", c)
+		}
+	}
+
+	a, err := NewAtomicGenerator(WithReservedBit(true))
+	if err != nil {
+		t.Fatalf("NewAtomicGenerator() error = %v", err)
+	}
+	if id, err := a.Next(); err != nil || !IsReservedBitSet(id) {
+		t.Errorf("AtomicGenerator.Next() = %v, %v, want the reserved bit set", id, err)
+	}
+}
+
+func TestWithReservedBit_Parse(t *testing.T) {
+	// Registered versions do not change how the IDs with the reserved bit are read.
+	for v := uint8(1); v <= maxLayoutVersion; v++ {
+		registerLayoutVersion(t, v, layoutV1)
+	}
+
+	at := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	for _, seq := range []int{5, 1023} {
+		id, err := NewSnowflakeID(WithTimestamp(at), WithDatacenterID(31), WithMachineID(15), WithSequenceNumber(seq), WithReservedBit(true))
+		if err != nil {
+			t.Fatalf("NewSnowflakeID() error = %v", err)
+		}
+		if _, err := ParseSnowflakeID(id, time.Time{}, Layout{}); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("ParseSnowflakeID(%d) error = %v, want %v", id, err, ErrInvalidID)
+		}
+		got, err := ParseSnowflakeID(int64(ClearReservedBit(SnowflakeID(id))), time.Time{}, Layout{})
+		if err != nil {
+			t.Fatalf("ParseSnowflakeID() error = %v", err)
+		}
+		want := SnowflakeComponents{Timestamp: at, DatacenterID: 31, MachineID: 15, SequenceNumber: seq}
+		if got != want {
+			t.Errorf("ParseSnowflakeID() = %+v, want %+v", got, want)
+		}
+		if layout, err := DetectLayout(SnowflakeID(id)); err != nil || layout != LayoutTwitterSnowflake {
+			t.Errorf("DetectLayout() = %+v, %v, want %+v", layout, err, LayoutTwitterSnowflake)
+		}
+		if got := ExtractTime(id, time.Time{}); !got.Equal(at) {
+			t.Errorf("ExtractTime() = %v, want %v", got, at)
+		}
+		if got := ExtractWorkerID(id); got != 1007 {
+			t.Errorf("ExtractWorkerID() = %v, want %v", got, 1007)
+		}
+		if got := ExtractSequenceNumber(id); got != seq {
+			t.Errorf("ExtractSequenceNumber() = %v, want %v", got, seq)
+		}
+	}
+}
+
+func TestWithReservedBit_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{"No reserved bit", []Option{WithLayout(Layout{TimestampBits: 44, MachineBits: 8, SequenceBits: 12})}, ErrInvalidLayout},
+		{"Sequence number in the tag bits", []Option{WithSequenceNumber(1024)}, ErrInvalidSequenceNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGenerator(append(tt.opts, WithReservedBit(true))...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewGenerator() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClearReservedBit(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     SnowflakeID
+		want   SnowflakeID
+		wantOK bool
+	}{
+		{"Set", 11234023837724673 | math.MinInt64, 11234023837724673, true},
+		{"Not set", 11234023837724673, 11234023837724673, false},
+		{"Zero", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsReservedBitSet(tt.id); got != tt.wantOK {
+				t.Errorf("IsReservedBitSet() = %v, want %v", got, tt.wantOK)
+			}
+			if got := ClearReservedBit(tt.id); got != tt.want {
+				t.Errorf("ClearReservedBit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package idgenerator
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// seededLifetime is the range from the default base time in which a SeededGenerator starts its simulated clock.
+const seededLifetime = 10 * 365 * 24 * time.Hour
+
+// SeededGenerator is an IDGenerator for tests, which returns the same IDs for the same seed,
+// e.g., to write golden files or to seed a database.
+//
+// Its simulated clock starts at a time picked by math/rand seeded with the seed,
+// and moves forward by 1ms on every generated ID, so the sequence number is always 0.
+// A SeededGenerator is safe for concurrent use by multiple goroutines.
+type SeededGenerator struct {
+	seed         int64
+	datacenterID int
+	machineID    int
+	timestamp    int64
+
+	mutex sync.Mutex
+}
+
+var _ IDGenerator = (*SeededGenerator)(nil)
+
+// NewSeededGenerator returns a SeededGenerator for seed, which generates IDs with workerID.
+// See WithWorkerID for workerID. It panics if workerID is invalid.
+func NewSeededGenerator(seed int64, workerID int) *SeededGenerator {
+	if workerID < 0 || workerID > maxWorkerID {
+		panic(fmt.Errorf("%w: %d", ErrInvalidWorkerID, workerID))
+	}
+	g := &SeededGenerator{
+		seed:         seed,
+		datacenterID: workerID >> machineBitRange,
+		machineID:    workerID & maxMachineID,
+	}
+	g.Reset()
+	return g
+}
+
+// Reset restarts the simulated clock, so that the SeededGenerator returns the same IDs again from the first one.
+func (g *SeededGenerator) Reset() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	rnd := rand.New(rand.NewSource(g.seed))
+	g.timestamp = rnd.Int63n(seededLifetime.Milliseconds())
+}
+
+// Next returns the next ID.
+func (g *SeededGenerator) Next() (SnowflakeID, error) {
+	ids, err := g.NextN(1)
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// NextN returns the next n IDs, which are the same as the ones returned by n calls of Next.
+func (g *SeededGenerator) NextN(n int) ([]SnowflakeID, error) {
+	if n <= 0 {
+		return nil, ErrInvalidCount
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.timestamp+int64(n) > maxTimestamp {
+		return nil, ErrOverLifeTime
+	}
+	ids := make([]SnowflakeID, n)
+	for i := range ids {
+		g.timestamp++
+		ids[i] = SnowflakeID(LayoutTwitterSnowflake.compose(g.timestamp, g.datacenterID, g.machineID, 0))
+	}
+	return ids, nil
+}
@@ -0,0 +1,74 @@
+package idgenerator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSeededGenerator_Next(t *testing.T) {
+	g := NewSeededGenerator(42, 1007)
+	var ids []SnowflakeID
+	for i := 0; i < 3; i++ {
+		id, err := g.Next()
+		if err != nil {
+			t.Fatalf("SeededGenerator.Next() error = %v", err)
+		}
+		ids = append(ids, id)
+	}
+	for i, id := range ids {
+		if got := ExtractWorkerID(int64(id)); got != 1007 {
+			t.Errorf("SeededGenerator.Next() worker ID = %v, want %v", got, 1007)
+		}
+		if got := ExtractSequenceNumber(int64(id)); got != 0 {
+			t.Errorf("SeededGenerator.Next() sequence number = %v, want %v", got, 0)
+		}
+		if i > 0 {
+			if got, want := id.timestamp()-ids[i-1].timestamp(), int64(1); got != want {
+				t.Errorf("SeededGenerator.Next() timestamp difference = %v, want %v", got, want)
+			}
+		}
+	}
+
+	same, err := NewSeededGenerator(42, 1007).NextN(3)
+	if err != nil {
+		t.Fatalf("SeededGenerator.NextN() error = %v", err)
+	}
+	if !reflect.DeepEqual(same, ids) {
+		t.Errorf("SeededGenerator.NextN() = %v, want %v", same, ids)
+	}
+	other, err := NewSeededGenerator(43, 1007).NextN(3)
+	if err != nil {
+		t.Fatalf("SeededGenerator.NextN() error = %v", err)
+	}
+	if reflect.DeepEqual(other, ids) {
+		t.Errorf("SeededGenerator.NextN() with another seed = %v, want different IDs", other)
+	}
+
+	g.Reset()
+	again, err := g.NextN(3)
+	if err != nil {
+		t.Fatalf("SeededGenerator.NextN() error = %v", err)
+	}
+	if !reflect.DeepEqual(again, ids) {
+		t.Errorf("SeededGenerator.NextN() after Reset() = %v, want %v", again, ids)
+	}
+}
+
+func TestSeededGenerator_NextN_Error(t *testing.T) {
+	g := NewSeededGenerator(42, 0)
+	if _, err := g.NextN(0); err != ErrInvalidCount {
+		t.Errorf("SeededGenerator.NextN() error = %v, want %v", err, ErrInvalidCount)
+	}
+	if _, err := g.NextN(int(maxTimestamp)); err != ErrOverLifeTime {
+		t.Errorf("SeededGenerator.NextN() error = %v, want %v", err, ErrOverLifeTime)
+	}
+}
+
+func TestNewSeededGenerator_Panic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewSeededGenerator() did not panic with an invalid worker ID")
+		}
+	}()
+	NewSeededGenerator(42, maxWorkerID+1)
+}
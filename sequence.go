@@ -0,0 +1,56 @@
+package idgenerator
+
+import (
+	"context"
+	"fmt"
+)
+
+// SequenceProvider hands out the sequence numbers of IDs on behalf of a Generator,
+// e.g., to share them among Generators with the same datacenter ID and machine ID.
+// See the redis sub-package for the Redis implementation.
+type SequenceProvider interface {
+	// NextSequence returns the next sequence number of timestamp, starting from 0.
+	// timestamp is the elapsed time since the base time in the time unit of the layout.
+	// A sequence number beyond the layout makes the Generator behave as on sequence exhaustion,
+	// and ask again for the next timestamp. A negative sequence number makes the Generator return ErrInvalidSequenceNumber.
+	NextSequence(ctx context.Context, timestamp int64) (int, error)
+}
+
+// WithSequenceProvider makes a Generator take the sequence numbers from sp instead of counting them by itself.
+//
+// sp is called while the Generator holds its lock, so a slow SequenceProvider, such as one over the network,
+// limits the throughput of the Generator. WithSequenceNumber is ignored.
+func WithSequenceProvider(sp SequenceProvider) Option {
+	return func(s *snowflake) error {
+		if sp == nil {
+			return ErrInvalidSequenceProvider
+		}
+		s.sequenceProvider = sp
+		return nil
+	}
+}
+
+// nextFromProvider generates a new Snowflake ID at ts with the sequence number from g.sequenceProvider.
+// The caller must hold g.mutex.
+func (g *Generator) nextFromProvider(ctx context.Context, ts int64) (SnowflakeID, error) {
+	for {
+		seq, err := g.sequenceProvider.NextSequence(ctx, ts)
+		if err != nil {
+			return 0, err
+		}
+		if seq < 0 {
+			return 0, fmt.Errorf("%w: %d from the SequenceProvider", ErrInvalidSequenceNumber, seq)
+		}
+		g.lastTimestamp = ts
+		if seq <= g.layout.maxSequenceNumber() {
+			g.sequenceNumber = seq
+			break
+		}
+		g.metrics.ObserveSequenceOverflow(g)
+		if ts, err = g.waitNextTimestamp(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	return g.emit(ts, g.sequenceNumber), nil
+}
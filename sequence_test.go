@@ -0,0 +1,103 @@
+package idgenerator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// sharedSequence is a SequenceProvider shared by multiple Generators.
+type sharedSequence struct {
+	counts map[int64]int
+	err    error
+}
+
+func (s *sharedSequence) NextSequence(_ context.Context, timestamp int64) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	seq := s.counts[timestamp]
+	s.counts[timestamp]++
+	return seq, nil
+}
+
+func TestWithSequenceProvider(t *testing.T) {
+	if _, err := NewGenerator(WithSequenceProvider(nil)); err != ErrInvalidSequenceProvider {
+		t.Errorf("NewGenerator() error = %v, want %v", err, ErrInvalidSequenceProvider)
+	}
+
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	sp := &sharedSequence{counts: make(map[int64]int)}
+	// Two Generators with the same machine ID do not collide.
+	g1, err := NewGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15), WithSequenceProvider(sp))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	g2, err := NewGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15), WithSequenceProvider(sp))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	for i, g := range []*Generator{g1, g2, g1} {
+		id, err := g.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if want := SnowflakeID(11234023837724672 + i); id != want {
+			t.Errorf("Next() = %v, want %v", id, want)
+		}
+	}
+}
+
+func TestWithSequenceProvider_Exhausted(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	sp := &sharedSequence{counts: make(map[int64]int)}
+	g, err := NewGenerator(WithClock(c), WithSequenceProvider(sp), WithSequenceExhaustionPolicy(ReturnError))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if _, err := g.NextN(maxSequenceNumber + 1); err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if _, err := g.Next(); err != ErrSequenceExhausted {
+		t.Fatalf("Next() error = %v, want %v", err, ErrSequenceExhausted)
+	}
+
+	// The next millisecond starts from the sequence number 0.
+	c.Advance(time.Millisecond)
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if got := ExtractSequenceNumber(int64(id)); got != 0 {
+		t.Errorf("Next() sequence number = %v, want %v", got, 0)
+	}
+}
+
+func TestWithSequenceProvider_Error(t *testing.T) {
+	errProvider := errors.New("provider error")
+	g, err := NewGenerator(WithSequenceProvider(&sharedSequence{err: errProvider}))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if _, err := g.Next(); err != errProvider {
+		t.Errorf("Next() error = %v, want %v", err, errProvider)
+	}
+}
+
+func TestWithSequenceProvider_Negative(t *testing.T) {
+	sp := &sharedSequence{counts: map[int64]int{}}
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c), WithSequenceProvider(sp))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	ts, err := elapsedTimestamp(c.Now(), defaultBaseTime, LayoutTwitterSnowflake)
+	if err != nil {
+		t.Fatalf("elapsedTimestamp() error = %v", err)
+	}
+	sp.counts[ts] = -1
+	if _, err := g.Next(); !errors.Is(err, ErrInvalidSequenceNumber) {
+		t.Errorf("Next() error = %v, want %v", err, ErrInvalidSequenceNumber)
+	}
+}
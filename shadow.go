@@ -0,0 +1,105 @@
+package idgenerator
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+// ShadowGenerator generates IDs with a primary IDGenerator, and also with a shadow IDGenerator to compare them,
+// e.g., to validate a new ID generation strategy in production before migrating to it.
+// The ID of the primary is always returned, and the ID of the shadow is discarded.
+//
+// The IDs are discrepant when they do not have the same timestamp resolution and range,
+// i.e., the layouts of the generators have different time units or timestamp bits,
+// or the IDs have different bit lengths, so they would not fit in the same storage or sort together.
+// The layout of a generator is taken from its Layout method if any, such as Generator.Layout,
+// and is LayoutTwitterSnowflake otherwise.
+//
+// The shadow is called synchronously after the primary, so it adds to the latency of Next.
+// A ShadowGenerator is safe for concurrent use by multiple goroutines if both generators are.
+type ShadowGenerator struct {
+	primary  IDGenerator
+	shadow   IDGenerator
+	observer func(primary, shadow SnowflakeID)
+
+	// sameLayout reports whether the layouts have the same timestamp resolution and range.
+	sameLayout bool
+
+	calls         atomic.Uint64
+	discrepancies atomic.Uint64
+	shadowErrors  atomic.Uint64
+}
+
+var _ IDGenerator = (*ShadowGenerator)(nil)
+
+// ShadowStats is the statistics of a ShadowGenerator.
+type ShadowStats struct {
+	// Calls is the number of IDs generated by both generators.
+	Calls uint64
+	// Discrepancies is the number of discrepant pairs of IDs.
+	Discrepancies uint64
+	// ShadowErrors is the number of IDs the shadow failed to generate.
+	ShadowErrors uint64
+}
+
+// NewShadowGenerator returns a new ShadowGenerator, which calls observer with every discrepant pair of IDs.
+// observer is called synchronously, so it should return quickly, e.g., by logging them.
+// It returns ErrInvalidObserver if observer is nil.
+func NewShadowGenerator(primary, shadow IDGenerator, observer func(primary, shadow SnowflakeID)) (*ShadowGenerator, error) {
+	if observer == nil {
+		return nil, ErrInvalidObserver
+	}
+	pl, sl := layoutOf(primary), layoutOf(shadow)
+	return &ShadowGenerator{
+		primary:    primary,
+		shadow:     shadow,
+		observer:   observer,
+		sameLayout: pl.timeUnit() == sl.timeUnit() && pl.maxTimestamp() == sl.maxTimestamp(),
+	}, nil
+}
+
+func layoutOf(g IDGenerator) Layout {
+	if l, ok := g.(interface{ Layout() Layout }); ok {
+		return l.Layout()
+	}
+	return LayoutTwitterSnowflake
+}
+
+// Stats returns the statistics so far.
+func (g *ShadowGenerator) Stats() ShadowStats {
+	return ShadowStats{
+		Calls:         g.calls.Load(),
+		Discrepancies: g.discrepancies.Load(),
+		ShadowErrors:  g.shadowErrors.Load(),
+	}
+}
+
+// Next returns a new ID generated by the primary. An error of the shadow is only counted in Stats.
+func (g *ShadowGenerator) Next() (SnowflakeID, error) {
+	ids, err := g.NextN(1)
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// NextN returns n new IDs generated by the primary, and compares them with n IDs generated by the shadow in order.
+func (g *ShadowGenerator) NextN(n int) ([]SnowflakeID, error) {
+	ids, err := g.primary.NextN(n)
+	if err != nil {
+		return nil, err
+	}
+	shadowIDs, err := g.shadow.NextN(n)
+	if err != nil || len(shadowIDs) != len(ids) {
+		g.shadowErrors.Add(uint64(len(ids)))
+		return ids, nil
+	}
+	g.calls.Add(uint64(len(ids)))
+	for i, id := range ids {
+		if !g.sameLayout || bits.Len64(uint64(id)) != bits.Len64(uint64(shadowIDs[i])) {
+			g.discrepancies.Add(1)
+			g.observer(id, shadowIDs[i])
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,79 @@
+package idgenerator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewShadowGenerator(t *testing.T) {
+	if _, err := NewShadowGenerator(SequentialMockGenerator(0), SequentialMockGenerator(0), nil); err != ErrInvalidObserver {
+		t.Errorf("NewShadowGenerator() error = %v, want %v", err, ErrInvalidObserver)
+	}
+}
+
+func TestShadowGenerator_NextN(t *testing.T) {
+	newGenerator := func(t *testing.T, layout Layout) *Generator {
+		t.Helper()
+		g, err := NewGeneratorWithLayout(layout)
+		if err != nil {
+			t.Fatalf("NewGeneratorWithLayout() error = %v", err)
+		}
+		return g
+	}
+	tests := []struct {
+		name              string
+		primary           IDGenerator
+		shadow            IDGenerator
+		wantDiscrepancies uint64
+		wantShadowErrors  uint64
+	}{
+		{"Same layout", newGenerator(t, LayoutTwitterSnowflake), newGenerator(t, LayoutTwitterSnowflake), 0, 0},
+		{"Different time unit", newGenerator(t, LayoutTwitterSnowflake), newGenerator(t, LayoutSecondResolution), 3, 0},
+		{"Different range", SequentialMockGenerator(1), SequentialMockGenerator(1 << 40), 3, 0},
+		{"Shadow error", SequentialMockGenerator(1), NewMockGenerator(), 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var observed [][2]SnowflakeID
+			g, err := NewShadowGenerator(tt.primary, tt.shadow, func(primary, shadow SnowflakeID) {
+				observed = append(observed, [2]SnowflakeID{primary, shadow})
+			})
+			if err != nil {
+				t.Fatalf("NewShadowGenerator() error = %v", err)
+			}
+			id, err := g.Next()
+			if err != nil {
+				t.Fatalf("Next() error = %v", err)
+			}
+			ids, err := g.NextN(2)
+			if err != nil {
+				t.Fatalf("NextN() error = %v", err)
+			}
+			if tt.wantShadowErrors == 0 {
+				for _, pair := range observed {
+					if pair[0] != id && pair[0] != ids[0] && pair[0] != ids[1] {
+						t.Errorf("observer called with primary %v, want one of the returned IDs", pair[0])
+					}
+				}
+			}
+			if got := uint64(len(observed)); got != tt.wantDiscrepancies {
+				t.Errorf("observer called %v times, want %v", got, tt.wantDiscrepancies)
+			}
+			want := ShadowStats{Calls: 3 - tt.wantShadowErrors, Discrepancies: tt.wantDiscrepancies, ShadowErrors: tt.wantShadowErrors}
+			if got := g.Stats(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Stats() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestShadowGenerator_Next_PrimaryError(t *testing.T) {
+	g, err := NewShadowGenerator(NewMockGenerator(), SequentialMockGenerator(1), func(primary, shadow SnowflakeID) {})
+	if err != nil {
+		t.Fatalf("NewShadowGenerator() error = %v", err)
+	}
+	if _, err := g.Next(); !errors.Is(err, ErrMockExhausted) {
+		t.Errorf("Next() error = %v, want %v", err, ErrMockExhausted)
+	}
+}
@@ -0,0 +1,30 @@
+package idgenerator
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// ShardIndex returns the index of the shard in [0, numShards) to route the ID to.
+//
+// The index is derived from the worker ID, which is the combination of the datacenter ID and the machine ID,
+// so IDs generated by the same Generator land on the same shard. Use HashShard for uniform distribution.
+// It panics if numShards <= 0.
+func ShardIndex(id SnowflakeID, numShards int) int {
+	if numShards <= 0 {
+		panic("idgenerator: invalid number of shards")
+	}
+	return ExtractWorkerID(int64(id)) % numShards
+}
+
+// HashShard returns the index of the shard in [0, numShards) to route the ID to,
+// distributing IDs uniformly with the FNV-1a hash of the ID.
+// It panics if numShards <= 0.
+func HashShard(id SnowflakeID, numShards int) int {
+	if numShards <= 0 {
+		panic("idgenerator: invalid number of shards")
+	}
+	h := fnv.New32a()
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(id)))
+	return int(h.Sum32() % uint32(numShards))
+}
@@ -0,0 +1,92 @@
+package idgenerator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShardIndex(t *testing.T) {
+	type args struct {
+		id        SnowflakeID
+		numShards int
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		// The worker ID of 11234023837724673 is 1007.
+		{"numShards:1", args{11234023837724673, 1}, 0},
+		{"numShards:10", args{11234023837724673, 10}, 7},
+		{"numShards:1024", args{11234023837724673, 1024}, 1007},
+		{"numShards:2000", args{11234023837724673, 2000}, 1007},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShardIndex(tt.args.id, tt.args.numShards); got != tt.want {
+				t.Errorf("ShardIndex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShardIndex_Locality(t *testing.T) {
+	g, err := NewGenerator(WithDatacenterID(3), WithMachineID(7))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	ids, err := g.NextN(1000)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	want := ShardIndex(ids[0], 16)
+	for _, id := range ids {
+		if got := ShardIndex(id, 16); got != want {
+			t.Fatalf("ShardIndex(%v) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestHashShard(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c), WithDatacenterID(3), WithMachineID(7))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	ids, err := g.NextN(4096)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+
+	const numShards = 16
+	counts := make([]int, numShards)
+	for _, id := range ids {
+		i := HashShard(id, numShards)
+		if i < 0 || i >= numShards {
+			t.Fatalf("HashShard(%v) = %v, want in [0, %v)", id, i, numShards)
+		}
+		if again := HashShard(id, numShards); again != i {
+			t.Fatalf("HashShard(%v) = %v, then %v, want stable", id, i, again)
+		}
+		counts[i]++
+	}
+	// IDs of a single Generator are spread over all shards, 256 on average.
+	for i, n := range counts {
+		if n < 128 || n > 384 {
+			t.Errorf("HashShard() assigned %v IDs to shard %v, want about %v", n, i, len(ids)/numShards)
+		}
+	}
+}
+
+func TestShard_Panic(t *testing.T) {
+	for name, f := range map[string]func(SnowflakeID, int) int{"ShardIndex": ShardIndex, "HashShard": HashShard} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s() did not panic with numShards 0", name)
+				}
+			}()
+			f(1, 0)
+		})
+	}
+}
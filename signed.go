@@ -0,0 +1,75 @@
+package idgenerator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+)
+
+// signedTagLength is the number of bytes of the HMAC-SHA256 tag in a SignedID.
+const signedTagLength = 6
+
+// signedIDEncoding rejects the strings with non-zero padding bits, so that a SignedID has only one string form.
+var signedIDEncoding = base64.RawURLEncoding.Strict()
+
+// SignedID is a Snowflake ID with a truncated HMAC-SHA256 tag, to detect tampering
+// when the ID is passed through untrusted channels such as URLs.
+type SignedID struct {
+	ID  SnowflakeID
+	Tag [signedTagLength]byte
+}
+
+// SignID returns the SignedID of id with key.
+func SignID(id SnowflakeID, key []byte) SignedID {
+	s := SignedID{ID: id}
+	copy(s.Tag[:], signedTag(id, key))
+	return s
+}
+
+// String returns the ID and the tag encoded in URL-safe base64 without padding, as a 19-character string.
+func (s SignedID) String() string {
+	b := binary.BigEndian.AppendUint64(make([]byte, 0, 8+signedTagLength), uint64(s.ID))
+	return signedIDEncoding.EncodeToString(append(b, s.Tag[:]...))
+}
+
+// VerifySignedID decodes a string encoded by SignedID.String, and returns the ID if the tag is valid for key.
+func VerifySignedID(s string, key []byte) (SnowflakeID, error) {
+	b, err := signedIDEncoding.DecodeString(s)
+	if err != nil || len(b) != 8+signedTagLength {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSignedID, s)
+	}
+	id := SnowflakeID(binary.BigEndian.Uint64(b))
+	if !hmac.Equal(b[8:], signedTag(id, key)) {
+		return 0, ErrInvalidSignature
+	}
+	return id, nil
+}
+
+func signedTag(id SnowflakeID, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(binary.BigEndian.AppendUint64(nil, uint64(id)))
+	return mac.Sum(nil)[:signedTagLength]
+}
+
+// SignedIDGenerator generates signed IDs with a Generator.
+// A SignedIDGenerator is safe for concurrent use by multiple goroutines.
+type SignedIDGenerator struct {
+	g   *Generator
+	key []byte
+}
+
+// NewSignedIDGenerator returns a new SignedIDGenerator signing IDs generated by g with key.
+func NewSignedIDGenerator(g *Generator, key []byte) *SignedIDGenerator {
+	return &SignedIDGenerator{g: g, key: append([]byte(nil), key...)}
+}
+
+// Next returns a new generated ID signed as a string, which can be verified by VerifySignedID.
+func (sg *SignedIDGenerator) Next() (string, error) {
+	id, err := sg.g.Next()
+	if err != nil {
+		return "", err
+	}
+	return SignID(id, sg.key).String(), nil
+}
@@ -0,0 +1,74 @@
+package idgenerator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSignedID_String(t *testing.T) {
+	got := SignID(11234023837724673, []byte("secret")).String()
+	if want := "ACfpSQA-8AE3wlHSxVM"; got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+}
+
+func TestVerifySignedID(t *testing.T) {
+	type args struct {
+		s   string
+		key []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    SnowflakeID
+		wantErr error
+	}{
+		{"Valid", args{"ACfpSQA-8AE3wlHSxVM", []byte("secret")}, 11234023837724673, nil},
+		{"Error tampered ID", args{"ACfpSQA-8AI3wlHSxVM", []byte("secret")}, 0, ErrInvalidSignature},
+		{"Error tampered tag", args{"ACfpSQA-8AE3wlHSxWM", []byte("secret")}, 0, ErrInvalidSignature},
+		{"Error non-zero padding bits", args{"ACfpSQA-8AE3wlHSxVN", []byte("secret")}, 0, ErrInvalidSignedID},
+		{"Error wrong key", args{"ACfpSQA-8AE3wlHSxVM", []byte("other")}, 0, ErrInvalidSignature},
+		{"Error too short", args{"ACfpSQA-8AE3wlHSxV", []byte("secret")}, 0, ErrInvalidSignedID},
+		{"Error not base64", args{"ACfpSQA+8AE3wlHSxVM", []byte("secret")}, 0, ErrInvalidSignedID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifySignedID(tt.args.s, tt.args.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifySignedID() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("VerifySignedID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignedIDGenerator_Next(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15), WithSequenceNumber(1))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	key := []byte("secret")
+	sg := NewSignedIDGenerator(g, key)
+	// Modifying the key after creating the generator does not affect it.
+	key[0] = 'x'
+
+	s, err := sg.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if want := "ACfpSQA-8AE3wlHSxVM"; s != want {
+		t.Errorf("Next() = %v, want %v", s, want)
+	}
+	id, err := VerifySignedID(s, []byte("secret"))
+	if err != nil {
+		t.Fatalf("VerifySignedID() error = %v", err)
+	}
+	if id != 11234023837724673 {
+		t.Errorf("VerifySignedID() = %v, want %v", id, 11234023837724673)
+	}
+}
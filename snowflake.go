@@ -10,6 +10,8 @@
 //	3rd	 5 bits are used for a datacenter id
 //	4th	 5 bits are used for a machine id
 //	5th 12 bits are used for a sequence number
+//
+// Other bit layouts, such as the one of Sonyflake, can be specified with WithLayout.
 package idgenerator
 
 import (
@@ -32,15 +34,60 @@ var (
 	datacenterBitShift = machineBitRange + sequenceNumBitRange
 	machineBitShift    = sequenceNumBitRange
 
+	maxTimestamp      = int64(math.Pow(2, timestampBitRange)) - 1
+	maxDatacenterID   = int(math.Pow(2, datacenterBitRange)) - 1
+	maxMachineID      = int(math.Pow(2, machineBitRange)) - 1
+	maxSequenceNumber = int(math.Pow(2, sequenceNumBitRange)) - 1
+	maxWorkerID       = int(math.Pow(2, datacenterBitRange+machineBitRange)) - 1
+
 	defaultBaseTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 )
 
 var (
-	ErrOverLifeTime          = errors.New("over the maximum lifetime")
-	ErrInvalidTimestamp      = errors.New("invalid timestamp")
-	ErrInvalidDatacenterID   = errors.New("invalid datacenter ID")
-	ErrInvalidMachineID      = errors.New("invalid machine ID")
-	ErrInvalidSequenceNumber = errors.New("invalid sequence number")
+	ErrInvalidID                 = errors.New("invalid ID")
+	ErrOverLifeTime              = errors.New("over the maximum lifetime")
+	ErrInvalidTimestamp          = errors.New("invalid timestamp")
+	ErrInvalidDatacenterID       = errors.New("invalid datacenter ID")
+	ErrInvalidMachineID          = errors.New("invalid machine ID")
+	ErrInvalidSequenceNumber     = errors.New("invalid sequence number")
+	ErrInvalidWorkerID           = errors.New("invalid worker ID")
+	ErrUnsupportedOption         = errors.New("unsupported option")
+	ErrInvalidWaitTimeout        = errors.New("invalid wait timeout")
+	ErrSequenceExhausted         = errors.New("sequence number exhausted")
+	ErrInvalidCount              = errors.New("invalid count")
+	ErrClockMovedBackward        = errors.New("clock moved backward")
+	ErrInvalidClockSkewTolerance = errors.New("invalid clock skew tolerance")
+	ErrMockExhausted             = errors.New("mock IDs exhausted")
+	ErrInvalidClock              = errors.New("invalid clock")
+	ErrNoIPAddress               = errors.New("no IP address found")
+	ErrEnvNotFound               = errors.New("environment variable not found")
+	ErrInvalidEnv                = errors.New("invalid environment variable")
+	ErrInvalidMetrics            = errors.New("invalid metrics")
+	ErrInvalidInterceptor        = errors.New("invalid interceptor")
+	ErrInvalidChanBufferSize     = errors.New("invalid channel buffer size")
+	ErrInvalidRateLimit          = errors.New("invalid rate limit")
+	ErrInvalidLayout             = errors.New("invalid layout")
+	ErrInvalidShardID            = errors.New("invalid shard ID")
+	ErrInvalidAuditLogCapacity   = errors.New("invalid audit log capacity")
+	ErrInvalidObserver           = errors.New("invalid observer")
+	ErrInvalidCheckpoint         = errors.New("invalid checkpoint")
+	ErrInvalidTimeRange          = errors.New("invalid time range")
+	ErrInvalidSignedID           = errors.New("invalid signed ID")
+	ErrInvalidSignature          = errors.New("invalid signature")
+	ErrInvalidPrefix             = errors.New("invalid prefix")
+	ErrPrefixMismatch            = errors.New("prefix mismatch")
+	ErrPrefixNotRegistered       = errors.New("prefix not registered")
+	ErrInvalidShardCount         = errors.New("invalid shard count")
+	ErrInvalidSequenceProvider   = errors.New("invalid sequence provider")
+	ErrInvalidCursor             = errors.New("invalid cursor")
+	ErrInvalidLatencyThreshold   = errors.New("invalid latency threshold")
+	ErrDuplicateID               = errors.New("duplicate ID")
+	ErrInvalidWindow             = errors.New("invalid window")
+	ErrInvalidPercentage         = errors.New("invalid percentage")
+	ErrInvalidLayoutVersion      = errors.New("invalid layout version")
+	ErrInvalidTenantID           = errors.New("invalid tenant ID")
+	ErrInvalidEntityType         = errors.New("invalid entity type")
+	ErrInvalidRegion             = errors.New("invalid region")
 )
 
 type snowflake struct {
@@ -48,17 +95,57 @@ type snowflake struct {
 	datacenterID   int
 	machineID      int
 	sequenceNumber int
+	shardID        int
+	hasShardID     bool
+	workerID       int
+	hasWorkerID    bool
+	reduceWorkerID bool
 
 	baseTime time.Time
 	random   bool
+	clock    ClockSource
+	layout   Layout
+
+	exhaustion           SequenceExhaustionPolicy
+	cryptoRandomFallback bool
+	clockSkewTolerance   time.Duration
+	metrics              Metrics
+	interceptors         []Interceptor
+	chanBufferSize       int
+	rateInterval         time.Duration
+	auditLogCapacity     int
+	observers            []func(id SnowflakeID, elapsed time.Duration)
+	obfuscationKey       uint64
+	obfuscation          bool
+	shardCount           int
+	sequenceProvider     SequenceProvider
+	reservedBit          bool
+	hasLayoutVersion     bool
+	layoutVersion        uint8
 
 	mutex sync.Mutex
 }
 
-type option func(*snowflake) error
+// Option configures how Snowflake IDs are generated.
+type Option func(*snowflake) error
+
+// LazyOption returns an Option that calls f when it is applied, and applies the Option returned by f.
+// It allows other packages to build an Option that may fail, such as one that reads an external source.
+func LazyOption(f func() (Option, error)) Option {
+	return func(s *snowflake) error {
+		opt, err := f()
+		if err != nil {
+			return err
+		}
+		return opt(s)
+	}
+}
 
 // NewSnowflakeID returns a new generated Snowflake ID.
-func NewSnowflakeID(opts ...option) (int64, error) {
+//
+// NewSnowflakeID does not share any state between calls, so two calls in the same millisecond
+// with the same options return the same ID. Use a Generator to generate unique IDs.
+func NewSnowflakeID(opts ...Option) (int64, error) {
 	s := &snowflake{}
 
 	s.mutex.Lock()
@@ -67,6 +154,7 @@ func NewSnowflakeID(opts ...option) (int64, error) {
 			return 0, err
 		}
 	}
+	layout := s.getLayout()
 
 	ts, err := s.getElapsedTimestamp()
 	if err != nil {
@@ -76,64 +164,91 @@ func NewSnowflakeID(opts ...option) (int64, error) {
 
 	if s.random {
 		if s.datacenterID == 0 {
-			s.datacenterID = rand.Intn(2 ^ datacenterBitRange - 1)
+			s.datacenterID = rand.Intn(layout.maxDatacenterID() + 1)
 		}
 		if s.machineID == 0 {
-			s.machineID = rand.Intn(2 ^ machineBitRange - 1)
+			s.machineID = rand.Intn(layout.maxMachineID() + 1)
 		}
 		if s.sequenceNumber == 0 {
-			s.sequenceNumber = rand.Intn(2 ^ sequenceNumBitRange - 1)
+			s.sequenceNumber = rand.Intn(layout.maxSequenceNumber() + 1)
 		}
 	}
+	if err := s.validate(); err != nil {
+		return 0, err
+	}
 	s.mutex.Unlock()
 
-	generatedID := s.timestamp<<timestampBitShift | int64(s.datacenterID)<<datacenterBitShift | int64(s.machineID)<<machineBitShift | int64(s.sequenceNumber)
+	generatedID := layout.compose(s.timestamp, s.datacenterID, s.machineID, s.sequenceNumber) | int64(s.getReservedBit())
 	return generatedID, nil
 }
 
+// NewSnowflakeIDUint64 returns a new generated Snowflake ID as uint64.
+//
+// The most significant bit is 0 unless WithReservedBit(true) is specified, so the value is the same as the one of NewSnowflakeID.
+// Prefer this form when the ID is stored as an unsigned integer (e.g., a uint64 primary key),
+// and prefer NewSnowflakeID when the ID is stored as a signed integer (e.g., a BIGINT column).
+func NewSnowflakeIDUint64(opts ...Option) (uint64, error) {
+	id, err := NewSnowflakeID(opts...)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(id), nil
+}
+
 // WithTimestamp specifies the timestamp of Snowflake ID.
-func WithTimestamp(v time.Time) option {
+func WithTimestamp(v time.Time) Option {
 	return func(s *snowflake) error {
-		s.timestamp = v.UnixMilli()
+		s.timestamp = v.UnixMicro()
 		return nil
 	}
 }
 
 // WithDatacenterID specifies the datacenter ID of Snowflake ID.
-func WithDatacenterID(v int) option {
+func WithDatacenterID(v int) Option {
 	return func(s *snowflake) error {
-		if v < 0 || v > int(math.Pow(2, datacenterBitRange))-1 {
-			return ErrInvalidDatacenterID
-		}
 		s.datacenterID = v
 		return nil
 	}
 }
 
 // WithMachineID specifies the machine ID of Snowflake ID.
-func WithMachineID(v int) option {
+func WithMachineID(v int) Option {
 	return func(s *snowflake) error {
-		if v < 0 || v > int(math.Pow(2, machineBitRange))-1 {
-			return ErrInvalidMachineID
-		}
 		s.machineID = v
 		return nil
 	}
 }
 
+// WithWorkerID specifies the datacenter ID and the machine ID of Snowflake ID as a single 10-bit worker ID.
+// The upper 5 bits are used as the datacenter ID, and the lower 5 bits are used as the machine ID,
+// which is compatible with the worker ID of the Discord and Mastodon Snowflake implementations.
+// With another layout, the worker ID is split at the machine bits of the layout, as with WithShardID,
+// and it returns ErrInvalidWorkerID if the worker ID does not fit in the datacenter and machine bits.
+func WithWorkerID(v int) Option {
+	return func(s *snowflake) error {
+		s.setWorkerID(v, false)
+		return nil
+	}
+}
+
+// setWorkerID sets the worker ID, which is split into the datacenter ID and the machine ID in validate.
+// If reduce is true, v is reduced to the worker ID range of the layout by modulo.
+func (s *snowflake) setWorkerID(v int, reduce bool) {
+	s.workerID = v
+	s.hasWorkerID = true
+	s.reduceWorkerID = reduce
+}
+
 // WithSequenceNumber specifies the sequence number of Snowflake ID.
-func WithSequenceNumber(v int) option {
+func WithSequenceNumber(v int) Option {
 	return func(s *snowflake) error {
-		if v < 0 || v > int(math.Pow(2, sequenceNumBitRange))-1 {
-			return ErrInvalidSequenceNumber
-		}
 		s.sequenceNumber = v
 		return nil
 	}
 }
 
 // WithBaseTime changes the Snowflake base time from the default.
-func WithBaseTime(v time.Time) option {
+func WithBaseTime(v time.Time) Option {
 	return func(s *snowflake) error {
 		s.baseTime = v
 		return nil
@@ -141,7 +256,7 @@ func WithBaseTime(v time.Time) option {
 }
 
 // WithRandomEnabled enables picking a random value for unset datacenter ID, machine ID, and sequence number.
-func WithRandomEnabled() option {
+func WithRandomEnabled() Option {
 	return func(s *snowflake) error {
 		s.random = true
 		return nil
@@ -149,21 +264,70 @@ func WithRandomEnabled() option {
 }
 
 func (s *snowflake) getElapsedTimestamp() (int64, error) {
-	at := time.Now().UTC()
+	at := s.getClock().Now().UTC()
 	if s.timestamp > 0 {
-		at = time.UnixMilli(s.timestamp)
+		at = time.UnixMicro(s.timestamp)
 	}
+	return elapsedTimestamp(at, s.getBaseTime(), s.getLayout())
+}
 
-	baseTime := defaultBaseTime
+// validate validates the datacenter ID, the machine ID, and the sequence number against the layout,
+// after splitting the shard ID and the worker ID into the datacenter ID and the machine ID.
+// It is called after all options are applied, so that the layout can be specified in any order.
+func (s *snowflake) validate() error {
+	layout := s.getLayout()
+	if s.hasShardID {
+		if s.shardID < 0 || s.shardID > layout.maxShardID() {
+			return ErrInvalidShardID
+		}
+		s.datacenterID = s.shardID >> layout.MachineBits
+		s.machineID = s.shardID & layout.maxMachineID()
+	}
+	if s.hasWorkerID {
+		if s.reduceWorkerID {
+			s.workerID %= layout.maxShardID() + 1
+		}
+		if s.workerID < 0 || s.workerID > layout.maxShardID() {
+			return ErrInvalidWorkerID
+		}
+		s.datacenterID = s.workerID >> layout.MachineBits
+		s.machineID = s.workerID & layout.maxMachineID()
+	}
+	if s.datacenterID < 0 || s.datacenterID > layout.maxDatacenterID() {
+		return ErrInvalidDatacenterID
+	}
+	if s.machineID < 0 || s.machineID > layout.maxMachineID() {
+		return ErrInvalidMachineID
+	}
+	if s.sequenceNumber < 0 || s.sequenceNumber > layout.maxSequenceNumber() {
+		return ErrInvalidSequenceNumber
+	}
+	if err := s.validateLayoutVersion(); err != nil {
+		return err
+	}
+	return s.validateReservedBit(layout)
+}
+
+func (s *snowflake) getClock() ClockSource {
+	if s.clock != nil {
+		return s.clock
+	}
+	return systemClock{}
+}
+
+func (s *snowflake) getBaseTime() time.Time {
 	if !s.baseTime.IsZero() {
-		baseTime = s.baseTime
+		return s.baseTime
 	}
+	return defaultBaseTime
+}
 
-	diffMilli := at.Sub(baseTime).Milliseconds()
-	if diffMilli <= 0 {
+func elapsedTimestamp(at, baseTime time.Time, layout Layout) (int64, error) {
+	diff := elapsedUnits(at, baseTime, layout.timeUnit())
+	if diff <= 0 {
 		return 0, ErrInvalidTimestamp
-	} else if diffMilli > int64(math.Pow(2, timestampBitRange))-1 {
+	} else if diff > layout.maxTimestamp() {
 		return 0, ErrOverLifeTime
 	}
-	return diffMilli, nil
+	return diff, nil
 }
@@ -0,0 +1,81 @@
+package idgenerator
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// Run a fuzz test with, e.g., go test -run '^$' -fuzz FuzzParseSnowflakeID.
+
+func FuzzParseSnowflakeID(f *testing.F) {
+	for _, id := range []int64{0, 1, 11234023837724673, 1 << 22, 1<<22 - 1, math.MaxInt64, -1, math.MinInt64} {
+		f.Add(id, int64(0), 0, 0, 0, 0)
+	}
+	f.Add(int64(11234023837724673), defaultBaseTime.UnixMilli(), 41, 5, 5, 12)
+	f.Add(int64(11234023837724673), EpochDiscord.UnixMilli(), 42, 5, 5, 12)
+	f.Add(int64(math.MaxInt64), int64(-62135596800000), 64, 0, 0, 0)
+	f.Add(int64(math.MaxInt64), int64(253402300799000), 1, 21, 21, 21)
+
+	f.Fuzz(func(t *testing.T, id int64, baseTimeMilli int64, timestampBits, datacenterBits, machineBits, sequenceBits int) {
+		var baseTime time.Time
+		if baseTimeMilli != 0 {
+			baseTime = time.UnixMilli(baseTimeMilli)
+		}
+		layout := Layout{
+			TimestampBits:  timestampBits,
+			DatacenterBits: datacenterBits,
+			MachineBits:    machineBits,
+			SequenceBits:   sequenceBits,
+		}
+		c, err := ParseSnowflakeID(id, baseTime, layout)
+		if err != nil {
+			return
+		}
+		// A negative ID is valid only with a layout version.
+		if id < 0 && layout != (Layout{}) {
+			t.Fatalf("ParseSnowflakeID(%d) error = nil, want error for a negative ID", id)
+		}
+
+		if baseTime.IsZero() {
+			baseTime = defaultBaseTime
+		}
+		if layout == (Layout{}) {
+			if layout, err = detectLayout(id); err != nil {
+				t.Fatalf("detectLayout(%d) error = %v, want nil as ParseSnowflakeID", id, err)
+			}
+			if layout.tagged && layout.tag == reservedBitTag {
+				t.Fatalf("ParseSnowflakeID(%d) error = nil, want error for the reserved bit", id)
+			}
+		}
+		// The fields compose the same ID again.
+		// The elapsed time may not fit in time.Duration, so it is computed in nanoseconds with big.Int.
+		elapsed := new(big.Int).Mul(big.NewInt(c.Timestamp.Unix()-baseTime.Unix()), big.NewInt(int64(time.Second)))
+		elapsed.Add(elapsed, big.NewInt(int64(c.Timestamp.Nanosecond()-baseTime.Nanosecond())))
+		ts := elapsed.Div(elapsed, big.NewInt(int64(layout.timeUnit()))).Int64()
+		if got := layout.compose(ts, c.DatacenterID, c.MachineID, c.SequenceNumber); got != id {
+			t.Fatalf("ParseSnowflakeID(%d) = %+v, which composes %d", id, c, got)
+		}
+		if c.DatacenterID > layout.maxDatacenterID() || c.MachineID > layout.maxMachineID() || c.SequenceNumber > layout.maxSequenceNumber() {
+			t.Fatalf("ParseSnowflakeID(%d) = %+v, out of the layout %+v", id, c, layout)
+		}
+	})
+}
+
+func FuzzParseBase62(f *testing.F) {
+	for _, s := range []string{"00000000000", "00pS1Hwq1mz", "AzL8n0Y58m7", "LygHa16AHYF", "LygHa16AHYG", "zzzzzzzzzzz", "", "0", "00pS1Hwq1m!", "00pS1Hwq1mzz"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		id, err := ParseBase62(s)
+		if err != nil {
+			return
+		}
+		// Only the canonical encoding of an ID is accepted.
+		if got := id.Base62(); got != s {
+			t.Fatalf("ParseBase62(%q) = %d, which encodes %q", s, id, got)
+		}
+	})
+}
@@ -1,14 +1,16 @@
 package idgenerator
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 )
 
 func TestNewSnowflakeID(t *testing.T) {
 	type args struct {
-		opts []option
+		opts []Option
 	}
 	tests := []struct {
 		name       string
@@ -19,7 +21,7 @@ func TestNewSnowflakeID(t *testing.T) {
 	}{
 		{
 			"WithTimestamp:2024-02-01 WithDatacenterID:31 WithMachineID:15 WithSequenceNumber:1",
-			args{[]option{
+			args{[]Option{
 				WithTimestamp(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
 				WithDatacenterID(31),
 				WithMachineID(15),
@@ -29,9 +31,20 @@ func TestNewSnowflakeID(t *testing.T) {
 			false,
 			"0000000000100111111010010100100100000000001111101111000000000001",
 		},
+		{
+			"WithTimestamp:2024-02-01 WithWorkerID:1007 WithSequenceNumber:1",
+			args{[]Option{
+				WithTimestamp(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+				WithWorkerID(1007),
+				WithSequenceNumber(1),
+			}},
+			11234023837724673,
+			false,
+			"0000000000100111111010010100100100000000001111101111000000000001",
+		},
 		{
 			"Error invalid datacenter ID",
-			args{[]option{
+			args{[]Option{
 				WithDatacenterID(32),
 			}},
 			0,
@@ -40,16 +53,25 @@ func TestNewSnowflakeID(t *testing.T) {
 		},
 		{
 			"Error invalid machine ID",
-			args{[]option{
+			args{[]Option{
 				WithMachineID(32),
 			}},
 			0,
 			true,
 			"0000000000000000000000000000000000000000000000000000000000000000",
 		},
+		{
+			"Error invalid worker ID",
+			args{[]Option{
+				WithWorkerID(1024),
+			}},
+			0,
+			true,
+			"0000000000000000000000000000000000000000000000000000000000000000",
+		},
 		{
 			"Error invalid sequence number",
-			args{[]option{
+			args{[]Option{
 				WithSequenceNumber(4096),
 			}},
 			0,
@@ -58,7 +80,7 @@ func TestNewSnowflakeID(t *testing.T) {
 		},
 		{
 			"Error over the maximum lifetime",
-			args{[]option{
+			args{[]Option{
 				WithTimestamp(time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC)),
 				WithBaseTime(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)),
 			}},
@@ -68,7 +90,7 @@ func TestNewSnowflakeID(t *testing.T) {
 		},
 		{
 			"Error invalid timestamp",
-			args{[]option{
+			args{[]Option{
 				WithTimestamp(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
 				WithBaseTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 			}},
@@ -94,3 +116,116 @@ func TestNewSnowflakeID(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSnowflakeID_WithRandomEnabled(t *testing.T) {
+	var gotMaxDatacenterID, gotMaxMachineID, gotMaxSequenceNumber int
+	for i := 0; i < 100000; i++ {
+		id, err := NewSnowflakeID(WithRandomEnabled())
+		if err != nil {
+			t.Fatalf("NewSnowflakeID() error = %v", err)
+		}
+		datacenterID := int(id>>datacenterBitShift) & maxDatacenterID
+		machineID := int(id>>machineBitShift) & maxMachineID
+		sequenceNumber := int(id) & maxSequenceNumber
+		gotMaxDatacenterID = max(gotMaxDatacenterID, datacenterID)
+		gotMaxMachineID = max(gotMaxMachineID, machineID)
+		gotMaxSequenceNumber = max(gotMaxSequenceNumber, sequenceNumber)
+	}
+	if gotMaxDatacenterID != maxDatacenterID {
+		t.Errorf("NewSnowflakeID() max datacenter ID = %v, want %v", gotMaxDatacenterID, maxDatacenterID)
+	}
+	if gotMaxMachineID != maxMachineID {
+		t.Errorf("NewSnowflakeID() max machine ID = %v, want %v", gotMaxMachineID, maxMachineID)
+	}
+	if gotMaxSequenceNumber != maxSequenceNumber {
+		t.Errorf("NewSnowflakeID() max sequence number = %v, want %v", gotMaxSequenceNumber, maxSequenceNumber)
+	}
+}
+
+func TestNewSnowflakeIDUint64(t *testing.T) {
+	type args struct {
+		opts []Option
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       uint64
+		wantErr    bool
+		outputBits string
+	}{
+		{
+			"WithTimestamp:2024-02-01 WithDatacenterID:31 WithMachineID:15 WithSequenceNumber:1",
+			args{[]Option{
+				WithTimestamp(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+				WithDatacenterID(31),
+				WithMachineID(15),
+				WithSequenceNumber(1),
+			}},
+			11234023837724673,
+			false,
+			"0000000000100111111010010100100100000000001111101111000000000001",
+		},
+		{
+			"Maximum value",
+			args{[]Option{
+				WithTimestamp(defaultBaseTime.Add(time.Duration(maxTimestamp) * time.Millisecond)),
+				WithDatacenterID(31),
+				WithMachineID(31),
+				WithSequenceNumber(4095),
+			}},
+			math.MaxInt64,
+			false,
+			"0111111111111111111111111111111111111111111111111111111111111111",
+		},
+		{
+			"Error over the maximum lifetime",
+			args{[]Option{
+				WithTimestamp(defaultBaseTime.Add(time.Duration(maxTimestamp+1) * time.Millisecond)),
+			}},
+			0,
+			true,
+			"0000000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSnowflakeIDUint64(tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSnowflakeIDUint64() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewSnowflakeIDUint64() uint64 = %v, want %v", got, tt.want)
+			}
+			gotBits := fmt.Sprintf("%064b", got)
+			if gotBits != tt.outputBits {
+				t.Errorf("NewSnowflakeIDUint64() bits = %v, want %v", gotBits, tt.outputBits)
+			}
+		})
+	}
+}
+
+func TestLazyOption(t *testing.T) {
+	errLazy := errors.New("lazy error")
+	tests := []struct {
+		name             string
+		f                func() (Option, error)
+		wantDatacenterID int
+		wantErr          error
+	}{
+		{"Apply option", func() (Option, error) { return WithDatacenterID(31), nil }, 31, nil},
+		{"Error from f", func() (Option, error) { return nil, errLazy }, 0, errLazy},
+		{"Error from option", func() (Option, error) { return WithClock(nil), nil }, 0, ErrInvalidClock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &snowflake{}
+			if err := LazyOption(tt.f)(s); err != tt.wantErr {
+				t.Errorf("LazyOption() error = %v, want %v", err, tt.wantErr)
+			}
+			if s.datacenterID != tt.wantDatacenterID {
+				t.Errorf("LazyOption() datacenter ID = %v, want %v", s.datacenterID, tt.wantDatacenterID)
+			}
+		})
+	}
+}
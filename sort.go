@@ -0,0 +1,38 @@
+package idgenerator
+
+import (
+	"sort"
+	"time"
+)
+
+// SnowflakeIDs attaches the methods of sort.Interface to []SnowflakeID, sorting in increasing order,
+// which is the order the IDs were generated in.
+type SnowflakeIDs []SnowflakeID
+
+var _ sort.Interface = SnowflakeIDs(nil)
+
+func (ids SnowflakeIDs) Len() int           { return len(ids) }
+func (ids SnowflakeIDs) Less(i, j int) bool { return ids[i] < ids[j] }
+func (ids SnowflakeIDs) Swap(i, j int)      { ids[i], ids[j] = ids[j], ids[i] }
+
+// SortIDs sorts ids in increasing order.
+func SortIDs(ids []SnowflakeID) {
+	sort.Sort(SnowflakeIDs(ids))
+}
+
+// IsSorted reports whether ids is sorted in increasing order.
+func IsSorted(ids []SnowflakeID) bool {
+	return sort.IsSorted(SnowflakeIDs(ids))
+}
+
+// SortByTime sorts ids by the time they were generated, ignoring the datacenter ID, the machine ID,
+// and the sequence number, e.g., to merge IDs generated by different sources.
+// IDs generated in the same millisecond keep their original order.
+//
+// baseTime must be the base time the IDs were generated with. If it is zero, the default base time is used.
+// All the IDs must share it, so it does not change the order.
+func SortByTime(ids []SnowflakeID, baseTime time.Time) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		return ids[i].Before(ids[j])
+	})
+}
@@ -0,0 +1,46 @@
+package idgenerator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSortIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []SnowflakeID
+		want []SnowflakeID
+	}{
+		{"Empty", []SnowflakeID{}, []SnowflakeID{}},
+		{"Sorted", []SnowflakeID{1, 2, 3}, []SnowflakeID{1, 2, 3}},
+		{"Reversed", []SnowflakeID{11234023841918976, 11234023837724673, 11234023837724672}, []SnowflakeID{11234023837724672, 11234023837724673, 11234023841918976}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SortIDs(tt.ids)
+			if !reflect.DeepEqual(tt.ids, tt.want) {
+				t.Errorf("SortIDs() = %v, want %v", tt.ids, tt.want)
+			}
+			if !IsSorted(tt.ids) {
+				t.Errorf("IsSorted() = %v, want %v", false, true)
+			}
+		})
+	}
+	if IsSorted([]SnowflakeID{2, 1}) {
+		t.Errorf("IsSorted() = %v, want %v", true, false)
+	}
+}
+
+func TestSortByTime(t *testing.T) {
+	// a1 and a2 are generated in the same millisecond by different machines, and b is generated 1 millisecond later.
+	a1 := SnowflakeID(11234023837724673)
+	a2 := SnowflakeID(11234023837724673 &^ (31 << 17))
+	b := SnowflakeID(11234023841918976)
+
+	ids := []SnowflakeID{b, a1, a2}
+	SortByTime(ids, time.Time{})
+	if want := []SnowflakeID{a1, a2, b}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("SortByTime() = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,40 @@
+package idgenerator
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// Scan implements sql.Scanner.
+// An int64 value (e.g., from a bigint column) and a string value (e.g., from a text column) are accepted.
+// A NULL value is scanned as the zero value.
+func (id *SnowflakeID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*id = 0
+	case int64:
+		*id = SnowflakeID(v)
+	case []byte:
+		return id.scanString(string(v))
+	case string:
+		return id.scanString(v)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidID, src)
+	}
+	return nil
+}
+
+func (id *SnowflakeID) scanString(s string) error {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidID, s)
+	}
+	*id = SnowflakeID(v)
+	return nil
+}
+
+// Value implements driver.Valuer. The ID is stored as int64.
+func (id SnowflakeID) Value() (driver.Value, error) {
+	return int64(id), nil
+}
@@ -0,0 +1,45 @@
+package idgenerator
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestSnowflakeID_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    SnowflakeID
+		wantErr bool
+	}{
+		{"bigint column", int64(11234023837724673), 11234023837724673, false},
+		{"text column as []byte", []byte("11234023837724673"), 11234023837724673, false},
+		{"text column as string", "11234023837724673", 11234023837724673, false},
+		{"NULL", nil, 0, false},
+		{"Error invalid text", "abc", 0, true},
+		{"Error unsupported type", 1.5, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := SnowflakeID(1)
+			err := id.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SnowflakeID.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && id != tt.want {
+				t.Errorf("SnowflakeID.Scan() = %v, want %v", id, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnowflakeID_Value(t *testing.T) {
+	got, err := SnowflakeID(11234023837724673).Value()
+	if err != nil {
+		t.Fatalf("SnowflakeID.Value() error = %v", err)
+	}
+	if want := driver.Value(int64(11234023837724673)); got != want {
+		t.Errorf("SnowflakeID.Value() = %#v, want %#v", got, want)
+	}
+}
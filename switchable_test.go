@@ -0,0 +1,109 @@
+package idgenerator
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestNewSwitchableGenerator(t *testing.T) {
+	tests := []struct {
+		name    string
+		pct     float64
+		wantErr error
+	}{
+		{"0%", 0, nil},
+		{"50%", 50, nil},
+		{"100%", 100, nil},
+		{"Error negative", -1, ErrInvalidPercentage},
+		{"Error over 100", 100.1, ErrInvalidPercentage},
+		{"Error NaN", math.NaN(), ErrInvalidPercentage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewSwitchableGenerator(SequentialMockGenerator(0), SequentialMockGenerator(1000), tt.pct)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewSwitchableGenerator() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := g.Percentage(); got != tt.pct {
+				t.Errorf("Percentage() = %v, want %v", got, tt.pct)
+			}
+		})
+	}
+}
+
+func TestSwitchableGenerator_Next(t *testing.T) {
+	const newStart = 1 << 40
+	g, err := NewSwitchableGenerator(SequentialMockGenerator(0), SequentialMockGenerator(newStart), 0)
+	if err != nil {
+		t.Fatalf("NewSwitchableGenerator() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		pct     float64
+		wantOld uint64
+		wantNew uint64
+	}{
+		{"All old", 0, 100, 0},
+		{"All new", 100, 100, 100},
+		{"Rolled back", 0, 200, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := g.SetPercentage(tt.pct); err != nil {
+				t.Fatalf("SetPercentage() error = %v", err)
+			}
+			for i := 0; i < 100; i++ {
+				id, err := g.Next()
+				if err != nil {
+					t.Fatalf("Next() error = %v", err)
+				}
+				if isNew := id >= newStart; isNew != (tt.pct == 100) {
+					t.Fatalf("Next() = %v, want from the new generator %v", id, tt.pct == 100)
+				}
+			}
+			if got, want := g.Stats(), (SwitchStats{Old: tt.wantOld, New: tt.wantNew}); got != want {
+				t.Errorf("Stats() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSwitchableGenerator_Percentage(t *testing.T) {
+	g, err := NewSwitchableGenerator(SequentialMockGenerator(0), SequentialMockGenerator(1<<40), 25)
+	if err != nil {
+		t.Fatalf("NewSwitchableGenerator() error = %v", err)
+	}
+	const goroutines, calls = 8, 2500
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				if _, err := g.NextN(2); err != nil {
+					t.Errorf("NextN() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	// Changing the percentage concurrently is safe.
+	if err := g.SetPercentage(25); err != nil {
+		t.Errorf("SetPercentage() error = %v", err)
+	}
+	wg.Wait()
+
+	stats := g.Stats()
+	if got, want := stats.Old+stats.New, uint64(goroutines*calls*2); got != want {
+		t.Errorf("Stats() total = %v, want %v", got, want)
+	}
+	// 25% of 20000 calls with a margin of more than 5 standard deviations.
+	if got := float64(stats.New) / float64(stats.Old+stats.New); got < 0.22 || got > 0.28 {
+		t.Errorf("Stats() new ratio = %v, want about 0.25", got)
+	}
+}
@@ -0,0 +1,79 @@
+package idgenerator
+
+import "fmt"
+
+// WithTenantID embeds tenantID % 1024 in the IDs as the 10-bit worker ID, i.e., the datacenter ID and the machine ID,
+// so that the tenant of an ID is known by ExtractTenantID without a database join, e.g., to route requests or partition data.
+//
+// The trade-off is the capacity: there are at most 1024 tenants, and there is no machine ID,
+// so IDs are unique only if a single Generator generates the IDs of each tenant at a time,
+// e.g., when the requests of a tenant are routed to a single process.
+// When there are more than 1024 tenants, tenants with the same tenantID % 1024 share the bits,
+// so ExtractTenantID cannot tell them apart, and their IDs may collide when generated by different Generators.
+// Use NewTenantAwareGenerator to reject such tenants. It returns ErrInvalidTenantID if tenantID is negative.
+func WithTenantID(tenantID int) Option {
+	return func(s *snowflake) error {
+		if tenantID < 0 {
+			return fmt.Errorf("%w: %d is negative", ErrInvalidTenantID, tenantID)
+		}
+		return WithWorkerID(tenantID % (maxWorkerID + 1))(s)
+	}
+}
+
+// ExtractTenantID returns the tenant ID of the ID generated with WithTenantID, read from bits 12-21.
+func ExtractTenantID(id SnowflakeID) int {
+	return ExtractWorkerID(int64(id))
+}
+
+// TenantAwareGenerator is a Generator of the IDs of a tenant, which embeds the tenant ID as WithTenantID does.
+// A TenantAwareGenerator is safe for concurrent use by multiple goroutines.
+type TenantAwareGenerator struct {
+	tenantID int
+	g        *Generator
+}
+
+var _ IDGenerator = (*TenantAwareGenerator)(nil)
+
+// NewTenantAwareGenerator returns a new TenantAwareGenerator for tenantID, which must be from 0 to 1023
+// to be recovered by ExtractTenantID. Otherwise, it returns ErrInvalidTenantID.
+// opts are passed to NewGenerator, and must not change the layout or the worker ID.
+// The options setting the worker ID or the shard ID, such as WithWorkerID and WithShardID, return ErrUnsupportedOption.
+func NewTenantAwareGenerator(tenantID int, opts ...Option) (*TenantAwareGenerator, error) {
+	if tenantID < 0 || tenantID > maxWorkerID {
+		return nil, fmt.Errorf("%w: %d is not from 0 to %d", ErrInvalidTenantID, tenantID, maxWorkerID)
+	}
+	g, err := NewGenerator(append(opts[:len(opts):len(opts)], withOnlyTenantID(tenantID))...)
+	if err != nil {
+		return nil, err
+	}
+	if g.Layout() != LayoutTwitterSnowflake {
+		return nil, fmt.Errorf("%w: tenant IDs need LayoutTwitterSnowflake", ErrInvalidLayout)
+	}
+	return &TenantAwareGenerator{tenantID: tenantID, g: g}, nil
+}
+
+// withOnlyTenantID is WithTenantID, which returns ErrUnsupportedOption if the options before it set the worker ID
+// or the shard ID, since validate would apply them instead of the tenant ID.
+func withOnlyTenantID(tenantID int) Option {
+	return func(s *snowflake) error {
+		if s.hasWorkerID || s.hasShardID {
+			return fmt.Errorf("%w: the worker ID is the tenant ID", ErrUnsupportedOption)
+		}
+		return WithTenantID(tenantID)(s)
+	}
+}
+
+// TenantID returns the tenant ID of the generated IDs.
+func (g *TenantAwareGenerator) TenantID() int {
+	return g.tenantID
+}
+
+// Next returns a new ID of the tenant.
+func (g *TenantAwareGenerator) Next() (SnowflakeID, error) {
+	return g.g.Next()
+}
+
+// NextN returns n new IDs of the tenant.
+func (g *TenantAwareGenerator) NextN(n int) ([]SnowflakeID, error) {
+	return g.g.NextN(n)
+}
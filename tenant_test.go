@@ -0,0 +1,89 @@
+package idgenerator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithTenantID(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID int
+		want     int
+		wantErr  error
+	}{
+		{"Zero", 0, 0, nil},
+		{"Maximum", 1023, 1023, nil},
+		{"Wrapped", 1024 + 42, 42, nil},
+		{"Error negative", -1, 0, ErrInvalidTenantID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := NewSnowflakeID(WithTenantID(tt.tenantID), WithTimestamp(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewSnowflakeID() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := ExtractTenantID(SnowflakeID(id)); got != tt.want {
+				t.Errorf("ExtractTenantID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTenantAwareGenerator(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID int
+		opts     []Option
+		wantErr  error
+	}{
+		{"Valid", 1007, nil, nil},
+		{"Error over 1023", 1024, nil, ErrInvalidTenantID},
+		{"Error negative", -1, nil, ErrInvalidTenantID},
+		{"Error another layout", 1, []Option{WithSonyflakeLayout()}, ErrInvalidLayout},
+		{"Error WithShardID", 1, []Option{WithShardID(5)}, ErrUnsupportedOption},
+		{"Error WithWorkerID", 1, []Option{WithWorkerID(5)}, ErrUnsupportedOption},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewTenantAwareGenerator(tt.tenantID, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewTenantAwareGenerator() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := g.TenantID(); got != tt.tenantID {
+				t.Errorf("TenantID() = %v, want %v", got, tt.tenantID)
+			}
+			ids, err := g.NextN(2)
+			if err != nil {
+				t.Fatalf("NextN() error = %v", err)
+			}
+			for _, id := range ids {
+				if got := ExtractTenantID(id); got != tt.tenantID {
+					t.Errorf("ExtractTenantID() = %v, want %v", got, tt.tenantID)
+				}
+			}
+		})
+	}
+}
+
+func TestNewTenantAwareGenerator_Options(t *testing.T) {
+	// NewTenantAwareGenerator does not write into the backing array of opts.
+	opts := make([]Option, 1, 2)
+	opts[0] = WithBaseTime(defaultBaseTime)
+	sentinel := WithMachineID(1)
+	_ = append(opts, sentinel)
+	if _, err := NewTenantAwareGenerator(7, opts...); err != nil {
+		t.Fatalf("NewTenantAwareGenerator() error = %v", err)
+	}
+	s := &snowflake{}
+	if err := opts[:2][1](s); err != nil || s.machineID != 1 {
+		t.Errorf("NewTenantAwareGenerator() overwrote the spare capacity of opts")
+	}
+}
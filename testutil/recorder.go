@@ -0,0 +1,134 @@
+// Package testutil provides helpers to use generated IDs in tests.
+package testutil
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+// TestRecorder is an IDGenerator that records the IDs generated by another IDGenerator to a file,
+// and replays them in the recorded order, like go-vcr does for HTTP interactions.
+// It makes fixtures with generated IDs, such as database seeds and API response snapshots, deterministic.
+// A TestRecorder is safe for concurrent use by multiple goroutines.
+type TestRecorder struct {
+	t    testing.TB
+	g    idgenerator.IDGenerator
+	path string
+
+	replaying bool
+	ids       []idgenerator.SnowflakeID
+	next      int
+
+	mutex sync.Mutex
+}
+
+var _ idgenerator.IDGenerator = (*TestRecorder)(nil)
+
+// NewRecorder returns a TestRecorder for path, which is a file with one decimal ID per line.
+//
+// If path does not exist, the TestRecorder generates IDs with g, and writes them to path when the test finishes.
+// Otherwise, it returns the IDs in path without calling g, and fails the test if more IDs are requested than recorded.
+// Delete path to record IDs again.
+func NewRecorder(t testing.TB, g idgenerator.IDGenerator, path string) *TestRecorder {
+	t.Helper()
+	r := &TestRecorder{t: t, g: g, path: path}
+
+	ids, err := readIDs(path)
+	switch {
+	case err == nil:
+		r.replaying = true
+		r.ids = ids
+	case os.IsNotExist(err):
+		t.Cleanup(r.save)
+	default:
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	return r
+}
+
+// Next returns the next recorded ID, or records the ID generated by the underlying IDGenerator.
+func (r *TestRecorder) Next() (idgenerator.SnowflakeID, error) {
+	ids, err := r.NextN(1)
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// NextN returns the next n recorded IDs, or records the IDs generated by the underlying IDGenerator.
+// If fewer than n IDs remain in the recording, it fails the test and returns idgenerator.ErrMockExhausted.
+func (r *TestRecorder) NextN(n int) ([]idgenerator.SnowflakeID, error) {
+	if n <= 0 {
+		return nil, idgenerator.ErrInvalidCount
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.replaying {
+		ids, err := r.g.NextN(n)
+		if err != nil {
+			return nil, err
+		}
+		r.ids = append(r.ids, ids...)
+		return ids, nil
+	}
+
+	if len(r.ids)-r.next < n {
+		err := fmt.Errorf("%w: %d IDs are requested, but %d IDs remain in %s", idgenerator.ErrMockExhausted, n, len(r.ids)-r.next, r.path)
+		r.t.Errorf("TestRecorder.NextN() error = %v", err)
+		return nil, err
+	}
+	ids := append([]idgenerator.SnowflakeID(nil), r.ids[r.next:r.next+n]...)
+	r.next += n
+	return ids, nil
+}
+
+func (r *TestRecorder) save() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var b strings.Builder
+	for _, id := range r.ids {
+		b.WriteString(id.String())
+		b.WriteByte('\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		r.t.Errorf("TestRecorder: failed to record IDs: %v", err)
+		return
+	}
+	if err := os.WriteFile(r.path, []byte(b.String()), 0o644); err != nil {
+		r.t.Errorf("TestRecorder: failed to record IDs: %v", err)
+	}
+}
+
+func readIDs(path string) ([]idgenerator.SnowflakeID, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ids []idgenerator.SnowflakeID
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		s := strings.TrimSpace(scanner.Text())
+		if s == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s:%d: %q", idgenerator.ErrInvalidID, path, line, s)
+		}
+		ids = append(ids, idgenerator.SnowflakeID(id))
+	}
+	return ids, scanner.Err()
+}
@@ -0,0 +1,89 @@
+package testutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func TestNewRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "ids.txt")
+
+	var recorded []idgenerator.SnowflakeID
+	t.Run("Record", func(t *testing.T) {
+		r := NewRecorder(t, idgenerator.SequentialMockGenerator(100), path)
+		id, err := r.Next()
+		if err != nil {
+			t.Fatalf("TestRecorder.Next() error = %v", err)
+		}
+		ids, err := r.NextN(2)
+		if err != nil {
+			t.Fatalf("TestRecorder.NextN() error = %v", err)
+		}
+		recorded = append([]idgenerator.SnowflakeID{id}, ids...)
+	})
+	if want := []idgenerator.SnowflakeID{100, 101, 102}; !reflect.DeepEqual(recorded, want) {
+		t.Fatalf("TestRecorder recorded %v, want %v", recorded, want)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	if got, want := string(b), "100\n101\n102\n"; got != want {
+		t.Errorf("recorded file = %q, want %q", got, want)
+	}
+
+	t.Run("Replay", func(t *testing.T) {
+		r := NewRecorder(t, idgenerator.SequentialMockGenerator(200), path)
+		got, err := r.NextN(3)
+		if err != nil {
+			t.Fatalf("TestRecorder.NextN() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, recorded) {
+			t.Errorf("TestRecorder.NextN() = %v, want %v", got, recorded)
+		}
+	})
+}
+
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestTestRecorder_NextN_Exhausted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(path, []byte("100\n101\n"), 0o644); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	tb := &fakeTB{TB: t}
+	r := NewRecorder(tb, idgenerator.SequentialMockGenerator(200), path)
+	if _, err := r.NextN(3); !errors.Is(err, idgenerator.ErrMockExhausted) {
+		t.Errorf("TestRecorder.NextN() error = %v, want %v", err, idgenerator.ErrMockExhausted)
+	}
+	if len(tb.errors) != 1 {
+		t.Errorf("TestRecorder.NextN() reported %d errors, want 1", len(tb.errors))
+	}
+	if got, err := r.NextN(2); err != nil || !reflect.DeepEqual(got, []idgenerator.SnowflakeID{100, 101}) {
+		t.Errorf("TestRecorder.NextN() = %v, %v, want %v", got, err, []idgenerator.SnowflakeID{100, 101})
+	}
+}
+
+func TestNewRecorder_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(path, []byte("100\nabc\n"), 0o644); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	if _, err := readIDs(path); !errors.Is(err, idgenerator.ErrInvalidID) {
+		t.Errorf("readIDs() error = %v, want %v", err, idgenerator.ErrInvalidID)
+	}
+}
@@ -0,0 +1,105 @@
+// Package tracing generates trace IDs and span IDs of the W3C Trace Context from Snowflake IDs,
+// e.g., to use them as request IDs propagated by the traceparent header.
+//
+// A trace ID is two consecutive Snowflake IDs, and a span ID is a Snowflake ID, both in big-endian,
+// so the IDs generated by the same Generator are unique and increase with time.
+package tracing
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+const (
+	// traceparentVersion is the version of the traceparent header this package generates.
+	traceparentVersion = "00"
+	// traceparentFlags is the trace flags of the generated traceparent header, which has the sampled flag set.
+	traceparentFlags = "01"
+	// traceparentLen is the length of the traceparent header of version 00.
+	traceparentLen = 2 + 1 + 32 + 1 + 16 + 1 + 2
+)
+
+var ErrInvalidTraceparent = errors.New("invalid traceparent")
+
+// Generator generates trace IDs and span IDs.
+// A Generator is safe for concurrent use by multiple goroutines if the IDGenerator is.
+type Generator struct {
+	g idgenerator.IDGenerator
+}
+
+// NewGenerator returns a new Generator generating IDs with g.
+// Share g with the other users of the node, such as *idgenerator.Generator, to keep the IDs unique and ordered.
+func NewGenerator(g idgenerator.IDGenerator) *Generator {
+	return &Generator{g: g}
+}
+
+// TraceID returns a new 16-byte trace ID, which is two consecutive Snowflake IDs.
+func (t *Generator) TraceID() ([16]byte, error) {
+	var traceID [16]byte
+	ids, err := t.g.NextN(2)
+	if err != nil {
+		return traceID, err
+	}
+	binary.BigEndian.PutUint64(traceID[:8], uint64(ids[0]))
+	binary.BigEndian.PutUint64(traceID[8:], uint64(ids[1]))
+	return traceID, nil
+}
+
+// SpanID returns a new 8-byte span ID, which is a Snowflake ID.
+func (t *Generator) SpanID() ([8]byte, error) {
+	var spanID [8]byte
+	id, err := t.g.Next()
+	if err != nil {
+		return spanID, err
+	}
+	binary.BigEndian.PutUint64(spanID[:], uint64(id))
+	return spanID, nil
+}
+
+// Traceparent returns the value of the traceparent header of version 00 with the sampled flag,
+// such as "00-0027e949003ef0010027e949003ef002-0027e949003ef003-01".
+func Traceparent(traceID [16]byte, spanID [8]byte) string {
+	return traceparentVersion + "-" + hex.EncodeToString(traceID[:]) + "-" + hex.EncodeToString(spanID[:]) + "-" + traceparentFlags
+}
+
+// ParseTraceparent returns the trace ID and the span ID, which is the parent ID, of the traceparent header value s.
+// As the W3C Trace Context specifies, the fields must be in lowercase hex, the IDs must not be all zeros,
+// and the fields after the trace flags are ignored in versions later than 00.
+// It returns ErrInvalidTraceparent if s is invalid.
+func ParseTraceparent(s string) (traceID [16]byte, spanID [8]byte, err error) {
+	if len(s) < traceparentLen || len(s) > traceparentLen && (s[:2] == traceparentVersion || s[traceparentLen] != '-') {
+		return traceID, spanID, fmt.Errorf("%w: %q has an invalid length", ErrInvalidTraceparent, s)
+	}
+	if s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return traceID, spanID, fmt.Errorf("%w: %q has no separators", ErrInvalidTraceparent, s)
+	}
+	var version, flags [1]byte
+	if err := decodeHex(version[:], s[:2]); err != nil || version[0] == 0xff {
+		return traceID, spanID, fmt.Errorf("%w: invalid version %q", ErrInvalidTraceparent, s[:2])
+	}
+	if err := decodeHex(traceID[:], s[3:35]); err != nil || traceID == [16]byte{} {
+		return [16]byte{}, spanID, fmt.Errorf("%w: invalid trace ID %q", ErrInvalidTraceparent, s[3:35])
+	}
+	if err := decodeHex(spanID[:], s[36:52]); err != nil || spanID == [8]byte{} {
+		return [16]byte{}, [8]byte{}, fmt.Errorf("%w: invalid parent ID %q", ErrInvalidTraceparent, s[36:52])
+	}
+	if err := decodeHex(flags[:], s[53:55]); err != nil {
+		return [16]byte{}, [8]byte{}, fmt.Errorf("%w: invalid trace flags %q", ErrInvalidTraceparent, s[53:55])
+	}
+	return traceID, spanID, nil
+}
+
+// decodeHex decodes s in lowercase hex into dst, which has the exact length.
+func decodeHex(dst []byte, s string) error {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return hex.InvalidByteError(c)
+		}
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
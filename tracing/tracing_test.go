@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"errors"
+	"testing"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func TestGenerator(t *testing.T) {
+	g := NewGenerator(idgenerator.SequentialMockGenerator(11234023837724673))
+	traceID, err := g.TraceID()
+	if err != nil {
+		t.Fatalf("TraceID() error = %v", err)
+	}
+	spanID, err := g.SpanID()
+	if err != nil {
+		t.Fatalf("SpanID() error = %v", err)
+	}
+	want := "00-0027e949003ef0010027e949003ef002-0027e949003ef003-01"
+	if got := Traceparent(traceID, spanID); got != want {
+		t.Errorf("Traceparent() = %v, want %v", got, want)
+	}
+
+	gotTraceID, gotSpanID, err := ParseTraceparent(want)
+	if err != nil {
+		t.Fatalf("ParseTraceparent() error = %v", err)
+	}
+	if gotTraceID != traceID || gotSpanID != spanID {
+		t.Errorf("ParseTraceparent() = %x, %x, want %x, %x", gotTraceID, gotSpanID, traceID, spanID)
+	}
+}
+
+func TestGenerator_Error(t *testing.T) {
+	g := NewGenerator(idgenerator.NewMockGenerator(1))
+	if _, err := g.TraceID(); !errors.Is(err, idgenerator.ErrMockExhausted) {
+		t.Errorf("TraceID() error = %v, want %v", err, idgenerator.ErrMockExhausted)
+	}
+	if _, err := g.SpanID(); err != nil {
+		t.Errorf("SpanID() error = %v", err)
+	}
+	if _, err := g.SpanID(); !errors.Is(err, idgenerator.ErrMockExhausted) {
+		t.Errorf("SpanID() error = %v, want %v", err, idgenerator.ErrMockExhausted)
+	}
+}
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		wantErr bool
+	}{
+		{"Example of the spec", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false},
+		{"Not sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", false},
+		{"Future version with more fields", "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what", false},
+		{"Error empty", "", true},
+		{"Error version 00 with more fields", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what", true},
+		{"Error future version without a separator", "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01what", true},
+		{"Error version ff", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true},
+		{"Error uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", true},
+		{"Error zero trace ID", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", true},
+		{"Error zero parent ID", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", true},
+		{"Error invalid flags", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0x", true},
+		{"Error invalid separator", "00_4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseTraceparent(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTraceparent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidTraceparent) {
+				t.Errorf("ParseTraceparent() error = %v, want %v", err, ErrInvalidTraceparent)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package idgenerator
+
+import "database/sql/driver"
+
+// ID is a Snowflake ID of the entity type T, which makes it a compile error
+// to pass an ID of one entity where an ID of another entity is expected.
+//
+// T is only a tag and is never instantiated. Since a method cannot have its own type parameters,
+// an ID[T] is converted to an ID of another entity only explicitly, e.g., ID[U](id).
+type ID[T any] int64
+
+// UserID is an example of an ID type. The unexported field makes the tag type distinct from the ones of other packages.
+// In your package, define a tag type for each entity, e.g., type order struct{} and type OrderID = ID[order].
+type UserID = ID[struct {
+	_    [0]byte
+	name string
+}]
+
+// Int64 returns the ID as int64.
+func (id ID[T]) Int64() int64 {
+	return int64(id)
+}
+
+// SnowflakeID returns the ID as an untyped SnowflakeID.
+func (id ID[T]) SnowflakeID() SnowflakeID {
+	return SnowflakeID(id)
+}
+
+// String returns the decimal representation of the ID.
+func (id ID[T]) String() string {
+	return SnowflakeID(id).String()
+}
+
+// MarshalJSON implements json.Marshaler. The ID is encoded as a quoted string, same as SnowflakeID.
+func (id ID[T]) MarshalJSON() ([]byte, error) {
+	return SnowflakeID(id).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Both a quoted string and a number are accepted, same as SnowflakeID.
+func (id *ID[T]) UnmarshalJSON(b []byte) error {
+	return (*SnowflakeID)(id).UnmarshalJSON(b)
+}
+
+// Scan implements sql.Scanner, same as SnowflakeID.
+func (id *ID[T]) Scan(src any) error {
+	return (*SnowflakeID)(id).Scan(src)
+}
+
+// Value implements driver.Valuer. The ID is stored as int64.
+func (id ID[T]) Value() (driver.Value, error) {
+	return int64(id), nil
+}
+
+// TypedGenerator generates IDs of the entity type T with a Generator.
+//
+// It is not named Generator[T], since a generic type cannot have the same name as the Generator type.
+// A TypedGenerator is safe for concurrent use by multiple goroutines.
+type TypedGenerator[T any] struct {
+	g *Generator
+}
+
+// NewTypedGenerator returns a new TypedGenerator generating IDs of T with g.
+// A Generator can be shared by TypedGenerators of different entity types.
+func NewTypedGenerator[T any](g *Generator) *TypedGenerator[T] {
+	return &TypedGenerator[T]{g: g}
+}
+
+// Next returns a new generated ID.
+func (tg *TypedGenerator[T]) Next() (ID[T], error) {
+	id, err := tg.g.Next()
+	if err != nil {
+		return 0, err
+	}
+	return ID[T](id), nil
+}
+
+// NextN returns n new generated IDs in increasing order.
+func (tg *TypedGenerator[T]) NextN(n int) ([]ID[T], error) {
+	ids, err := tg.g.NextN(n)
+	if err != nil {
+		return nil, err
+	}
+	typed := make([]ID[T], len(ids))
+	for i, id := range ids {
+		typed[i] = ID[T](id)
+	}
+	return typed, nil
+}
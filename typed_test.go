@@ -0,0 +1,89 @@
+package idgenerator
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type order struct{}
+
+type OrderID = ID[order]
+
+func TestID(t *testing.T) {
+	id := UserID(11234023837724673)
+	if got := id.Int64(); got != 11234023837724673 {
+		t.Errorf("Int64() = %v, want %v", got, int64(11234023837724673))
+	}
+	if got := id.SnowflakeID(); got != SnowflakeID(11234023837724673) {
+		t.Errorf("SnowflakeID() = %v, want %v", got, SnowflakeID(11234023837724673))
+	}
+	if got := id.String(); got != "11234023837724673" {
+		t.Errorf("String() = %v, want %v", got, "11234023837724673")
+	}
+
+	b, err := json.Marshal(struct {
+		UserID  UserID  `json:"user_id"`
+		OrderID OrderID `json:"order_id"`
+	}{id, OrderID(1)})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if got, want := string(b), `{"user_id":"11234023837724673","order_id":"1"}`; got != want {
+		t.Errorf("json.Marshal() = %v, want %v", got, want)
+	}
+
+	var got UserID
+	if err := json.Unmarshal([]byte(`"11234023837724673"`), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got != id {
+		t.Errorf("json.Unmarshal() = %v, want %v", got, id)
+	}
+
+	var scanned OrderID
+	if err := scanned.Scan("11234023837724673"); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if v, err := scanned.Value(); err != nil || v != int64(11234023837724673) {
+		t.Errorf("Value() = %v, %v, want %v", v, err, int64(11234023837724673))
+	}
+	if err := scanned.Scan(1.5); err == nil {
+		t.Errorf("Scan() error = %v, wantErr %v", err, true)
+	}
+}
+
+func TestTypedGenerator(t *testing.T) {
+	c := NewSimulatedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	g, err := NewGenerator(WithClock(c), WithDatacenterID(31), WithMachineID(15))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	users := NewTypedGenerator[struct {
+		_    [0]byte
+		name string
+	}](g)
+	orders := NewTypedGenerator[order](g)
+
+	var userID UserID
+	userID, err = users.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if userID != 11234023837724672 {
+		t.Errorf("Next() = %v, want %v", userID, 11234023837724672)
+	}
+
+	var orderIDs []OrderID
+	orderIDs, err = orders.NextN(2)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if len(orderIDs) != 2 || orderIDs[0] != 11234023837724673 || orderIDs[1] != 11234023837724674 {
+		t.Errorf("NextN() = %v, want %v", orderIDs, []OrderID{11234023837724673, 11234023837724674})
+	}
+
+	if _, err := orders.NextN(0); err != ErrInvalidCount {
+		t.Errorf("NextN() error = %v, want %v", err, ErrInvalidCount)
+	}
+}
@@ -0,0 +1,198 @@
+// Package ulid generates ULIDs (Universally Unique Lexicographically Sortable Identifiers).
+//
+// A ULID is a 128-bit identifier composed of a 48-bit timestamp in milliseconds and an 80-bit random component,
+// encoded in Crockford's Base32 as a 26-character string. See https://github.com/ulid/spec.
+package ulid
+
+import (
+	crand "crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+const (
+	// alphabet is the Crockford's Base32 alphabet, which excludes I, L, O and U.
+	alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// encodedLength is the number of characters of an encoded ULID.
+	encodedLength = 26
+	// entropyLength is the number of bytes of the random component.
+	entropyLength = 10
+	maxTime       = int64(1)<<48 - 1
+)
+
+var (
+	ErrInvalidULID     = errors.New("invalid ULID")
+	ErrInvalidTime     = errors.New("time out of the ULID range")
+	ErrEntropyOverflow = errors.New("random component overflowed in the same millisecond")
+)
+
+var index = func() [256]int8 {
+	var index [256]int8
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		index[c] = int8(i)
+		index[c|0x20] = int8(i) // lower case
+	}
+	return index
+}()
+
+// ULIDComponents represents the fields of a ULID.
+type ULIDComponents struct {
+	Time    time.Time
+	Entropy [entropyLength]byte
+}
+
+// Generator generates monotonic ULIDs.
+//
+// Within the same millisecond, the random component is incremented from the previous ULID instead of re-randomized,
+// so that ULIDs generated by a Generator are strictly increasing.
+// A Generator is safe for concurrent use by multiple goroutines.
+type Generator struct {
+	clock   idgenerator.ClockSource
+	entropy io.Reader
+
+	lastTime    int64
+	lastEntropy [entropyLength]byte
+
+	mutex sync.Mutex
+}
+
+// Option configures a Generator.
+type Option func(*Generator) error
+
+// WithClock specifies the ClockSource of the timestamp. The default is the system clock.
+func WithClock(cs idgenerator.ClockSource) Option {
+	return func(g *Generator) error {
+		if cs == nil {
+			return idgenerator.ErrInvalidClock
+		}
+		g.clock = cs
+		return nil
+	}
+}
+
+// WithSeed uses math/rand seeded with seed for the random component instead of crypto/rand.
+// It makes ULIDs reproducible, so use it only in tests.
+func WithSeed(seed int64) Option {
+	return func(g *Generator) error {
+		g.entropy = rand.New(rand.NewSource(seed))
+		return nil
+	}
+}
+
+// NewGenerator returns a new Generator.
+func NewGenerator(opts ...Option) (*Generator, error) {
+	g := &Generator{clock: systemClock{}, entropy: crand.Reader}
+	for _, f := range opts {
+		if err := f(g); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+var defaultGenerator, _ = NewGenerator()
+
+// New returns a new ULID generated by the default Generator, which uses the system clock and crypto/rand.
+func New() (string, error) {
+	return defaultGenerator.New()
+}
+
+// New returns a new ULID.
+//
+// If the clock moved backward, the timestamp of the previous ULID is reused to keep ULIDs increasing.
+// It returns ErrEntropyOverflow if the random component cannot be incremented in the same millisecond,
+// and keeps returning it until the clock moves to the next millisecond.
+func (g *Generator) New() (string, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	ms := g.clock.Now().UnixMilli()
+	if ms < 0 || ms > maxTime {
+		return "", ErrInvalidTime
+	}
+	if ms <= g.lastTime {
+		// Increment a copy, so that an overflow keeps the last entropy instead of wrapping it to zero.
+		next := g.lastEntropy
+		if !increment(&next) {
+			return "", ErrEntropyOverflow
+		}
+		g.lastEntropy = next
+	} else {
+		if _, err := io.ReadFull(g.entropy, g.lastEntropy[:]); err != nil {
+			return "", err
+		}
+		g.lastTime = ms
+	}
+	return encode(g.lastTime, g.lastEntropy), nil
+}
+
+// increment adds 1 to the big-endian value of b, and returns false if it overflows.
+func increment(b *[entropyLength]byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func encode(ms int64, entropy [entropyLength]byte) string {
+	hi := uint64(ms)<<16 | uint64(entropy[0])<<8 | uint64(entropy[1])
+	var lo uint64
+	for _, c := range entropy[2:] {
+		lo = lo<<8 | uint64(c)
+	}
+
+	var b [encodedLength]byte
+	for i := encodedLength - 1; i >= 0; i-- {
+		b[i] = alphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(b[:])
+}
+
+// Parse decodes a ULID. The input is case-insensitive.
+func Parse(s string) (ULIDComponents, error) {
+	if len(s) != encodedLength {
+		return ULIDComponents{}, fmt.Errorf("%w: %q must be %d characters", ErrInvalidULID, s, encodedLength)
+	}
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := index[s[i]]
+		if v < 0 {
+			return ULIDComponents{}, fmt.Errorf("%w: %q contains %q", ErrInvalidULID, s, s[i])
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	// The first character holds only 3 bits, since 26 characters hold 130 bits.
+	if index[s[0]] > 7 {
+		return ULIDComponents{}, fmt.Errorf("%w: %q overflows 128 bits", ErrInvalidULID, s)
+	}
+
+	c := ULIDComponents{Time: time.UnixMilli(int64(hi >> 16)).UTC()}
+	c.Entropy[0], c.Entropy[1] = byte(hi>>8), byte(hi)
+	for i := entropyLength - 1; i >= 2; i-- {
+		c.Entropy[i] = byte(lo)
+		lo >>= 8
+	}
+	return c, nil
+}
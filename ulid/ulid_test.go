@@ -0,0 +1,202 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func TestParse(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    ULIDComponents
+		wantErr bool
+	}{
+		{
+			// The example in the ULID specification.
+			"01ARZ3NDEKTSV4RRFFQ69G5FAV",
+			args{"01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+			ULIDComponents{
+				Time:    time.UnixMilli(1469922850259).UTC(),
+				Entropy: [10]byte{0xd6, 0x76, 0x4c, 0x61, 0xef, 0xb9, 0x93, 0x02, 0xbd, 0x5b},
+			},
+			false,
+		},
+		{
+			"Lower case",
+			args{"01arz3ndektsv4rrffq69g5fav"},
+			ULIDComponents{
+				Time:    time.UnixMilli(1469922850259).UTC(),
+				Entropy: [10]byte{0xd6, 0x76, 0x4c, 0x61, 0xef, 0xb9, 0x93, 0x02, 0xbd, 0x5b},
+			},
+			false,
+		},
+		{
+			"Max",
+			args{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+			ULIDComponents{
+				Time:    time.UnixMilli(maxTime).UTC(),
+				Entropy: [10]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			},
+			false,
+		},
+		{"Error overflow", args{"80000000000000000000000000"}, ULIDComponents{}, true},
+		{"Error too short", args{"01ARZ3NDEKTSV4RRFFQ69G5FA"}, ULIDComponents{}, true},
+		{"Error invalid character", args{"01ARZ3NDEKTSV4RRFFQ69G5FAU"}, ULIDComponents{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.args.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr || tt.name == "Lower case" {
+				return
+			}
+			if s := encode(got.Time.UnixMilli(), got.Entropy); s != tt.args.s {
+				t.Errorf("encode() = %v, want %v", s, tt.args.s)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	prev, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	for i := 0; i < 10000; i++ {
+		got, err := New()
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if got <= prev {
+			t.Fatalf("New() returned %v after %v, want increasing ULIDs", got, prev)
+		}
+		prev = got
+	}
+}
+
+func TestGenerator_New(t *testing.T) {
+	at := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	c := idgenerator.NewSimulatedClock(at)
+	g, err := NewGenerator(WithClock(c), WithSeed(1))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	first, err := g.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	// The random component is incremented within the same millisecond.
+	second, err := g.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	a, _ := Parse(first)
+	b, _ := Parse(second)
+	if !a.Time.Equal(at) || !b.Time.Equal(at) {
+		t.Errorf("New() time = %v, %v, want %v", a.Time, b.Time, at)
+	}
+	increment(&a.Entropy)
+	if b.Entropy != a.Entropy {
+		t.Errorf("New() entropy = %x, want %x", b.Entropy, a.Entropy)
+	}
+
+	// The same seed gives the same ULIDs.
+	g2, err := NewGenerator(WithClock(idgenerator.NewSimulatedClock(at)), WithSeed(1))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if got, _ := g2.New(); got != first {
+		t.Errorf("New() with the same seed = %v, want %v", got, first)
+	}
+
+	// The clock moving backward keeps the ULIDs increasing.
+	c.Advance(-time.Second)
+	third, err := g.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if third <= second {
+		t.Errorf("New() returned %v after %v, want increasing ULIDs", third, second)
+	}
+
+	c.Advance(2 * time.Second)
+	fourth, err := g.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got, _ := Parse(fourth); !got.Time.Equal(c.Now()) {
+		t.Errorf("New() time = %v, want %v", got.Time, c.Now())
+	}
+}
+
+func TestGenerator_New_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		at      time.Time
+		entropy [10]byte
+		wantErr error
+	}{
+		{"Error entropy overflow", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), [10]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, ErrEntropyOverflow},
+		{"Error before 1970", time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC), [10]byte{}, ErrInvalidTime},
+		{"Error after 10889", time.Date(10890, 1, 1, 0, 0, 0, 0, time.UTC), [10]byte{}, ErrInvalidTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(WithClock(idgenerator.NewSimulatedClock(tt.at)))
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			g.lastTime = tt.at.UnixMilli()
+			g.lastEntropy = tt.entropy
+			if _, err := g.New(); err != tt.wantErr {
+				t.Errorf("New() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerator_New_EntropyOverflow(t *testing.T) {
+	at := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	c := idgenerator.NewSimulatedClock(at)
+	g, err := NewGenerator(WithClock(c))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	g.lastTime = at.UnixMilli()
+	g.lastEntropy = [10]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	last := encode(g.lastTime, g.lastEntropy)
+
+	// The overflow does not wrap the entropy to zero, which would return lower ULIDs.
+	for i := 0; i < 2; i++ {
+		if got, err := g.New(); err != ErrEntropyOverflow {
+			t.Fatalf("New() = %v, %v, want %v", got, err, ErrEntropyOverflow)
+		}
+	}
+	c.Advance(time.Millisecond)
+	got, err := g.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got <= last {
+		t.Errorf("New() returned %v after %v, want increasing ULIDs", got, last)
+	}
+}
+
+func TestNewGenerator(t *testing.T) {
+	if _, err := NewGenerator(WithClock(nil)); err != idgenerator.ErrInvalidClock {
+		t.Errorf("NewGenerator() error = %v, want %v", err, idgenerator.ErrInvalidClock)
+	}
+}
@@ -0,0 +1,177 @@
+package idgenerator
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// UniquenessChecker detects IDs issued again, e.g., by a Generator restarted after a crash without a checkpoint
+// while the clock moved backward.
+//
+// It keeps the sequence numbers issued in a recent time window in a bitset per millisecond (or the time unit of the layout),
+// which rotate as the time goes on to bound the memory. The issued IDs are appended to a file, and loaded again at startup.
+// IDs older than the window are not checked.
+// A UniquenessChecker is safe for concurrent use by multiple goroutines.
+type UniquenessChecker struct {
+	layout Layout
+	file   *os.File
+
+	buckets []uniquenessBucket
+	latest  int64
+
+	mutex sync.Mutex
+}
+
+// uniquenessBucket is the issued sequence numbers of a time unit, keyed by the ID without the sequence number,
+// which is usually only one unless IDs of multiple datacenter IDs or machine IDs are recorded.
+type uniquenessBucket struct {
+	timestamp int64
+	bitsets   map[SnowflakeID][]uint64
+}
+
+// NewUniquenessChecker returns a new UniquenessChecker of the IDs in layout issued in the last window,
+// which records them to the file at path. The IDs already recorded in the file are loaded.
+// If layout is zero, LayoutTwitterSnowflake is used.
+//
+// The memory is about window / the time unit * 2^sequenceBits / 8 bytes at most, e.g., 512 KB for a second in the default layout.
+// It returns ErrInvalidWindow if window is shorter than the time unit of the layout.
+func NewUniquenessChecker(path string, window time.Duration, layout Layout) (*UniquenessChecker, error) {
+	if layout == (Layout{}) {
+		layout = LayoutTwitterSnowflake
+	} else if err := layout.validate(); err != nil {
+		return nil, err
+	}
+	n := int64(window / layout.timeUnit())
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: %v is shorter than %v", ErrInvalidWindow, window, layout.timeUnit())
+	}
+	c := &UniquenessChecker{layout: layout, buckets: make([]uniquenessBucket, n)}
+
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	// A partial record at the end, written on a crash, is ignored.
+	for i := 0; i+8 <= len(b); i += 8 {
+		c.set(SnowflakeID(binary.BigEndian.Uint64(b[i:])))
+	}
+	// Compact the file to the IDs in the window, so that it does not grow across restarts.
+	if err := c.compact(path); err != nil {
+		return nil, err
+	}
+	if c.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Assert returns ErrDuplicateID if id has been recorded, and nil otherwise, including when id is older than the window.
+func (c *UniquenessChecker) Assert(id SnowflakeID) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.has(id) {
+		return fmt.Errorf("%w: %v", ErrDuplicateID, id)
+	}
+	return nil
+}
+
+// Record marks id as issued, and appends it to the file.
+// The file is written on each call, so the ID survives a crash of the process. Call Sync to survive a crash of the host.
+func (c *UniquenessChecker) Record(id SnowflakeID) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.set(id)
+	_, err := c.file.Write(binary.BigEndian.AppendUint64(nil, uint64(id)))
+	return err
+}
+
+// Sync commits the recorded IDs to stable storage.
+func (c *UniquenessChecker) Sync() error {
+	return c.file.Sync()
+}
+
+// Close closes the file.
+func (c *UniquenessChecker) Close() error {
+	return c.file.Close()
+}
+
+func (c *UniquenessChecker) split(id SnowflakeID) (ts int64, key SnowflakeID, seq int) {
+	ts = int64(id) >> c.layout.timestampShift() & c.layout.maxTimestamp()
+	seq = c.layout.ExtractSequenceNumber(int64(id))
+	key = id &^ SnowflakeID(c.layout.maxSequenceNumber()<<c.layout.sequenceShift())
+	return ts, key, seq
+}
+
+// inWindow reports whether ts is in the window ending at the latest recorded timestamp.
+func (c *UniquenessChecker) inWindow(ts int64) bool {
+	return ts > c.latest-int64(len(c.buckets))
+}
+
+func (c *UniquenessChecker) has(id SnowflakeID) bool {
+	ts, key, seq := c.split(id)
+	if !c.inWindow(ts) {
+		return false
+	}
+	b := &c.buckets[ts%int64(len(c.buckets))]
+	if b.timestamp != ts {
+		return false
+	}
+	bits := b.bitsets[key]
+	return bits != nil && bits[seq/64]&(1<<(seq%64)) != 0
+}
+
+func (c *UniquenessChecker) set(id SnowflakeID) {
+	ts, key, seq := c.split(id)
+	c.latest = max(c.latest, ts)
+	if !c.inWindow(ts) {
+		return
+	}
+	b := &c.buckets[ts%int64(len(c.buckets))]
+	if b.timestamp != ts || b.bitsets == nil {
+		// Rotate the bucket of a time unit out of the window.
+		*b = uniquenessBucket{timestamp: ts, bitsets: make(map[SnowflakeID][]uint64, 1)}
+	}
+	bits := b.bitsets[key]
+	if bits == nil {
+		bits = make([]uint64, (c.layout.maxSequenceNumber()+64)/64)
+		b.bitsets[key] = bits
+	}
+	bits[seq/64] |= 1 << (seq % 64)
+}
+
+// compact rewrites the file at path with the IDs in the window, replacing it atomically.
+func (c *UniquenessChecker) compact(path string) error {
+	var b []byte
+	for _, bucket := range c.buckets {
+		if bucket.bitsets == nil || !c.inWindow(bucket.timestamp) {
+			continue
+		}
+		for key, bits := range bucket.bitsets {
+			for seq := 0; seq < len(bits)*64; seq++ {
+				if bits[seq/64]&(1<<(seq%64)) != 0 {
+					id := key | SnowflakeID(seq<<c.layout.sequenceShift())
+					b = binary.BigEndian.AppendUint64(b, uint64(id))
+				}
+			}
+		}
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
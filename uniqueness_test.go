@@ -0,0 +1,128 @@
+package idgenerator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUniquenessChecker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issued")
+	c, err := NewUniquenessChecker(path, time.Second, Layout{})
+	if err != nil {
+		t.Fatalf("NewUniquenessChecker() error = %v", err)
+	}
+
+	const id = SnowflakeID(11234023837724673)
+	if err := c.Assert(id); err != nil {
+		t.Errorf("Assert() error = %v, want nil", err)
+	}
+	if err := c.Record(id); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := c.Assert(id); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Assert() error = %v, want %v", err, ErrDuplicateID)
+	}
+	// The other sequence numbers and machine IDs of the same millisecond are not issued.
+	for _, other := range []SnowflakeID{id + 1, id - 1, id - 1<<machineBitShift} {
+		if err := c.Assert(other); err != nil {
+			t.Errorf("Assert(%v) error = %v, want nil", other, err)
+		}
+	}
+	if err := c.Sync(); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// The recorded IDs are loaded after a restart.
+	c, err = NewUniquenessChecker(path, time.Second, Layout{})
+	if err != nil {
+		t.Fatalf("NewUniquenessChecker() error = %v", err)
+	}
+	defer c.Close()
+	if err := c.Assert(id); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Assert() error = %v after restart, want %v", err, ErrDuplicateID)
+	}
+}
+
+func TestUniquenessChecker_Window(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issued")
+	c, err := NewUniquenessChecker(path, 10*time.Millisecond, Layout{})
+	if err != nil {
+		t.Fatalf("NewUniquenessChecker() error = %v", err)
+	}
+	const old = SnowflakeID(11234023837724673)
+	recent := old + 10<<timestampBitShift
+	for _, id := range []SnowflakeID{old, recent} {
+		if err := c.Record(id); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	// The ID 10 milliseconds before the latest one is out of the window, and is not checked.
+	if err := c.Assert(old); err != nil {
+		t.Errorf("Assert() error = %v, want nil", err)
+	}
+	if err := c.Assert(recent); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Assert() error = %v, want %v", err, ErrDuplicateID)
+	}
+	c.Close()
+
+	// The file is compacted to the IDs in the window, and a partial record at the end is ignored.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatalf("os.OpenFile() error = %v", err)
+	}
+	f.Write([]byte{0, 1, 2})
+	f.Close()
+	c, err = NewUniquenessChecker(path, 10*time.Millisecond, Layout{})
+	if err != nil {
+		t.Fatalf("NewUniquenessChecker() error = %v", err)
+	}
+	defer c.Close()
+	if err := c.Assert(recent); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Assert() error = %v after restart, want %v", err, ErrDuplicateID)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("os.Stat() error = %v", err)
+	}
+	if got, want := info.Size(), int64(8); got != want {
+		t.Errorf("file size = %v, want %v", got, want)
+	}
+}
+
+func TestUniquenessChecker_Layout(t *testing.T) {
+	c, err := NewUniquenessChecker(filepath.Join(t.TempDir(), "issued"), time.Second, LayoutSonyflake)
+	if err != nil {
+		t.Fatalf("NewUniquenessChecker() error = %v", err)
+	}
+	defer c.Close()
+	// The sequence number is above the machine ID in LayoutSonyflake.
+	const id = SnowflakeID(498645703065670196)
+	if err := c.Record(id); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := c.Assert(id); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Assert() error = %v, want %v", err, ErrDuplicateID)
+	}
+	if err := c.Assert(id + 1<<16); err != nil {
+		t.Errorf("Assert() error = %v, want nil", err)
+	}
+}
+
+func TestNewUniquenessChecker_Error(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewUniquenessChecker(filepath.Join(dir, "issued"), time.Microsecond, Layout{}); !errors.Is(err, ErrInvalidWindow) {
+		t.Errorf("NewUniquenessChecker() error = %v, want %v", err, ErrInvalidWindow)
+	}
+	if _, err := NewUniquenessChecker(filepath.Join(dir, "issued"), time.Second, Layout{TimestampBits: 41, SequenceBits: 24}); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("NewUniquenessChecker() error = %v, want %v", err, ErrInvalidLayout)
+	}
+	if _, err := NewUniquenessChecker(filepath.Join(dir, "missing", "issued"), time.Second, Layout{}); err == nil {
+		t.Error("NewUniquenessChecker() error = nil for a missing directory, want an error")
+	}
+}
@@ -0,0 +1,153 @@
+// Package uuidv7 generates UUIDs of version 7 defined in RFC 9562.
+//
+// A UUIDv7 is composed of a 48-bit timestamp in milliseconds, the 4-bit version,
+// a 12-bit sub-millisecond fraction, the 2-bit variant, and 62 random bits.
+package uuidv7
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+const (
+	// subMilliBits is the number of bits of the sub-millisecond fraction.
+	subMilliBits = 12
+	// maxTimestamp is the largest timestamp in units of 1/4096 milliseconds.
+	maxTimestamp = int64(1)<<(48+subMilliBits) - 1
+)
+
+var ErrInvalidTime = errors.New("time out of the UUIDv7 range")
+
+// Generator generates monotonic UUIDv7s.
+//
+// The sub-millisecond fraction is filled with the clock precision as the method 3 of RFC 9562.
+// When the clock has not advanced since the previous UUID, the timestamp and the fraction are incremented together
+// as a counter, so that UUIDs generated by a Generator are strictly increasing.
+// A Generator is safe for concurrent use by multiple goroutines.
+type Generator struct {
+	clock    idgenerator.ClockSource
+	baseTime time.Time
+	random   io.Reader
+
+	// last is the timestamp of the previous UUID in units of 1/4096 milliseconds.
+	last int64
+
+	mutex sync.Mutex
+}
+
+// Option configures a Generator.
+type Option func(*Generator) error
+
+// WithClock specifies the ClockSource of the timestamp. The default is the system clock.
+func WithClock(cs idgenerator.ClockSource) Option {
+	return func(g *Generator) error {
+		if cs == nil {
+			return idgenerator.ErrInvalidClock
+		}
+		g.clock = cs
+		return nil
+	}
+}
+
+// WithBaseTime changes the base time of the timestamp from the Unix epoch.
+// The UUIDs are still sortable by time, but the embedded timestamp is no longer the Unix time RFC 9562 specifies.
+func WithBaseTime(v time.Time) Option {
+	return func(g *Generator) error {
+		g.baseTime = v
+		return nil
+	}
+}
+
+// NewGenerator returns a new Generator.
+func NewGenerator(opts ...Option) (*Generator, error) {
+	g := &Generator{clock: systemClock{}, baseTime: time.Unix(0, 0), random: crand.Reader}
+	for _, f := range opts {
+		if err := f(g); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+var defaultGenerator, _ = NewGenerator()
+
+// New returns a new UUIDv7 generated by the default Generator, which uses the system clock and crypto/rand.
+func New() ([16]byte, error) {
+	return defaultGenerator.New()
+}
+
+// NewString returns a new UUIDv7 generated by the default Generator in the form of xxxxxxxx-xxxx-7xxx-xxxx-xxxxxxxxxxxx.
+func NewString() (string, error) {
+	return defaultGenerator.NewString()
+}
+
+// New returns a new UUIDv7.
+func (g *Generator) New() ([16]byte, error) {
+	var u [16]byte
+	if _, err := io.ReadFull(g.random, u[8:]); err != nil {
+		return u, err
+	}
+
+	g.mutex.Lock()
+	elapsed := g.clock.Now().Sub(g.baseTime)
+	if elapsed < 0 {
+		g.mutex.Unlock()
+		return u, ErrInvalidTime
+	}
+	ts := int64(elapsed/time.Millisecond)<<subMilliBits | int64(elapsed%time.Millisecond)<<subMilliBits/int64(time.Millisecond)
+	if ts <= g.last {
+		ts = g.last + 1
+	}
+	if ts > maxTimestamp {
+		g.mutex.Unlock()
+		return u, ErrInvalidTime
+	}
+	g.last = ts
+	g.mutex.Unlock()
+
+	ms := ts >> subMilliBits
+	for i := 5; i >= 0; i-- {
+		u[i] = byte(ms)
+		ms >>= 8
+	}
+	u[6] = 0x70 | byte(ts>>8)&0x0f
+	u[7] = byte(ts)
+	u[8] = 0x80 | u[8]&0x3f
+	return u, nil
+}
+
+// NewString returns a new UUIDv7 in the form of xxxxxxxx-xxxx-7xxx-xxxx-xxxxxxxxxxxx.
+func (g *Generator) NewString() (string, error) {
+	u, err := g.New()
+	if err != nil {
+		return "", err
+	}
+	return String(u), nil
+}
+
+// String returns u in the form of xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func String(u [16]byte) string {
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
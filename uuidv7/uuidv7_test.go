@@ -0,0 +1,125 @@
+package uuidv7
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+
+	idgenerator "github.com/kawabatas/go-id-generator"
+)
+
+func TestString(t *testing.T) {
+	// The example UUIDv7 in RFC 9562 Appendix A.6.
+	u := [16]byte{0x01, 0x7f, 0x22, 0xe2, 0x79, 0xb0, 0x7c, 0xc3, 0x98, 0xc4, 0xdc, 0x0c, 0x0c, 0x07, 0x39, 0x8f}
+	if got, want := String(u), "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"; got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+}
+
+func TestNewString(t *testing.T) {
+	format := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	prev, err := NewString()
+	if err != nil {
+		t.Fatalf("NewString() error = %v", err)
+	}
+	for i := 0; i < 10000; i++ {
+		got, err := NewString()
+		if err != nil {
+			t.Fatalf("NewString() error = %v", err)
+		}
+		if !format.MatchString(got) {
+			t.Fatalf("NewString() = %v, want UUIDv7 format", got)
+		}
+		if got <= prev {
+			t.Fatalf("NewString() returned %v after %v, want increasing UUIDs", got, prev)
+		}
+		prev = got
+	}
+}
+
+func TestGenerator_New(t *testing.T) {
+	type args struct {
+		opts []Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			// The timestamp of the example UUIDv7 in RFC 9562 Appendix A.6.
+			"RFC 9562 example time",
+			args{[]Option{
+				WithClock(idgenerator.NewSimulatedClock(time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC))),
+			}},
+			[]string{
+				"017f22e2-79b0-7000-8000-000000000000",
+				"017f22e2-79b0-7001-8000-000000000000",
+			},
+			false,
+		},
+		{
+			"Sub-millisecond fraction",
+			args{[]Option{
+				WithClock(idgenerator.NewSimulatedClock(time.Date(2022, 2, 22, 19, 22, 22, 500*int(time.Microsecond), time.UTC))),
+			}},
+			[]string{
+				"017f22e2-79b0-7800-8000-000000000000",
+				"017f22e2-79b0-7801-8000-000000000000",
+			},
+			false,
+		},
+		{
+			"WithBaseTime",
+			args{[]Option{
+				WithClock(idgenerator.NewSimulatedClock(time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC))),
+				WithBaseTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			}},
+			[]string{
+				"00000000-03e8-7000-8000-000000000000",
+				"00000000-03e8-7001-8000-000000000000",
+			},
+			false,
+		},
+		{
+			"Error before base time",
+			args{[]Option{
+				WithClock(idgenerator.NewSimulatedClock(time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC))),
+			}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(tt.args.opts...)
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			g.random = bytes.NewReader(make([]byte, 1024))
+
+			for _, want := range tt.want {
+				got, err := g.NewString()
+				if err != nil {
+					t.Fatalf("NewString() error = %v", err)
+				}
+				if got != want {
+					t.Errorf("NewString() = %v, want %v", got, want)
+				}
+			}
+			if tt.wantErr {
+				if _, err := g.New(); err != ErrInvalidTime {
+					t.Errorf("New() error = %v, want %v", err, ErrInvalidTime)
+				}
+			}
+		})
+	}
+}
+
+func TestNewGenerator(t *testing.T) {
+	if _, err := NewGenerator(WithClock(nil)); err != idgenerator.ErrInvalidClock {
+		t.Errorf("NewGenerator() error = %v, want %v", err, idgenerator.ErrInvalidClock)
+	}
+}
@@ -0,0 +1,161 @@
+package idgenerator
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	// layoutTagBits is the number of the top bits of the sequence number used for the tag of an ID with bit 63 set.
+	layoutTagBits = 2
+	// layoutTagShift is the position of the tag, which is bits 10-11,
+	// the top 2 bits of the sequence number in LayoutTwitterSnowflake.
+	layoutTagShift   = sequenceNumBitRange - layoutTagBits
+	maxLayoutVersion = 1<<layoutTagBits - 1
+	// reservedBitTag is the tag of the IDs generated with WithReservedBit(true), which is not a layout version,
+	// so that they are told apart from the IDs generated with WithLayoutVersion.
+	reservedBitTag = 0
+)
+
+var (
+	layoutVersionsMutex sync.RWMutex
+	// layoutVersions maps a layout version to its layout. Version 0 is always LayoutTwitterSnowflake, whose IDs are not tagged.
+	layoutVersions = map[uint8]Layout{0: LayoutTwitterSnowflake}
+)
+
+// RegisterLayoutVersion registers l as layout version v from 1 to 3 for WithLayoutVersion and DetectLayout.
+// Version 0 is LayoutTwitterSnowflake, the layout of the IDs without bit 63. Register the same versions in every process that generates or parses the IDs,
+// e.g., in an init function, and never change the layout of a version once IDs are generated with it.
+//
+// The top 2 bits of the sequence number of l must be bits 10-11, as in LayoutTwitterSnowflake,
+// e.g., Layout{TimestampBits: 41, MachineBits: 10, SequenceBits: 12, TimeUnit: 10 * time.Millisecond},
+// and l must leave bit 63 unused. Otherwise, it returns ErrInvalidLayout.
+// It returns ErrInvalidLayoutVersion if v is out of range, or is already registered with another layout.
+func RegisterLayoutVersion(v uint8, l Layout) error {
+	if v == 0 || v > maxLayoutVersion {
+		return fmt.Errorf("%w: %d is not from 1 to %d", ErrInvalidLayoutVersion, v, maxLayoutVersion)
+	}
+	if err := l.validate(); err != nil {
+		return err
+	}
+	if !l.taggable() {
+		return fmt.Errorf("%w: the top bits of the sequence number must be bits %d-%d, and bit 63 must be unused",
+			ErrInvalidLayout, layoutTagShift, sequenceNumBitRange-1)
+	}
+
+	layoutVersionsMutex.Lock()
+	defer layoutVersionsMutex.Unlock()
+	if registered, ok := layoutVersions[v]; ok && registered != l {
+		return fmt.Errorf("%w: %d is already registered", ErrInvalidLayoutVersion, v)
+	}
+	layoutVersions[v] = l
+	return nil
+}
+
+func lookupLayoutVersion(v uint8) (Layout, error) {
+	layoutVersionsMutex.RLock()
+	defer layoutVersionsMutex.RUnlock()
+	l, ok := layoutVersions[v]
+	if !ok {
+		return Layout{}, fmt.Errorf("%w: %d is not registered", ErrInvalidLayoutVersion, v)
+	}
+	return l, nil
+}
+
+// WithLayoutVersion generates IDs in the layout of version v, and marks them with the version,
+// so that DetectLayout, ParseSnowflakeID, and the Extract functions find the layout. See RegisterLayoutVersion for the versions.
+//
+// The version is stored in bit 63 as the flag, and the top 2 bits of the sequence number,
+// so the sequence number is 2 bits narrower, e.g., up to 1024 IDs per millisecond. The IDs are negative as int64.
+// Version 0 is LayoutTwitterSnowflake, whose IDs are not marked: they are the IDs without bit 63,
+// so WithLayoutVersion(0) generates the same IDs as LayoutTwitterSnowflake.
+// It cannot be used with WithLayout, WithReservedBit(true), or WithObfuscation, which return ErrInvalidLayout or ErrUnsupportedOption.
+// It returns ErrInvalidLayoutVersion if v is not registered.
+func WithLayoutVersion(v uint8) Option {
+	return func(s *snowflake) error {
+		l, err := lookupVersionedLayout(v)
+		if err != nil {
+			return err
+		}
+		s.layout = l
+		s.layoutVersion = v
+		s.hasLayoutVersion = true
+		return nil
+	}
+}
+
+func (s *snowflake) validateLayoutVersion() error {
+	if !s.hasLayoutVersion {
+		return nil
+	}
+	if l, _ := lookupVersionedLayout(s.layoutVersion); s.layout != l {
+		return fmt.Errorf("%w: WithLayout overrides WithLayoutVersion", ErrInvalidLayout)
+	}
+	// Bit 63 and the tag are the version, so they cannot mean anything else.
+	if s.reservedBit || s.obfuscation {
+		return ErrUnsupportedOption
+	}
+	return nil
+}
+
+// lookupVersionedLayout returns the layout of version v, which is tagged with v unless v is 0.
+func lookupVersionedLayout(v uint8) (Layout, error) {
+	l, err := lookupLayoutVersion(v)
+	if err != nil || v == 0 {
+		return l, err
+	}
+	return l.withTag(v), nil
+}
+
+// DetectLayout returns the layout of id, which is the layout of its version from 1 to 3 if bit 63 is set,
+// and LayoutTwitterSnowflake otherwise. The IDs generated with WithReservedBit(true) in LayoutTwitterSnowflake
+// have bit 63 set with no version, so their layout is LayoutTwitterSnowflake.
+// It returns ErrInvalidLayoutVersion if the version is not registered.
+func DetectLayout(id SnowflakeID) (Layout, error) {
+	l, err := detectLayout(int64(id))
+	if err != nil {
+		return Layout{}, err
+	}
+	return l.withoutTag(), nil
+}
+
+// detectLayout returns the layout of id. For an ID with bit 63 set, the layout is tagged with the tag of id,
+// which excludes the tag from the sequence number.
+func detectLayout(id int64) (Layout, error) {
+	if !IsReservedBitSet(SnowflakeID(id)) {
+		return LayoutTwitterSnowflake, nil
+	}
+	tag := uint8(id>>layoutTagShift) & maxLayoutVersion
+	if tag == reservedBitTag {
+		return LayoutTwitterSnowflake.withTag(tag), nil
+	}
+	return lookupVersionedLayout(tag)
+}
+
+// detectLayoutOrDefault returns the layout of id, or LayoutTwitterSnowflake if the version of id is not registered.
+func detectLayoutOrDefault(id int64) Layout {
+	l, err := detectLayout(id)
+	if err != nil {
+		return LayoutTwitterSnowflake
+	}
+	return l
+}
+
+// taggable reports whether the IDs in l can be tagged, i.e., the top 2 bits of the sequence number are bits 10-11,
+// and bit 63 is unused.
+func (l Layout) taggable() bool {
+	return l.SequenceBits > layoutTagBits && l.sequenceShift()+l.SequenceBits == sequenceNumBitRange &&
+		l.TimestampBits+l.DatacenterBits+l.MachineBits+l.SequenceBits <= 63
+}
+
+func (l Layout) withTag(tag uint8) Layout {
+	l.tagged = true
+	l.tag = tag
+	return l
+}
+
+func (l Layout) withoutTag() Layout {
+	l.tagged = false
+	l.tag = 0
+	return l
+}
@@ -0,0 +1,179 @@
+package idgenerator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// layoutV1 is a layout registered as version 1 in tests.
+var layoutV1 = Layout{TimestampBits: 41, MachineBits: 10, SequenceBits: 12, TimeUnit: 10 * time.Millisecond}
+
+// registerLayoutVersion registers l as version v during the test.
+func registerLayoutVersion(t *testing.T, v uint8, l Layout) {
+	t.Helper()
+	if err := RegisterLayoutVersion(v, l); err != nil {
+		t.Fatalf("RegisterLayoutVersion() error = %v", err)
+	}
+	t.Cleanup(func() {
+		layoutVersionsMutex.Lock()
+		defer layoutVersionsMutex.Unlock()
+		delete(layoutVersions, v)
+	})
+}
+
+func TestRegisterLayoutVersion(t *testing.T) {
+	registerLayoutVersion(t, 1, layoutV1)
+
+	tests := []struct {
+		name    string
+		v       uint8
+		layout  Layout
+		wantErr error
+	}{
+		{"Same layout again", 1, layoutV1, nil},
+		{"Error version 0", 0, LayoutTwitterSnowflake, ErrInvalidLayoutVersion},
+		{"Error version 4", 4, LayoutTwitterSnowflake, ErrInvalidLayoutVersion},
+		{"Error another layout", 1, LayoutTwitterSnowflake, ErrInvalidLayoutVersion},
+		{"Error sequence number in other bits", 2, LayoutSecondResolution, ErrInvalidLayout},
+		{"Error no reserved bit", 2, LayoutDiscord, ErrInvalidLayout},
+		{"Error invalid layout", 2, Layout{MachineBits: 10, SequenceBits: 12}, ErrInvalidLayout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterLayoutVersion(tt.v, tt.layout); !errors.Is(err, tt.wantErr) {
+				t.Errorf("RegisterLayoutVersion() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithLayoutVersion(t *testing.T) {
+	registerLayoutVersion(t, 1, layoutV1)
+
+	at := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		v          uint8
+		want       int64
+		wantLayout Layout
+	}{
+		{"Version 0", 0, 11234023837724673, LayoutTwitterSnowflake},
+		{"Version 1", 1, -9222248634467290111, layoutV1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := NewSnowflakeID(WithLayoutVersion(tt.v), WithTimestamp(at), WithShardID(1007), WithSequenceNumber(1))
+			if err != nil {
+				t.Fatalf("NewSnowflakeID() error = %v", err)
+			}
+			if id != tt.want {
+				t.Errorf("NewSnowflakeID() = %v, want %v", id, tt.want)
+			}
+
+			layout, err := DetectLayout(SnowflakeID(id))
+			if err != nil {
+				t.Fatalf("DetectLayout() error = %v", err)
+			}
+			if layout != tt.wantLayout {
+				t.Errorf("DetectLayout() = %+v, want %+v", layout, tt.wantLayout)
+			}
+			got, err := ParseSnowflakeID(id, time.Time{}, Layout{})
+			if err != nil {
+				t.Fatalf("ParseSnowflakeID() error = %v", err)
+			}
+			want := SnowflakeComponents{Timestamp: at, DatacenterID: 1007 >> tt.wantLayout.MachineBits, MachineID: 1007 & tt.wantLayout.maxMachineID(), SequenceNumber: 1}
+			if got != want {
+				t.Errorf("ParseSnowflakeID() = %+v, want %+v", got, want)
+			}
+			if got := ExtractTime(id, time.Time{}); !got.Equal(at) {
+				t.Errorf("ExtractTime() = %v, want %v", got, at)
+			}
+			if got := ExtractWorkerID(id); got != 1007 {
+				t.Errorf("ExtractWorkerID() = %v, want %v", got, 1007)
+			}
+			if got := ExtractSequenceNumber(id); got != 1 {
+				t.Errorf("ExtractSequenceNumber() = %v, want %v", got, 1)
+			}
+		})
+	}
+}
+
+func TestWithLayoutVersion_Generator(t *testing.T) {
+	registerLayoutVersion(t, 1, layoutV1)
+
+	g, err := NewGenerator(WithLayoutVersion(1), WithShardID(1007))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if got := g.Layout(); got != layoutV1 {
+		t.Errorf("Layout() = %+v, want %+v", got, layoutV1)
+	}
+	if got := g.MaxSequenceNumber(); got != 1023 {
+		t.Errorf("MaxSequenceNumber() = %v, want %v", got, 1023)
+	}
+	// More IDs than the narrower sequence number in a time unit.
+	ids, err := g.NextN(3000)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	for i, id := range ids {
+		if layout, err := DetectLayout(id); err != nil || layout != layoutV1 {
+			t.Fatalf("DetectLayout(ids[%d]) = %+v, %v, want %+v", i, layout, err, layoutV1)
+		}
+		if i > 0 && id <= ids[i-1] {
+			t.Fatalf("NextN() ids[%d] = %v, want greater than %v", i, id, ids[i-1])
+		}
+	}
+}
+
+func TestWithLayoutVersion_Error(t *testing.T) {
+	registerLayoutVersion(t, 1, layoutV1)
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{"Unregistered version", []Option{WithLayoutVersion(3)}, ErrInvalidLayoutVersion},
+		{"With WithLayout", []Option{WithLayoutVersion(1), WithLayout(layoutV1)}, ErrInvalidLayout},
+		{"With WithReservedBit(true)", []Option{WithLayoutVersion(1), WithReservedBit(true)}, ErrUnsupportedOption},
+		{"With WithReservedBit(true) before", []Option{WithReservedBit(true), WithLayoutVersion(1)}, ErrUnsupportedOption},
+		{"With WithObfuscation", []Option{WithLayoutVersion(1), WithObfuscation(42)}, ErrUnsupportedOption},
+		{"Sequence number in the version bits", []Option{WithLayoutVersion(1), WithSequenceNumber(1024)}, ErrInvalidSequenceNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGenerator(tt.opts...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewGenerator() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDetectLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      SnowflakeID
+		want    Layout
+		wantErr error
+	}{
+		{"Without version", 11234023837724673, LayoutTwitterSnowflake, nil},
+		{"Reserved bit", 11234023837724673 | reservedBit, LayoutTwitterSnowflake, nil},
+		{"Unregistered version", -1, Layout{}, ErrInvalidLayoutVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectLayout(tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DetectLayout() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DetectLayout() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if _, err := ParseSnowflakeID(-1, time.Time{}, Layout{}); !errors.Is(err, ErrInvalidLayoutVersion) {
+		t.Errorf("ParseSnowflakeID() error = %v, want %v", err, ErrInvalidLayoutVersion)
+	}
+}